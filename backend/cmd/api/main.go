@@ -21,7 +21,7 @@ func main() {
 
 	// создаём Gin-роутер через внутренний серверный слой
 	// передаем конфигурацию в NewRouter
-	r := server.NewRouter(cfg)
+	r, background := server.NewRouter(cfg)
 
 	// оборачиваем Gin в стандартный http.Server для graceful shutdown
 	srv := &http.Server{
@@ -29,7 +29,7 @@ func main() {
 		Handler: cors.New(cors.Options{
 			AllowedOrigins:   []string{"http://localhost:5173", "http://localhost:3000", "http://localhost:3001", "http://localhost", "http://perf-assist.local:3001", "http://perf-assist.local"},
 			AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-			AllowedHeaders:   []string{"Content-Type"},
+			AllowedHeaders:   []string{"Content-Type", "Authorization"},
 			AllowCredentials: false,
 		}).Handler(r),
 		ReadTimeout:  5 * time.Second,
@@ -44,6 +44,9 @@ func main() {
 		}
 	}()
 
+	// запускаем воркеры очереди перф-саммари
+	background.Start()
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 	<-stop
@@ -55,5 +58,10 @@ func main() {
 		log.Printf("server shutdown error: %v", err)
 	}
 
+	// дожидаемся текущих задач; не успевшие завершиться вернутся в очередь при следующем старте
+	if err := background.Shutdown(ctx); err != nil {
+		log.Printf("background shutdown error: %v", err)
+	}
+
 	log.Println("server stopped")
 }
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// APITokenPrefix отличает персональные API-токены от сессионных и упрощает поиск утёкших токенов
+const APITokenPrefix = "pat_"
+
+// apiTokenDisplayLength — сколько первых символов токена хранится для отображения в списке
+const apiTokenDisplayLength = 12
+
+// ErrInvalidAPIToken возвращается для неизвестного или отозванного API-токена
+var ErrInvalidAPIToken = errors.New("invalid api token")
+
+// Области доступа API-токенов: <ресурс>:read для GET-запросов и <ресурс>:write для остальных,
+// write включает read. Токен без областей имеет полный доступ, как и веб-сессия
+const (
+	ScopeEntriesRead  = "entries:read"
+	ScopeEntriesWrite = "entries:write"
+	ScopeGoalsRead    = "goals:read"
+	ScopeGoalsWrite   = "goals:write"
+	ScopePerfRead     = "perf:read"
+	ScopePerfWrite    = "perf:write"
+	ScopeProfileRead  = "profile:read"
+	ScopeProfileWrite = "profile:write"
+)
+
+// knownScopes — все допустимые области доступа
+var knownScopes = map[string]bool{
+	ScopeEntriesRead: true, ScopeEntriesWrite: true,
+	ScopeGoalsRead: true, ScopeGoalsWrite: true,
+	ScopePerfRead: true, ScopePerfWrite: true,
+	ScopeProfileRead: true, ScopeProfileWrite: true,
+}
+
+// IsKnownScope проверяет, что область доступа поддерживается
+func IsKnownScope(scope string) bool {
+	return knownScopes[scope]
+}
+
+// NewAPIToken генерирует новый API-токен и возвращает его вместе с хешем и префиксом для отображения
+func NewAPIToken() (token, hash, displayPrefix string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", "", err
+	}
+	token = APITokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashAPIToken(token), token[:apiTokenDisplayLength], nil
+}
+
+// HashAPIToken возвращает SHA-256 хеш токена. У случайного 256-битного токена
+// медленный хеш вроде bcrypt не нужен, а поиск по хешу остаётся индексным
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// isAPIToken проверяет, что предъявлен персональный API-токен, а не сессионный
+func isAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
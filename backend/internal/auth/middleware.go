@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalKey — ключ gin-контекста, под которым middleware сохраняет аутентифицированного субъекта
+const principalKey = "auth.principal"
+
+// Principal — от чьего имени выполняется запрос
+type Principal struct {
+	UserID string
+	// Scopes — области доступа API-токена; nil означает полный доступ (веб-сессия или токен без ограничений)
+	Scopes []string
+	// APITokenID непустой, если запрос аутентифицирован API-токеном
+	APITokenID string
+}
+
+// APITokenAuthenticator проверяет персональный API-токен.
+// Для неизвестного или отозванного токена возвращает ErrInvalidAPIToken
+type APITokenAuthenticator interface {
+	Execute(token string) (Principal, error)
+}
+
+// Middleware проверяет заголовок Authorization: Bearer <token> и сохраняет субъекта в контексте.
+// Принимаются как сессионные токены, так и персональные API-токены (с префиксом pat_).
+// devUserID — только для локальной разработки: если задан, запросы без заголовка выполняются от его имени
+func Middleware(sessions *SessionTokens, apiTokens APITokenAuthenticator, devUserID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" && devUserID != "" {
+			c.Set(principalKey, Principal{UserID: devUserID})
+			c.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authorization required"})
+			return
+		}
+
+		if isAPIToken(token) {
+			principal, err := apiTokens.Execute(token)
+			if errors.Is(err, ErrInvalidAPIToken) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			}
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to verify api token"})
+				return
+			}
+			c.Set(principalKey, principal)
+			c.Next()
+			return
+		}
+
+		userID, err := sessions.Verify(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set(principalKey, Principal{UserID: userID})
+		c.Next()
+	}
+}
+
+// RequireResourceScope пропускает запрос, если у API-токена есть доступ к ресурсу:
+// <resource>:read для GET и HEAD, <resource>:write для остальных методов
+func RequireResourceScope(resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := resource + ":write"
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = resource + ":read"
+		}
+		requireScope(c, scope)
+	}
+}
+
+// RequireScope пропускает запрос, только если у API-токена есть указанная область доступа.
+// Нужен для GET-ручек, которые что-то меняют, например SSE-генерации саммари
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requireScope(c, scope)
+	}
+}
+
+// requireScope прерывает запрос с 403, если области доступа нет
+func requireScope(c *gin.Context, scope string) {
+	if !CurrentPrincipal(c).HasScope(scope) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "api token lacks scope " + scope})
+		return
+	}
+	c.Next()
+}
+
+// RequireSession пропускает только запросы веб-сессии. Управлять API-токенами
+// с помощью API-токена нельзя — иначе токен с узкими правами мог бы выпустить себе более широкий
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if CurrentPrincipal(c).APITokenID != "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "api tokens cannot be managed with an api token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// HasScope проверяет наличие области доступа. <resource>:write включает <resource>:read
+func (p Principal) HasScope(scope string) bool {
+	if p.Scopes == nil {
+		return true
+	}
+	write := scope
+	if resource, ok := strings.CutSuffix(scope, ":read"); ok {
+		write = resource + ":write"
+	}
+	for _, s := range p.Scopes {
+		if s == scope || s == write {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentPrincipal возвращает субъекта, аутентифицированного Middleware
+func CurrentPrincipal(c *gin.Context) Principal {
+	principal, _ := c.Get(principalKey)
+	p, _ := principal.(Principal)
+	return p
+}
+
+// UserID возвращает ID пользователя, аутентифицированного Middleware.
+// Для ручек за Middleware значение всегда непустое
+func UserID(c *gin.Context) string {
+	return CurrentPrincipal(c).UserID
+}
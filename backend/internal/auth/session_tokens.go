@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken возвращается для токена с неверным форматом или подписью
+	ErrInvalidToken = errors.New("invalid session token")
+	// ErrTokenExpired возвращается для токена с истёкшим сроком действия
+	ErrTokenExpired = errors.New("session token expired")
+)
+
+// sessionClaims — полезная нагрузка сессионного токена
+type sessionClaims struct {
+	UserID    string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// SessionTokens выпускает и проверяет сессионные токены вида base64(claims).base64(hmac-sha256).
+// Токены не хранятся на сервере: проверка сводится к сверке подписи и срока действия
+type SessionTokens struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSessionTokens создает новый экземпляр SessionTokens
+func NewSessionTokens(secret []byte, ttl time.Duration) *SessionTokens {
+	return &SessionTokens{
+		secret: secret,
+		ttl:    ttl,
+	}
+}
+
+// Issue выпускает токен для пользователя и возвращает его вместе с моментом истечения
+func (t *SessionTokens) Issue(userID string) (string, time.Time) {
+	expiresAt := time.Now().UTC().Add(t.ttl).Truncate(time.Second)
+
+	// Маршалинг структуры из строки и числа не может завершиться ошибкой
+	payload, _ := json.Marshal(sessionClaims{UserID: userID, ExpiresAt: expiresAt.Unix()})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + t.sign(encoded), expiresAt
+}
+
+// Verify проверяет подпись и срок действия токена и возвращает ID пользователя
+func (t *SessionTokens) Verify(token string) (string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(t.sign(encoded))) {
+		return "", ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	var claims sessionClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.UserID == "" {
+		return "", ErrInvalidToken
+	}
+
+	if time.Now().UTC().Unix() >= claims.ExpiresAt {
+		return "", ErrTokenExpired
+	}
+
+	return claims.UserID, nil
+}
+
+// sign возвращает HMAC-SHA256 подпись закодированной полезной нагрузки
+func (t *SessionTokens) sign(encoded string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
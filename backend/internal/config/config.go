@@ -13,6 +13,42 @@ type Config struct {
 	DBUser     string
 	DBPassword string
 	DBName     string
+
+	// Настройки LLM-провайдера
+	LLMProvider       string // openai | ollama | fake; обязателен, fake — только для разработки и тестов
+	LLMBaseURL        string
+	LLMAPIKey         string
+	LLMModel          string
+	LLMTimeoutSeconds int
+	// Бюджет токенов; 0 — значение по умолчанию для провайдера
+	LLMContextTokens   int
+	LLMMaxOutputTokens int
+
+	// Настройки провайдера эмбеддингов; пустые значения берутся из настроек LLM
+	EmbeddingProvider string // fake | openai | ollama | none
+	EmbeddingBaseURL  string
+	EmbeddingAPIKey   string
+	EmbeddingModel    string
+
+	// Количество воркеров очереди генерации перф-саммари
+	SummaryWorkers int
+	// Фоновое обогащение записей моделью (llm_enriched)
+	EntryEnrichmentEnabled bool
+
+	// Синхронизация с каталогом ежедневных заметок Obsidian/Logseq; пустой каталог — синхронизация выключена
+	DailyNotesDir         string
+	DailyNotesUserID      string // владелец записей; пустой — AuthDevUserID
+	DailyNotesPlanHeading string
+	DailyNotesFactHeading string
+
+	// Каталог с git-репозиториями, из истории которых можно импортировать факты; пустой — импорт выключен
+	GitImportRoot    string
+	GitImportPerUser bool // у каждого пользователя свой подкаталог GitImportRoot/<user_id>
+
+	// Настройки аутентификации
+	AuthSecret          string // ключ подписи сессионных токенов; пустой — случайный на время жизни процесса
+	AuthSessionTTLHours int
+	AuthDevUserID       string // только для локальной разработки: пользователь для запросов без токена
 }
 
 // New создает новый экземпляр Config с значениями по умолчанию или из environment variables
@@ -24,6 +60,34 @@ func New() *Config {
 		DBUser:     getEnv("DB_USER", "perfassist"),
 		DBPassword: getEnv("DB_PASSWORD", "perfassist"),
 		DBName:     getEnv("DB_NAME", "perfassist"),
+
+		LLMProvider:        getEnv("LLM_PROVIDER", ""),
+		LLMBaseURL:         getEnv("LLM_BASE_URL", ""),
+		LLMAPIKey:          getEnv("LLM_API_KEY", ""),
+		LLMModel:           getEnv("LLM_MODEL", ""),
+		LLMTimeoutSeconds:  getEnvAsInt("LLM_TIMEOUT_SECONDS", 120),
+		LLMContextTokens:   getEnvAsInt("LLM_CONTEXT_TOKENS", 0),
+		LLMMaxOutputTokens: getEnvAsInt("LLM_MAX_OUTPUT_TOKENS", 0),
+
+		EmbeddingProvider: getEnv("EMBEDDING_PROVIDER", ""),
+		EmbeddingBaseURL:  getEnv("EMBEDDING_BASE_URL", ""),
+		EmbeddingAPIKey:   getEnv("EMBEDDING_API_KEY", ""),
+		EmbeddingModel:    getEnv("EMBEDDING_MODEL", ""),
+
+		SummaryWorkers:         getEnvAsInt("SUMMARY_WORKERS", 2),
+		EntryEnrichmentEnabled: getEnvAsBool("ENTRY_ENRICHMENT_ENABLED", true),
+
+		DailyNotesDir:         getEnv("DAILY_NOTES_DIR", ""),
+		DailyNotesUserID:      getEnv("DAILY_NOTES_USER_ID", ""),
+		DailyNotesPlanHeading: getEnv("DAILY_NOTES_PLAN_HEADING", "Plan"),
+		DailyNotesFactHeading: getEnv("DAILY_NOTES_FACT_HEADING", "Fact"),
+
+		GitImportRoot:    getEnv("GIT_IMPORT_ROOT", ""),
+		GitImportPerUser: getEnvAsBool("GIT_IMPORT_PER_USER", false),
+
+		AuthSecret:          getEnv("AUTH_SECRET", ""),
+		AuthSessionTTLHours: getEnvAsInt("AUTH_SESSION_TTL_HOURS", 168),
+		AuthDevUserID:       getEnv("AUTH_DEV_USER_ID", ""),
 	}
 
 	// Если порт не начинается с двоеточия, добавим его
@@ -50,3 +114,12 @@ func getEnvAsInt(name string, defaultVal int) int {
 	}
 	return defaultVal
 }
+
+// getEnvAsBool возвращает значение переменной окружения как bool или значение по умолчанию
+func getEnvAsBool(name string, defaultVal bool) bool {
+	valueStr := getEnv(name, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultVal
+}
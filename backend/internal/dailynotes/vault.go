@@ -0,0 +1,274 @@
+// Package dailynotes читает и пишет ежедневные заметки в каталоге Obsidian или Logseq: файлы YYYY-MM-DD.md,
+// в которых план и факт дня лежат в разделах под заданными заголовками ("## Plan", "## Fact").
+// Остальное содержимое заметки сервису не принадлежит и при записи сохраняется как есть
+package dailynotes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ErrNoteChanged возвращается WriteSection, если файл заметки изменился после того, как его прочитали
+var ErrNoteChanged = errors.New("daily note changed since it was read")
+
+// newSectionLevel — уровень заголовка раздела, который добавляется в заметку, если его там ещё нет
+const newSectionLevel = 2
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	fencePattern    = regexp.MustCompile("^\\s*(```|~~~)")
+	noteNamePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\.md$`)
+)
+
+// Note представляет ежедневную заметку. Sections содержит только разделы, заголовки которых есть в файле:
+// раздел с пустым текстом означает пустую запись, а отсутствие раздела — что заметка о записи ничего не говорит.
+// Hash — SHA-256 содержимого файла; у заметки, которой нет на диске, он пустой
+type Note struct {
+	Date     string
+	Sections map[repositories.EntryType]string
+	ModTime  time.Time
+	Hash     string
+}
+
+// NoteFile описывает файл заметки без его содержимого: по времени изменения и размеру видно, что файл менялся
+type NoteFile struct {
+	Date    string
+	ModTime time.Time
+	Size    int64
+}
+
+// Vault представляет каталог ежедневных заметок
+type Vault struct {
+	dir      string
+	headings map[repositories.EntryType]string
+}
+
+// NewVault создает новый экземпляр Vault; заголовки разделов сравниваются без учёта регистра и двоеточия в конце
+func NewVault(dir, planHeading, factHeading string) *Vault {
+	return &Vault{
+		dir: dir,
+		headings: map[repositories.EntryType]string{
+			repositories.EntryTypePlan: planHeading,
+			repositories.EntryTypeFact: factHeading,
+		},
+	}
+}
+
+// Dir возвращает каталог заметок
+func (v *Vault) Dir() string {
+	return v.dir
+}
+
+// List возвращает файлы заметок каталога, упорядоченные по дате, не читая их содержимое.
+// Вложенные каталоги и файлы с другими именами пропускаются
+func (v *Vault) List() ([]NoteFile, error) {
+	entries, err := os.ReadDir(v.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []NoteFile
+	for _, e := range entries {
+		m := noteNamePattern.FindStringSubmatch(e.Name())
+		if m == nil || !e.Type().IsRegular() {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", m[1]); err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// Файл удалили между чтением каталога и запросом его свойств
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, NoteFile{Date: m[1], ModTime: info.ModTime().UTC(), Size: info.Size()})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Date < files[j].Date
+	})
+	return files, nil
+}
+
+// Read читает заметку за дату. Если файла нет, возвращает ошибку fs.ErrNotExist
+func (v *Vault) Read(date string) (Note, error) {
+	path := v.path(date)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Note{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return Note{}, err
+	}
+
+	return v.parse(date, content, info.ModTime()), nil
+}
+
+// parse разбирает содержимое заметки на разделы записей
+func (v *Vault) parse(date string, content []byte, modTime time.Time) Note {
+	lines := splitLines(string(content))
+	note := Note{Date: date, Sections: make(map[repositories.EntryType]string), ModTime: modTime.UTC(), Hash: contentHash(content)}
+	for entryType := range v.headings {
+		if s, ok := v.findSection(lines, entryType); ok {
+			note.Sections[entryType] = strings.TrimSpace(strings.Join(lines[s.bodyStart:s.bodyEnd], "\n"))
+		}
+	}
+	return note
+}
+
+// WriteSection записывает text в раздел заметки за дату. Если заметки нет, она создаётся,
+// если в ней нет раздела — он добавляется в конец. Файл заменяется целиком через переименование,
+// чтобы редактор не увидел наполовину записанную заметку.
+// base — заметка в том виде, в каком её прочитал вызывающий (пустая, если файла не было). Если с тех пор
+// файл изменился, например его отредактировали в редакторе, он не перезаписывается и возвращается ErrNoteChanged.
+// Возвращает заметку в том виде, в каком она записана
+func (v *Vault) WriteSection(date string, entryType repositories.EntryType, text string, base Note) (Note, error) {
+	path := v.path(date)
+	mode := fs.FileMode(0o644)
+	content, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if contentHash(content) != base.Hash {
+			return Note{}, ErrNoteChanged
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		if base.Hash != "" {
+			return Note{}, ErrNoteChanged
+		}
+		content = nil
+	default:
+		return Note{}, err
+	}
+
+	lines := splitLines(string(content))
+	var body []string
+	if text = strings.TrimSpace(text); text != "" {
+		body = splitLines(text)
+	}
+
+	if s, ok := v.findSection(lines, entryType); ok {
+		// Пустая строка отделяет текст раздела от следующего заголовка
+		if s.bodyEnd < len(lines) {
+			body = append(body, "")
+		}
+		lines = append(lines[:s.bodyStart], append(body, lines[s.bodyEnd:]...)...)
+	} else {
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, strings.Repeat("#", newSectionLevel)+" "+v.headings[entryType])
+		lines = append(lines, body...)
+	}
+
+	content = []byte(strings.Join(lines, "\n") + "\n")
+	if err := writeFileAtomic(path, content, mode); err != nil {
+		return Note{}, err
+	}
+	modTime := time.Now()
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+	return v.parse(date, content, modTime), nil
+}
+
+// path возвращает путь к заметке за дату
+func (v *Vault) path(date string) string {
+	return filepath.Join(v.dir, date+".md")
+}
+
+// section описывает положение раздела в строках заметки: текст раздела — строки [bodyStart, bodyEnd)
+type section struct {
+	bodyStart int
+	bodyEnd   int
+}
+
+// findSection находит первый раздел с заголовком записи типа entryType. Раздел заканчивается
+// на следующем заголовке того же или более высокого уровня; строки внутри блоков кода заголовками не считаются
+func (v *Vault) findSection(lines []string, entryType repositories.EntryType) (section, bool) {
+	want := v.headings[entryType]
+	level := 0
+	s := section{}
+	inFence := false
+	for i, line := range lines {
+		if fencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		m := headingPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if level > 0 && len(m[1]) <= level {
+			s.bodyEnd = i
+			return s, true
+		}
+		if level == 0 && strings.EqualFold(strings.TrimSuffix(strings.TrimSpace(m[2]), ":"), want) {
+			level = len(m[1])
+			s.bodyStart = i + 1
+		}
+	}
+	if level == 0 {
+		return section{}, false
+	}
+	s.bodyEnd = len(lines)
+	return s, true
+}
+
+// contentHash возвращает SHA-256 содержимого файла в hex
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// splitLines разбивает текст на строки без завершающего перевода строки
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// writeFileAtomic записывает файл через временный файл в том же каталоге и переименование
+func writeFileAtomic(path string, content []byte, mode fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".perf-assist-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,261 @@
+package dailynotes
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+func TestVaultReadSections(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		wantPlan string
+		wantFact string
+		// noFact — раздела факта в заметке нет, а не пустой раздел
+		noFact bool
+	}{
+		{
+			name:     "both sections",
+			content:  "# Monday\n\n## Plan\n- do A\n\n## Fact\n- did A\n",
+			wantPlan: "- do A",
+			wantFact: "- did A",
+		},
+		{
+			name:     "heading with colon and any case",
+			content:  "### plan:\n- do A\n",
+			wantPlan: "- do A",
+			noFact:   true,
+		},
+		{
+			name:     "nested headings stay in the section",
+			content:  "## Plan\n- do A\n\n### Details\nmore\n\n## Other\nnot plan\n",
+			wantPlan: "- do A\n\n### Details\nmore",
+			noFact:   true,
+		},
+		{
+			name:     "section ends at a higher heading",
+			content:  "## Plan\n- do A\n# Week\nnot plan\n",
+			wantPlan: "- do A",
+			noFact:   true,
+		},
+		{
+			name:     "headings in fenced code are text",
+			content:  "## Plan\n```\n## Fact\n```\n~~~\n# Fact\n~~~\n",
+			wantPlan: "```\n## Fact\n```\n~~~\n# Fact\n~~~",
+			noFact:   true,
+		},
+		{
+			name:     "empty section",
+			content:  "## Plan\n\n## Fact\n",
+			wantPlan: "",
+			wantFact: "",
+		},
+		{
+			name:    "missing sections",
+			content: "# Monday\njust notes\n",
+			noFact:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewVault(t.TempDir(), "Plan", "Fact")
+			writeNote(t, v, "2026-01-05", tc.content)
+
+			note, err := v.Read("2026-01-05")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := note.Sections[repositories.EntryTypePlan]; got != tc.wantPlan {
+				t.Fatalf("plan = %q, want %q", got, tc.wantPlan)
+			}
+			fact, found := note.Sections[repositories.EntryTypeFact]
+			if found == tc.noFact {
+				t.Fatalf("fact section found = %v, want %v", found, !tc.noFact)
+			}
+			if fact != tc.wantFact {
+				t.Fatalf("fact = %q, want %q", fact, tc.wantFact)
+			}
+		})
+	}
+}
+
+func TestVaultWriteSection(t *testing.T) {
+	cases := []struct {
+		name      string
+		content   string
+		noFile    bool
+		entryType repositories.EntryType
+		text      string
+		want      string
+	}{
+		{
+			name:      "replaces the section and keeps the rest",
+			content:   "# Monday\n\n## Plan\n- do A\n\n## Other\nkeep me\n",
+			entryType: repositories.EntryTypePlan,
+			text:      "- do B",
+			want:      "# Monday\n\n## Plan\n- do B\n\n## Other\nkeep me\n",
+		},
+		{
+			name:      "replaces nested headings of the section",
+			content:   "## Plan\n- do A\n### Details\nmore\n## Fact\n- did A\n",
+			entryType: repositories.EntryTypePlan,
+			text:      "- do B",
+			want:      "## Plan\n- do B\n\n## Fact\n- did A\n",
+		},
+		{
+			name:      "ignores headings in fenced code",
+			content:   "## Notes\n```\n## Fact\nold\n```\n",
+			entryType: repositories.EntryTypeFact,
+			text:      "- did A",
+			want:      "## Notes\n```\n## Fact\nold\n```\n\n## Fact\n- did A\n",
+		},
+		{
+			name:      "appends a missing section",
+			content:   "# Monday\nnotes\n\n\n",
+			entryType: repositories.EntryTypeFact,
+			text:      "- did A",
+			want:      "# Monday\nnotes\n\n## Fact\n- did A\n",
+		},
+		{
+			name:      "creates a missing note",
+			noFile:    true,
+			entryType: repositories.EntryTypePlan,
+			text:      "- do A",
+			want:      "## Plan\n- do A\n",
+		},
+		{
+			name:      "clears the section",
+			content:   "## Plan\n- do A\n## Fact\n- did A\n",
+			entryType: repositories.EntryTypePlan,
+			text:      "",
+			want:      "## Plan\n\n## Fact\n- did A\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewVault(t.TempDir(), "Plan", "Fact")
+			var base Note
+			if !tc.noFile {
+				writeNote(t, v, "2026-01-05", tc.content)
+				var err error
+				if base, err = v.Read("2026-01-05"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			written, err := v.WriteSection("2026-01-05", tc.entryType, tc.text, base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := readNote(t, v, "2026-01-05"); got != tc.want {
+				t.Fatalf("note = %q, want %q", got, tc.want)
+			}
+			if written.Sections[tc.entryType] != tc.text {
+				t.Fatalf("written section = %q, want %q", written.Sections[tc.entryType], tc.text)
+			}
+			reread, err := v.Read("2026-01-05")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reread.Hash != written.Hash {
+				t.Fatal("hash of the written note differs from the file")
+			}
+		})
+	}
+}
+
+func TestVaultWriteSectionNoteChanged(t *testing.T) {
+	t.Run("edited after read", func(t *testing.T) {
+		v := NewVault(t.TempDir(), "Plan", "Fact")
+		writeNote(t, v, "2026-01-05", "## Plan\n- do A\n")
+		base, err := v.Read("2026-01-05")
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeNote(t, v, "2026-01-05", "## Plan\n- edited in the editor\n")
+
+		if _, err := v.WriteSection("2026-01-05", repositories.EntryTypePlan, "- do B", base); !errors.Is(err, ErrNoteChanged) {
+			t.Fatalf("want ErrNoteChanged, got %v", err)
+		}
+		if got := readNote(t, v, "2026-01-05"); got != "## Plan\n- edited in the editor\n" {
+			t.Fatalf("note was overwritten: %q", got)
+		}
+	})
+
+	t.Run("created after a read of a missing note", func(t *testing.T) {
+		v := NewVault(t.TempDir(), "Plan", "Fact")
+		writeNote(t, v, "2026-01-05", "## Plan\n- created in the editor\n")
+
+		if _, err := v.WriteSection("2026-01-05", repositories.EntryTypePlan, "- do B", Note{}); !errors.Is(err, ErrNoteChanged) {
+			t.Fatalf("want ErrNoteChanged, got %v", err)
+		}
+	})
+
+	t.Run("deleted after read", func(t *testing.T) {
+		v := NewVault(t.TempDir(), "Plan", "Fact")
+		writeNote(t, v, "2026-01-05", "## Plan\n- do A\n")
+		base, err := v.Read("2026-01-05")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Remove(v.path("2026-01-05")); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := v.WriteSection("2026-01-05", repositories.EntryTypePlan, "- do B", base); !errors.Is(err, ErrNoteChanged) {
+			t.Fatalf("want ErrNoteChanged, got %v", err)
+		}
+		if _, err := os.Stat(v.path("2026-01-05")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("deleted note was recreated: %v", err)
+		}
+	})
+}
+
+func TestVaultList(t *testing.T) {
+	v := NewVault(t.TempDir(), "Plan", "Fact")
+	writeNote(t, v, "2026-01-06", "## Plan\n")
+	writeNote(t, v, "2026-01-05", "## Plan\n")
+	for _, name := range []string{"notes.md", "2026-13-40.md", "2026-01-07.txt"} {
+		if err := os.WriteFile(filepath.Join(v.Dir(), name), []byte("## Plan\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(v.Dir(), "2026-01-08.md"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := v.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0].Date != "2026-01-05" || files[1].Date != "2026-01-06" {
+		t.Fatalf("got %+v, want notes for 2026-01-05 and 2026-01-06", files)
+	}
+	if files[0].Size != int64(len("## Plan\n")) {
+		t.Fatalf("size = %d", files[0].Size)
+	}
+}
+
+// writeNote записывает заметку за дату
+func writeNote(t *testing.T, v *Vault, date, content string) {
+	t.Helper()
+	if err := os.WriteFile(v.path(date), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readNote возвращает содержимое заметки за дату
+func readNote(t *testing.T, v *Vault, date string) string {
+	t.Helper()
+	content, err := os.ReadFile(v.path(date))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
@@ -0,0 +1,159 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"strings"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// docxContentType — MIME-тип документа Word
+const docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// Служебные части минимального пакета DOCX: типы содержимого, связи, стили заголовков и маркированный список.
+// Заголовки используют стандартные стили Title/Heading1/Heading2, поэтому при вставке в другой
+// документ или инструмент ревью структура сохраняется
+const (
+	docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"/>
+</Types>`
+
+	docxPackageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+	docxDocumentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>
+</Relationships>`
+
+	docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults>
+<w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:eastAsia="Calibri" w:cs="Calibri"/><w:sz w:val="22"/></w:rPr></w:rPrDefault>
+<w:pPrDefault><w:pPr><w:spacing w:after="120"/></w:pPr></w:pPrDefault>
+</w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr><w:spacing w:after="240"/></w:pPr><w:rPr><w:b/><w:sz w:val="36"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="30"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="26"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="ListParagraph"><w:name w:val="List Paragraph"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:after="60"/><w:ind w:left="720"/></w:pPr></w:style>
+</w:styles>`
+
+	docxNumbering = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:abstractNum w:abstractNumId="0"><w:multiLevelType w:val="singleLevel"/><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="•"/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="720" w:hanging="360"/></w:pPr></w:lvl></w:abstractNum>
+<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>
+</w:numbering>`
+)
+
+// perfSummaryDOCX собирает DOCX с той же структурой, что и шаблоны: заголовок, цели, Context/Outputs/Outcomes
+func perfSummaryDOCX(summary repositories.PerfSummary) ([]byte, error) {
+	var doc docxBody
+	doc.paragraph("Title", "Perf summary: "+summary.PeriodStart+" — "+summary.PeriodEnd)
+	if len(summary.Tags) > 0 {
+		doc.paragraph("", "Tags: "+strings.Join(summary.Tags, ", "))
+	}
+	doc.text(summary.SummaryText)
+	for _, goal := range summary.Goals {
+		doc.paragraph("Heading1", goal.Title)
+		if goal.Context != "" {
+			doc.paragraph("Heading2", "Context")
+			doc.text(goal.Context)
+		}
+		if len(goal.Outputs) > 0 {
+			doc.paragraph("Heading2", "Outputs")
+			for _, o := range goal.Outputs {
+				doc.bullet(o)
+			}
+		}
+		if len(goal.Outcomes) > 0 {
+			doc.paragraph("Heading2", "Outcomes")
+			for _, o := range goal.Outcomes {
+				doc.bullet(o)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRels},
+		{"word/_rels/document.xml.rels", docxDocumentRels},
+		{"word/styles.xml", docxStyles},
+		{"word/numbering.xml", docxNumbering},
+		{"word/document.xml", doc.document()},
+	}
+	for _, part := range parts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// docxBody накапливает абзацы word/document.xml
+type docxBody struct {
+	b strings.Builder
+}
+
+// paragraph добавляет абзац со стилем style; пустой style — обычный текст
+func (d *docxBody) paragraph(style, text string) {
+	d.b.WriteString("<w:p>")
+	if style != "" {
+		d.b.WriteString(`<w:pPr><w:pStyle w:val="` + style + `"/></w:pPr>`)
+	}
+	d.run(text)
+	d.b.WriteString("</w:p>")
+}
+
+// text добавляет многострочный текст: каждая непустая строка — отдельный абзац
+func (d *docxBody) text(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			d.paragraph("", line)
+		}
+	}
+}
+
+// bullet добавляет пункт маркированного списка
+func (d *docxBody) bullet(text string) {
+	d.b.WriteString(`<w:p><w:pPr><w:pStyle w:val="ListParagraph"/><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr>`)
+	d.run(text)
+	d.b.WriteString("</w:p>")
+}
+
+// run добавляет фрагмент текста с экранированием XML
+func (d *docxBody) run(text string) {
+	d.b.WriteString(`<w:r><w:t xml:space="preserve">`)
+	xml.EscapeText(&d.b, []byte(text))
+	d.b.WriteString("</w:t></w:r>")
+}
+
+// document возвращает word/document.xml с накопленными абзацами и полями страницы A4
+func (d *docxBody) document() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		d.b.String() +
+		`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr></w:body></w:document>`
+}
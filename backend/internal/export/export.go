@@ -0,0 +1,88 @@
+// Package export рендерит перф-саммари в файлы для вставки в инструмент перф-ревью:
+// Markdown, HTML и текст — по шаблонам из templates, DOCX — средствами стандартной библиотеки
+package export
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Format определяет формат экспорта
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+	FormatText     Format = "txt"
+	FormatDOCX     Format = "docx"
+)
+
+// ErrUnsupportedFormat возвращается для формата, который не поддерживается
+var ErrUnsupportedFormat = errors.New("export format must be one of md, html, docx, txt")
+
+// File представляет готовый к отдаче файл
+type File struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// templateFuncs доступны во всех шаблонах
+var templateFuncs = map[string]any{
+	"join": strings.Join,
+	"inc":  func(i int) int { return i + 1 },
+}
+
+var (
+	markdownTemplate = texttemplate.Must(texttemplate.New("perf_summary.md.tmpl").Funcs(templateFuncs).ParseFS(templateFiles, "templates/perf_summary.md.tmpl"))
+	textTemplate     = texttemplate.Must(texttemplate.New("perf_summary.txt.tmpl").Funcs(templateFuncs).ParseFS(templateFiles, "templates/perf_summary.txt.tmpl"))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("perf_summary.html.tmpl").Funcs(templateFuncs).ParseFS(templateFiles, "templates/perf_summary.html.tmpl"))
+)
+
+// executor — общий интерфейс text/template и html/template
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// PerfSummary рендерит перф-саммари в заданном формате.
+// Источники пунктов и предупреждения о метриках в экспорт не попадают — это текст для ревью
+func PerfSummary(summary repositories.PerfSummary, format Format) (File, error) {
+	name := "perf-summary-" + summary.PeriodStart + "-" + summary.PeriodEnd + "." + string(format)
+
+	switch format {
+	case FormatMarkdown:
+		return render(markdownTemplate, summary, name, "text/markdown; charset=utf-8")
+	case FormatHTML:
+		return render(htmlTemplate, summary, name, "text/html; charset=utf-8")
+	case FormatText:
+		return render(textTemplate, summary, name, "text/plain; charset=utf-8")
+	case FormatDOCX:
+		content, err := perfSummaryDOCX(summary)
+		if err != nil {
+			return File{}, err
+		}
+		return File{Name: name, ContentType: docxContentType, Content: content}, nil
+	default:
+		return File{}, ErrUnsupportedFormat
+	}
+}
+
+// render выполняет шаблон и собирает файл
+func render(tmpl executor, summary repositories.PerfSummary, name, contentType string) (File, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, summary); err != nil {
+		return File{}, err
+	}
+	content := bytes.TrimSpace(buf.Bytes())
+	return File{Name: name, ContentType: contentType, Content: append(content, '\n')}, nil
+}
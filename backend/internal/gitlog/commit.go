@@ -0,0 +1,115 @@
+package gitlog
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit представляет коммит. AuthorTime — время в часовом поясе автора, как его показывает git log
+type Commit struct {
+	Hash        Hash
+	Parents     []Hash
+	AuthorName  string
+	AuthorEmail string
+	AuthorTime  time.Time
+	// Subject — первый абзац сообщения одной строкой, как %s в git log
+	Subject string
+}
+
+// parseCommit разбирает тело объекта commit
+func parseCommit(h Hash, data []byte) (Commit, error) {
+	commit := Commit{Hash: h}
+	headers, message, _ := bytes.Cut(data, []byte("\n\n"))
+
+	authorFound := false
+	for _, line := range strings.Split(string(headers), "\n") {
+		// Строки с пробелом в начале продолжают многострочный заголовок (gpgsig, mergetag)
+		if line == "" || line[0] == ' ' {
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "parent":
+			p, ok := ParseHash(value)
+			if !ok {
+				return Commit{}, fmt.Errorf("malformed parent in commit %s", h)
+			}
+			commit.Parents = append(commit.Parents, p)
+		case "author":
+			name, email, at, err := parseSignature(value)
+			if err != nil {
+				return Commit{}, fmt.Errorf("malformed author in commit %s: %w", h, err)
+			}
+			commit.AuthorName, commit.AuthorEmail, commit.AuthorTime = name, email, at
+			authorFound = true
+		}
+	}
+	if !authorFound {
+		return Commit{}, fmt.Errorf("commit %s has no author", h)
+	}
+
+	subject, _, _ := strings.Cut(strings.TrimLeft(strings.ReplaceAll(string(message), "\r\n", "\n"), "\n"), "\n\n")
+	commit.Subject = strings.Join(strings.Fields(subject), " ")
+	return commit, nil
+}
+
+// parseSignature разбирает "Name <email> 1700000000 +0300"
+func parseSignature(value string) (name, email string, at time.Time, err error) {
+	open := strings.LastIndex(value, "<")
+	closing := strings.LastIndex(value, ">")
+	if open < 0 || closing < open {
+		return "", "", time.Time{}, fmt.Errorf("no email in %q", value)
+	}
+	name = strings.TrimSpace(value[:open])
+	email = value[open+1 : closing]
+
+	fields := strings.Fields(value[closing+1:])
+	if len(fields) != 2 {
+		return "", "", time.Time{}, fmt.Errorf("no date in %q", value)
+	}
+	seconds, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	offset, err := parseTimezone(fields[1])
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return name, email, time.Unix(seconds, 0).In(time.FixedZone(fields[1], offset)), nil
+}
+
+// parseTimezone переводит смещение "+0300" в секунды
+func parseTimezone(tz string) (int, error) {
+	if len(tz) != 5 || (tz[0] != '+' && tz[0] != '-') {
+		return 0, fmt.Errorf("malformed timezone %q", tz)
+	}
+	hours, err := strconv.Atoi(tz[1:3])
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.Atoi(tz[3:5])
+	if err != nil {
+		return 0, err
+	}
+	offset := hours*3600 + minutes*60
+	if tz[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// tagTarget возвращает объект, на который указывает аннотированный тег
+func tagTarget(data []byte) (Hash, bool) {
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(line, "object "); ok {
+			return ParseHash(value)
+		}
+		if line == "" {
+			break
+		}
+	}
+	return Hash{}, false
+}
@@ -0,0 +1,247 @@
+package gitlog
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// Фикстуры собирают репозитории прямо в тестах, без git: loose-объекты, pack-файлы с индексом версии 2
+// и дельтами обоих видов. Так в них можно точно разложить объекты и испортить нужный байт
+
+// testTree — хеш дерева в коммитах фикстур; сами деревья пакет не читает
+var testTree = Hash{0x4b, 0x82, 0x5d, 0xc6}
+
+// testRepo — репозиторий в каталоге теста: path — рабочий каталог, gitDir — его .git
+type testRepo struct {
+	t      *testing.T
+	path   string
+	gitDir string
+}
+
+// newTestRepo создаёт пустой репозиторий с HEAD на refs/heads/main
+func newTestRepo(t *testing.T, path string) *testRepo {
+	t.Helper()
+	r := &testRepo{t: t, path: path, gitDir: filepath.Join(path, ".git")}
+	for _, dir := range []string{"objects/info", "objects/pack", "refs/heads", "refs/tags"} {
+		if err := os.MkdirAll(filepath.Join(r.gitDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.writeFile("HEAD", "ref: refs/heads/main\n")
+	return r
+}
+
+// writeFile записывает файл внутри .git
+func (r *testRepo) writeFile(name, content string) {
+	r.t.Helper()
+	path := filepath.Join(r.gitDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		r.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+// setRef направляет ссылку на объект
+func (r *testRepo) setRef(name string, h Hash) {
+	r.writeFile(name, h.String()+"\n")
+}
+
+// writeLoose сохраняет объект loose-файлом и возвращает его хеш
+func (r *testRepo) writeLoose(objType string, data []byte) Hash {
+	r.t.Helper()
+	raw := append([]byte(fmt.Sprintf("%s %d\x00", objType, len(data))), data...)
+	h := Hash(sha1.Sum(raw))
+	name := h.String()
+	path := filepath.Join(r.gitDir, "objects", name[:2], name[2:])
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		r.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, zlibBytes(raw), 0o644); err != nil {
+		r.t.Fatal(err)
+	}
+	return h
+}
+
+// commitData возвращает тело коммита с автором email в часовом поясе +0300
+func commitData(parents []Hash, email string, at time.Time, message string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "tree %s\n", testTree)
+	for _, p := range parents {
+		fmt.Fprintf(&b, "parent %s\n", p)
+	}
+	fmt.Fprintf(&b, "author Dev <%s> %d +0300\n", email, at.Unix())
+	fmt.Fprintf(&b, "committer Dev <%s> %d +0300\n\n%s\n", email, at.Unix(), message)
+	return b.Bytes()
+}
+
+// objectHash возвращает хеш объекта так, как его считает git
+func objectHash(objType string, data []byte) Hash {
+	return Hash(sha1.Sum(append([]byte(fmt.Sprintf("%s %d\x00", objType, len(data))), data...)))
+}
+
+// packEntry — объект в собираемом pack-файле. Для дельты ofsBase — индекс базы в том же pack-файле,
+// refBase — хеш базы; data — готовая дельта, а hash — хеш восстановленного объекта
+type packEntry struct {
+	objType objectType
+	data    []byte
+	hash    Hash
+	ofsBase int
+	refBase *Hash
+}
+
+// fullEntry возвращает объект целиком
+func fullEntry(objType objectType, typeName string, data []byte) packEntry {
+	return packEntry{objType: objType, data: data, hash: objectHash(typeName, data), ofsBase: -1}
+}
+
+// writePack сохраняет объекты в objects/pack/pack-test.{pack,idx} и возвращает путь к индексу
+func (r *testRepo) writePack(entries []packEntry) string {
+	r.t.Helper()
+	var pack bytes.Buffer
+	pack.WriteString("PACK")
+	binary.Write(&pack, binary.BigEndian, uint32(2))
+	binary.Write(&pack, binary.BigEndian, uint32(len(entries)))
+
+	offsets := make([]int64, len(entries))
+	crcs := make([]uint32, len(entries))
+	for i, e := range entries {
+		offsets[i] = int64(pack.Len())
+		var obj bytes.Buffer
+		objType := e.objType
+		switch {
+		case e.ofsBase >= 0:
+			objType = objectOfsDelta
+		case e.refBase != nil:
+			objType = objectRefDelta
+		}
+		writePackObjectHeader(&obj, objType, len(e.data))
+		switch {
+		case e.ofsBase >= 0:
+			writeOfsDeltaDistance(&obj, offsets[i]-offsets[e.ofsBase])
+		case e.refBase != nil:
+			obj.Write(e.refBase[:])
+		}
+		obj.Write(zlibBytes(e.data))
+		crcs[i] = crc32.ChecksumIEEE(obj.Bytes())
+		pack.Write(obj.Bytes())
+	}
+	packSum := sha1.Sum(pack.Bytes())
+	pack.Write(packSum[:])
+
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return bytes.Compare(entries[order[i]].hash[:], entries[order[j]].hash[:]) < 0
+	})
+
+	var idx bytes.Buffer
+	idx.Write([]byte{0xff, 't', 'O', 'c'})
+	binary.Write(&idx, binary.BigEndian, uint32(2))
+	for b := 0; b < 256; b++ {
+		count := 0
+		for _, e := range entries {
+			if int(e.hash[0]) <= b {
+				count++
+			}
+		}
+		binary.Write(&idx, binary.BigEndian, uint32(count))
+	}
+	for _, i := range order {
+		idx.Write(entries[i].hash[:])
+	}
+	for _, i := range order {
+		binary.Write(&idx, binary.BigEndian, crcs[i])
+	}
+	for _, i := range order {
+		binary.Write(&idx, binary.BigEndian, uint32(offsets[i]))
+	}
+	idx.Write(packSum[:])
+	idxSum := sha1.Sum(idx.Bytes())
+	idx.Write(idxSum[:])
+
+	dir := filepath.Join(r.gitDir, "objects", "pack")
+	if err := os.WriteFile(filepath.Join(dir, "pack-test.pack"), pack.Bytes(), 0o644); err != nil {
+		r.t.Fatal(err)
+	}
+	idxPath := filepath.Join(dir, "pack-test.idx")
+	if err := os.WriteFile(idxPath, idx.Bytes(), 0o644); err != nil {
+		r.t.Fatal(err)
+	}
+	return idxPath
+}
+
+// makeDelta кодирует target как копию общего с base начала и вставку остатка
+func makeDelta(base, target []byte) []byte {
+	var d bytes.Buffer
+	writeDeltaVarint(&d, uint64(len(base)))
+	writeDeltaVarint(&d, uint64(len(target)))
+
+	common := 0
+	for common < len(base) && common < len(target) && common < maxDeltaCopySize-1 && base[common] == target[common] {
+		common++
+	}
+	if common > 0 {
+		// copy с нулевым смещением: заданы только два байта размера
+		d.Write([]byte{0x80 | 0x10 | 0x20, byte(common), byte(common >> 8)})
+	}
+	for rest := target[common:]; len(rest) > 0; {
+		n := min(len(rest), 127)
+		d.WriteByte(byte(n))
+		d.Write(rest[:n])
+		rest = rest[n:]
+	}
+	return d.Bytes()
+}
+
+// writeDeltaVarint пишет размер в кодировке дельты: little-endian base-128
+func writeDeltaVarint(b *bytes.Buffer, v uint64) {
+	for v >= 0x80 {
+		b.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	b.WriteByte(byte(v))
+}
+
+// writePackObjectHeader пишет тип и размер объекта pack-файла
+func writePackObjectHeader(b *bytes.Buffer, objType objectType, size int) {
+	c := byte(objType)<<4 | byte(size&0x0f)
+	size >>= 4
+	for size > 0 {
+		b.WriteByte(c | 0x80)
+		c = byte(size & 0x7f)
+		size >>= 7
+	}
+	b.WriteByte(c)
+}
+
+// writeOfsDeltaDistance пишет расстояние до базы OFS_DELTA в кодировке git
+func writeOfsDeltaDistance(b *bytes.Buffer, distance int64) {
+	buf := []byte{byte(distance & 0x7f)}
+	for distance >>= 7; distance > 0; distance >>= 7 {
+		distance--
+		buf = append([]byte{byte(distance&0x7f) | 0x80}, buf...)
+	}
+	b.Write(buf)
+}
+
+// zlibBytes сжимает данные zlib
+func zlibBytes(data []byte) []byte {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	w.Write(data)
+	w.Close()
+	return b.Bytes()
+}
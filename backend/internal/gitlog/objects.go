@@ -0,0 +1,488 @@
+package gitlog
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Типы объектов git, как они закодированы в pack-файлах
+type objectType int
+
+const (
+	objectCommit   objectType = 1
+	objectTree     objectType = 2
+	objectBlob     objectType = 3
+	objectTag      objectType = 4
+	objectOfsDelta objectType = 6
+	objectRefDelta objectType = 7
+)
+
+// Ограничения чтения объектов
+const (
+	// maxAlternatesDepth — глубина цепочки objects/info/alternates, как в git
+	maxAlternatesDepth = 5
+	// maxDeltaChain защищает от зацикленных дельт в повреждённом pack-файле
+	maxDeltaChain = 10000
+	// deltaCacheBytes — сколько распакованных баз дельт держать в памяти на один pack-файл
+	deltaCacheBytes = 32 << 20
+	// maxObjectSize — больше объекты не читаются: коммиты и теги много меньше, а размер из повреждённого
+	// pack-файла иначе ушёл бы прямо в выделение памяти
+	maxObjectSize = 256 << 20
+	// maxDeltaCopySize — сколько байт базы копирует одна команда copy дельты
+	maxDeltaCopySize = 0x10000
+)
+
+var errObjectNotFound = errors.New("object not found")
+
+var objectTypeNames = map[string]objectType{
+	"commit": objectCommit,
+	"tree":   objectTree,
+	"blob":   objectBlob,
+	"tag":    objectTag,
+}
+
+// objectStore читает объекты из каталогов objects репозитория и его alternates.
+// root — каталог, за пределы которого не должны выходить каталоги объектов; пустой — без ограничения
+type objectStore struct {
+	root  string
+	dirs  []string
+	packs []*packFile
+}
+
+// openObjectStore открывает каталог объектов и индексы всех его pack-файлов
+func openObjectStore(dir, root string) (*objectStore, error) {
+	s := &objectStore{root: root}
+	if err := s.addDir(dir, 0); err != nil {
+		s.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// addDir добавляет каталог объектов вместе с его alternates
+func (s *objectStore) addDir(dir string, depth int) error {
+	if depth > maxAlternatesDepth {
+		return nil
+	}
+	for _, d := range []string{dir, filepath.Join(dir, "pack")} {
+		if err := checkWithin(s.root, d, "objects"); err != nil {
+			return err
+		}
+	}
+	s.dirs = append(s.dirs, dir)
+
+	indexes, err := filepath.Glob(filepath.Join(dir, "pack", "*.idx"))
+	if err != nil {
+		return err
+	}
+	for _, idx := range indexes {
+		p, err := openPack(s, idx)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", filepath.Base(idx), err)
+		}
+		s.packs = append(s.packs, p)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "info", "alternates"))
+	if err != nil {
+		return nil
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := s.addDir(resolvePath(dir, line), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// read возвращает тип и содержимое объекта
+func (s *objectStore) read(h Hash) (objectType, []byte, error) {
+	for _, dir := range s.dirs {
+		objType, data, err := readLooseObject(dir, h)
+		if !errors.Is(err, errObjectNotFound) {
+			return objType, data, err
+		}
+	}
+	for _, p := range s.packs {
+		if offset, ok := p.find(h); ok {
+			return p.readAt(offset, 0)
+		}
+	}
+	return 0, nil, errObjectNotFound
+}
+
+// close закрывает pack-файлы
+func (s *objectStore) close() error {
+	var errs []error
+	for _, p := range s.packs {
+		errs = append(errs, p.close())
+	}
+	return errors.Join(errs...)
+}
+
+// readLooseObject читает объект objects/xx/yyyy...: zlib-поток с заголовком "<тип> <размер>\x00"
+func readLooseObject(dir string, h Hash) (objectType, []byte, error) {
+	name := h.String()
+	f, err := os.Open(filepath.Join(dir, name[:2], name[2:]))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil, errObjectNotFound
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	defer f.Close()
+
+	zr, err := zlib.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return 0, nil, fmt.Errorf("loose object %s: %w", name, err)
+	}
+	defer zr.Close()
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return 0, nil, fmt.Errorf("loose object %s: %w", name, err)
+	}
+
+	header, data, ok := bytes.Cut(raw, []byte{0})
+	typeName, sizeStr, _ := strings.Cut(string(header), " ")
+	objType, known := objectTypeNames[typeName]
+	size, err := strconv.Atoi(sizeStr)
+	if !ok || !known || err != nil || size != len(data) {
+		return 0, nil, fmt.Errorf("loose object %s: malformed header", name)
+	}
+	return objType, data, nil
+}
+
+// cachedObject — распакованный объект в кэше баз дельт
+type cachedObject struct {
+	objType objectType
+	data    []byte
+}
+
+// packFile представляет pack-файл и его индекс версии 2. Сам pack открывается при первом чтении
+type packFile struct {
+	store    *objectStore
+	packPath string
+	file     *os.File
+
+	fanout       [256]uint32
+	names        []byte
+	offsets      []byte
+	largeOffsets []byte
+
+	cache      map[int64]cachedObject
+	cacheBytes int
+}
+
+// openPack читает индекс pack-файла
+func openPack(store *objectStore, idxPath string) (*packFile, error) {
+	idx, err := os.ReadFile(idxPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(idx) < 8+256*4 || !bytes.Equal(idx[:4], []byte{0xff, 't', 'O', 'c'}) || binary.BigEndian.Uint32(idx[4:8]) != 2 {
+		return nil, fmt.Errorf("%w: only pack index version 2 is supported", ErrUnsupportedRepository)
+	}
+
+	p := &packFile{
+		store:    store,
+		packPath: strings.TrimSuffix(idxPath, ".idx") + ".pack",
+		cache:    make(map[int64]cachedObject),
+	}
+	// Таблица fanout обязана не убывать: по ней find выбирает границы поиска в именах
+	for i := range p.fanout {
+		p.fanout[i] = binary.BigEndian.Uint32(idx[8+4*i:])
+		if i > 0 && p.fanout[i] < p.fanout[i-1] {
+			return nil, errors.New("malformed pack index: fanout table is not sorted")
+		}
+	}
+	namesStart := 8 + 256*4
+	// Имена, CRC32 и смещения занимают 28 байт на объект; fanout[255] — число объектов
+	if int64(p.fanout[255])*28 > int64(len(idx)-namesStart-2*20) {
+		return nil, errors.New("truncated pack index")
+	}
+	n := int(p.fanout[255])
+	offsetsStart := namesStart + 20*n + 4*n // имена, затем CRC32, затем смещения
+	largeStart := offsetsStart + 4*n
+	p.names = idx[namesStart : namesStart+20*n]
+	p.offsets = idx[offsetsStart:largeStart]
+	p.largeOffsets = idx[largeStart : len(idx)-2*20]
+	return p, nil
+}
+
+// find ищет объект в индексе и возвращает его смещение в pack-файле
+func (p *packFile) find(h Hash) (int64, bool) {
+	lo := 0
+	if h[0] > 0 {
+		lo = int(p.fanout[h[0]-1])
+	}
+	hi := int(p.fanout[h[0]])
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch cmp := bytes.Compare(p.names[20*mid:20*mid+20], h[:]); {
+		case cmp == 0:
+			return p.offset(mid), true
+		case cmp < 0:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, false
+}
+
+// offset возвращает смещение i-го объекта индекса; старший бит означает ссылку на 8-байтовое смещение
+func (p *packFile) offset(i int) int64 {
+	v := binary.BigEndian.Uint32(p.offsets[4*i:])
+	if v&0x80000000 == 0 {
+		return int64(v)
+	}
+	j := int(v & 0x7fffffff)
+	if 8*j+8 > len(p.largeOffsets) {
+		return -1
+	}
+	return int64(binary.BigEndian.Uint64(p.largeOffsets[8*j:]))
+}
+
+// readAt читает объект по смещению, применяя дельты к их базам
+func (p *packFile) readAt(offset int64, depth int) (objectType, []byte, error) {
+	if cached, ok := p.cache[offset]; ok {
+		return cached.objType, cached.data, nil
+	}
+	if offset < 0 || depth > maxDeltaChain {
+		return 0, nil, fmt.Errorf("%s: malformed object at offset %d", filepath.Base(p.packPath), offset)
+	}
+	if p.file == nil {
+		f, err := os.Open(p.packPath)
+		if err != nil {
+			return 0, nil, err
+		}
+		p.file = f
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(p.file, offset, 1<<62))
+	objType, size, err := readPackObjectHeader(r)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", filepath.Base(p.packPath), err)
+	}
+	if size < 0 || size > maxObjectSize {
+		return 0, nil, fmt.Errorf("%s: object at offset %d is too large", filepath.Base(p.packPath), offset)
+	}
+
+	var baseType objectType
+	var base []byte
+	switch objType {
+	case objectCommit, objectTree, objectBlob, objectTag:
+	case objectOfsDelta:
+		distance, err := readOfsDeltaDistance(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		baseType, base, err = p.readAt(offset-distance, depth+1)
+		if err != nil {
+			return 0, nil, err
+		}
+	case objectRefDelta:
+		var baseHash Hash
+		if _, err := io.ReadFull(r, baseHash[:]); err != nil {
+			return 0, nil, err
+		}
+		baseType, base, err = p.store.read(baseHash)
+		if err != nil {
+			return 0, nil, fmt.Errorf("delta base %s: %w", baseHash, err)
+		}
+	default:
+		return 0, nil, fmt.Errorf("%s: unknown object type %d at offset %d", filepath.Base(p.packPath), objType, offset)
+	}
+
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(io.LimitReader(zr, int64(size)+1))
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) != size {
+		return 0, nil, fmt.Errorf("%s: object at offset %d has wrong size", filepath.Base(p.packPath), offset)
+	}
+
+	if base != nil {
+		if data, err = applyDelta(base, data); err != nil {
+			return 0, nil, fmt.Errorf("%s: object at offset %d: %w", filepath.Base(p.packPath), offset, err)
+		}
+		objType = baseType
+	}
+	p.remember(offset, objType, data)
+	return objType, data, nil
+}
+
+// remember кладёт объект в кэш; переполненный кэш очищается целиком
+func (p *packFile) remember(offset int64, objType objectType, data []byte) {
+	if len(data) > deltaCacheBytes/4 {
+		return
+	}
+	if p.cacheBytes+len(data) > deltaCacheBytes {
+		clear(p.cache)
+		p.cacheBytes = 0
+	}
+	p.cache[offset] = cachedObject{objType: objType, data: data}
+	p.cacheBytes += len(data)
+}
+
+// close закрывает pack-файл, если он был открыт
+func (p *packFile) close() error {
+	if p.file == nil {
+		return nil
+	}
+	return p.file.Close()
+}
+
+// readPackObjectHeader читает тип и размер объекта: 3 бита типа и размер переменной длины
+func readPackObjectHeader(r io.ByteReader) (objectType, int, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	objType := objectType((c >> 4) & 7)
+	size := int(c & 0x0f)
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		if shift > 56 {
+			return 0, 0, errors.New("object size is too large")
+		}
+		if c, err = r.ReadByte(); err != nil {
+			return 0, 0, err
+		}
+		size |= int(c&0x7f) << shift
+	}
+	return objType, size, nil
+}
+
+// readOfsDeltaDistance читает расстояние до базы OFS_DELTA в кодировке git
+func readOfsDeltaDistance(r io.ByteReader) (int64, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	distance := int64(c & 0x7f)
+	for c&0x80 != 0 {
+		if c, err = r.ReadByte(); err != nil {
+			return 0, err
+		}
+		distance = ((distance + 1) << 7) | int64(c&0x7f)
+	}
+	return distance, nil
+}
+
+// applyDelta собирает объект из базы и дельты: размеры базы и результата, затем команды copy и insert.
+// Размер результата берётся из дельты, поэтому до выделения памяти он сверяется с тем,
+// сколько дельта такой длины вообще может собрать
+func applyDelta(base, delta []byte) ([]byte, error) {
+	d := &deltaReader{data: delta}
+	if d.varint() != uint64(len(base)) {
+		return nil, errors.New("delta base size mismatch")
+	}
+	targetSize := d.varint()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if targetSize > maxObjectSize || targetSize > uint64(len(d.data))*maxDeltaCopySize {
+		return nil, errors.New("delta result size is out of range")
+	}
+	out := make([]byte, 0, int(targetSize))
+
+	for d.err == nil && len(d.data) > 0 {
+		op := d.byte()
+		switch {
+		case op&0x80 != 0:
+			var offset, size int
+			for i := range 4 {
+				if op&(1<<i) != 0 {
+					offset |= int(d.byte()) << (8 * i)
+				}
+			}
+			for i := range 3 {
+				if op&(0x10<<i) != 0 {
+					size |= int(d.byte()) << (8 * i)
+				}
+			}
+			if size == 0 {
+				size = maxDeltaCopySize
+			}
+			if offset+size > len(base) {
+				return nil, errors.New("delta copies outside of base")
+			}
+			if uint64(len(out)+size) > targetSize {
+				return nil, errors.New("delta result size mismatch")
+			}
+			out = append(out, base[offset:offset+size]...)
+		case op != 0:
+			if uint64(len(out)+int(op)) > targetSize {
+				return nil, errors.New("delta result size mismatch")
+			}
+			out = append(out, d.bytes(int(op))...)
+		default:
+			return nil, errors.New("reserved delta opcode")
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if uint64(len(out)) != targetSize {
+		return nil, errors.New("delta result size mismatch")
+	}
+	return out, nil
+}
+
+// deltaReader читает дельту, запоминая первую ошибку выхода за её конец
+type deltaReader struct {
+	data []byte
+	err  error
+}
+
+// byte читает один байт
+func (d *deltaReader) byte() byte {
+	b := d.bytes(1)
+	if len(b) == 0 {
+		return 0
+	}
+	return b[0]
+}
+
+// bytes читает n байт
+func (d *deltaReader) bytes(n int) []byte {
+	if n > len(d.data) {
+		d.err = errors.New("truncated delta")
+		d.data = nil
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+// varint читает размер в кодировке little-endian base-128
+func (d *deltaReader) varint() uint64 {
+	var v uint64
+	for shift := 0; shift < 64; shift += 7 {
+		c := d.byte()
+		v |= uint64(c&0x7f) << shift
+		if c&0x80 == 0 || d.err != nil {
+			break
+		}
+	}
+	return v
+}
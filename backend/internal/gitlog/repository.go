@@ -0,0 +1,337 @@
+// Package gitlog читает историю коммитов локального git-репозитория прямо с диска, без git и сети.
+// Поддерживаются loose-объекты и pack-файлы с индексом версии 2, alternates, рабочие деревья (git worktree)
+// и неглубокие клоны. Репозитории с SHA-256 вместо SHA-1 не поддерживаются
+package gitlog
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrNotRepository возвращается, если по пути нет git-репозитория
+	ErrNotRepository = errors.New("not a git repository")
+	// ErrRefNotFound возвращается, если ветка, тег или коммит не найдены
+	ErrRefNotFound = errors.New("git ref not found")
+	// ErrUnsupportedRepository возвращается для репозиториев, которые пакет не умеет читать
+	ErrUnsupportedRepository = errors.New("unsupported git repository")
+	// ErrOutsideRoot возвращается, если каталоги репозитория (gitdir рабочего дерева, commondir, alternates)
+	// ведут за пределы каталога, переданного в Open
+	ErrOutsideRoot = errors.New("git repository points outside the allowed root")
+)
+
+// maxSymrefDepth ограничивает цепочку символических ссылок (HEAD -> refs/heads/main -> ...)
+const maxSymrefDepth = 5
+
+var sha256FormatPattern = regexp.MustCompile(`(?im)^\s*objectformat\s*=\s*sha256\s*$`)
+
+// Hash — SHA-1 объекта git
+type Hash [20]byte
+
+// String возвращает хеш в hex
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// ParseHash разбирает полный хеш в hex
+func ParseHash(s string) (Hash, bool) {
+	var h Hash
+	if len(s) != 2*len(h) {
+		return Hash{}, false
+	}
+	if _, err := hex.Decode(h[:], []byte(s)); err != nil {
+		return Hash{}, false
+	}
+	return h, true
+}
+
+// Repository представляет открытый репозиторий. Не предназначен для использования из нескольких горутин
+type Repository struct {
+	// gitDir содержит HEAD рабочего дерева, commonDir — ссылки и объекты; без worktree это один каталог
+	gitDir    string
+	commonDir string
+	objects   *objectStore
+	// shallow — коммиты на границе неглубокого клона, их родителей в репозитории нет
+	shallow map[Hash]bool
+}
+
+// Open открывает репозиторий по пути к рабочему каталогу или к bare-репозиторию.
+// Если root не пустой, каталоги, которые читаются по ссылкам из файлов репозитория (.git-файл рабочего дерева,
+// commondir, objects/info/alternates), и симлинки на них обязаны оставаться внутри root, иначе возвращается ErrOutsideRoot
+func Open(path, root string) (*Repository, error) {
+	if root != "" {
+		var err error
+		if root, err = filepath.EvalSymlinks(root); err != nil {
+			return nil, err
+		}
+	}
+	gitDir, err := findGitDir(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWithin(root, gitDir, "gitdir"); err != nil {
+		return nil, err
+	}
+
+	commonDir := gitDir
+	if b, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = resolvePath(gitDir, strings.TrimSpace(string(b)))
+		if err := checkWithin(root, commonDir, "commondir"); err != nil {
+			return nil, err
+		}
+	}
+	if b, err := os.ReadFile(filepath.Join(commonDir, "config")); err == nil && sha256FormatPattern.Match(b) {
+		return nil, fmt.Errorf("%w: SHA-256 object format", ErrUnsupportedRepository)
+	}
+
+	objects, err := openObjectStore(filepath.Join(commonDir, "objects"), root)
+	if err != nil {
+		return nil, err
+	}
+	repo := &Repository{
+		gitDir:    gitDir,
+		commonDir: commonDir,
+		objects:   objects,
+		shallow:   make(map[Hash]bool),
+	}
+	if b, err := os.ReadFile(filepath.Join(commonDir, "shallow")); err == nil {
+		for _, line := range strings.Fields(string(b)) {
+			if h, ok := ParseHash(line); ok {
+				repo.shallow[h] = true
+			}
+		}
+	}
+	return repo, nil
+}
+
+// Close закрывает открытые pack-файлы
+func (r *Repository) Close() error {
+	return r.objects.close()
+}
+
+// Resolve возвращает коммит, на который указывает ref: полный хеш, HEAD, ветка, тег или удалённая ветка.
+// Пустой ref означает HEAD. Аннотированные теги разыменовываются до коммита
+func (r *Repository) Resolve(ref string) (Hash, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	h, ok := ParseHash(ref)
+	if !ok {
+		var err error
+		h, err = r.resolveName(ref)
+		if err != nil {
+			return Hash{}, err
+		}
+	}
+
+	// Аннотированный тег указывает на другой объект; идём по цепочке до коммита
+	for range maxSymrefDepth {
+		objType, data, err := r.objects.read(h)
+		if errors.Is(err, errObjectNotFound) {
+			return Hash{}, fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+		}
+		if err != nil {
+			return Hash{}, err
+		}
+		switch objType {
+		case objectCommit:
+			return h, nil
+		case objectTag:
+			target, ok := tagTarget(data)
+			if !ok {
+				return Hash{}, fmt.Errorf("malformed tag object %s", h)
+			}
+			h = target
+		default:
+			return Hash{}, fmt.Errorf("%w: %s does not point to a commit", ErrRefNotFound, ref)
+		}
+	}
+	return Hash{}, fmt.Errorf("%w: %s: too many nested tags", ErrRefNotFound, ref)
+}
+
+// Commits возвращает все коммиты, достижимые из from, включая его самого. Порядок не определён
+func (r *Repository) Commits(from Hash) ([]Commit, error) {
+	var commits []Commit
+	seen := map[Hash]bool{from: true}
+	stack := []Hash{from}
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		objType, data, err := r.objects.read(h)
+		if err != nil {
+			return nil, fmt.Errorf("read commit %s: %w", h, err)
+		}
+		if objType != objectCommit {
+			return nil, fmt.Errorf("object %s is not a commit", h)
+		}
+		commit, err := parseCommit(h, data)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+
+		if r.shallow[h] {
+			continue
+		}
+		for _, p := range commit.Parents {
+			if !seen[p] {
+				seen[p] = true
+				stack = append(stack, p)
+			}
+		}
+	}
+	return commits, nil
+}
+
+// resolveName ищет ссылку по имени в том же порядке, что и git: как есть, в refs/, refs/heads/, refs/tags/, refs/remotes/
+func (r *Repository) resolveName(name string) (Hash, error) {
+	if strings.Contains(name, "..") {
+		return Hash{}, fmt.Errorf("%w: %s", ErrRefNotFound, name)
+	}
+	packed, err := r.packedRefs()
+	if err != nil {
+		return Hash{}, err
+	}
+	for _, candidate := range []string{name, "refs/" + name, "refs/heads/" + name, "refs/tags/" + name, "refs/remotes/" + name} {
+		h, found, err := r.readRef(candidate, packed, 0)
+		if err != nil {
+			return Hash{}, err
+		}
+		if found {
+			return h, nil
+		}
+	}
+	return Hash{}, fmt.Errorf("%w: %s", ErrRefNotFound, name)
+}
+
+// readRef читает ссылку из файла или из packed-refs, следуя символическим ссылкам
+func (r *Repository) readRef(name string, packed map[string]Hash, depth int) (Hash, bool, error) {
+	if depth > maxSymrefDepth {
+		return Hash{}, false, fmt.Errorf("%w: %s: too many symbolic refs", ErrRefNotFound, name)
+	}
+	// Имя из символической ссылки не должно выводить из каталога репозитория
+	if strings.Contains(name, "..") || filepath.IsAbs(name) {
+		return Hash{}, false, fmt.Errorf("%w: %s", ErrRefNotFound, name)
+	}
+
+	dir := r.commonDir
+	if name == "HEAD" {
+		dir = r.gitDir
+	}
+	b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		// Каталог вместо файла (refs/heads при name=heads) и отсутствующий файл — ссылки нет
+		h, ok := packed[name]
+		return h, ok, nil
+	}
+
+	content := strings.TrimSpace(string(b))
+	if target, ok := strings.CutPrefix(content, "ref:"); ok {
+		return r.readRef(strings.TrimSpace(target), packed, depth+1)
+	}
+	h, ok := ParseHash(content)
+	if !ok {
+		return Hash{}, false, fmt.Errorf("malformed ref %s", name)
+	}
+	return h, true, nil
+}
+
+// packedRefs читает файл packed-refs. Строки "^hash" с разыменованными тегами не нужны: Resolve разыменовывает сам
+func (r *Repository) packedRefs() (map[string]Hash, error) {
+	refs := make(map[string]Hash)
+	f, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if errors.Is(err, os.ErrNotExist) {
+		return refs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		hash, name, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || strings.HasPrefix(hash, "#") || strings.HasPrefix(hash, "^") {
+			continue
+		}
+		if h, ok := ParseHash(hash); ok {
+			refs[name] = h
+		}
+	}
+	return refs, scanner.Err()
+}
+
+// findGitDir находит каталог репозитория: path/.git (каталог или файл "gitdir: ..." рабочего дерева)
+// или сам path, если это bare-репозиторий
+func findGitDir(path string) (string, error) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	switch {
+	case err == nil && info.IsDir():
+		return dotGit, nil
+	case err == nil:
+		b, err := os.ReadFile(dotGit)
+		if err != nil {
+			return "", err
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir:")
+		if !ok {
+			return "", ErrNotRepository
+		}
+		return resolvePath(path, strings.TrimSpace(target)), nil
+	}
+
+	if isFile(filepath.Join(path, "HEAD")) && isDir(filepath.Join(path, "objects")) {
+		return path, nil
+	}
+	return "", ErrNotRepository
+}
+
+// resolvePath возвращает target, а относительный target — относительно base
+func resolvePath(base, target string) string {
+	if filepath.IsAbs(target) {
+		return target
+	}
+	return filepath.Join(base, target)
+}
+
+// checkWithin проверяет, что каталог с учётом симлинков лежит внутри root (root уже без симлинков).
+// Пустой root ничего не ограничивает; отсутствующий каталог не читается и не проверяется.
+// В ошибку попадает только what — что за каталог, а не путь за пределами root
+func checkWithin(root, dir, what string) error {
+	if root == "" {
+		return nil
+	}
+	resolved, err := filepath.EvalSymlinks(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%w: %s", ErrOutsideRoot, what)
+	}
+	return nil
+}
+
+// isFile сообщает, есть ли по пути обычный файл
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// isDir сообщает, есть ли по пути каталог
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
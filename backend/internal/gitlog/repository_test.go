@@ -0,0 +1,370 @@
+package gitlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// base — время первого коммита фикстур; остальные идут через час
+var base = time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+
+func TestOpenLooseRepository(t *testing.T) {
+	r := newTestRepo(t, t.TempDir())
+	c1 := r.writeLoose("commit", commitData(nil, "alice@x.io", base, "first\n\nbody"))
+	c2 := r.writeLoose("commit", commitData([]Hash{c1}, "bob@x.io", base.Add(time.Hour), "second\nline"))
+	c3 := r.writeLoose("commit", commitData([]Hash{c2}, "alice@x.io", base.Add(2*time.Hour), "third"))
+	r.setRef("refs/heads/main", c3)
+	r.setRef("refs/heads/old", c2)
+	tag := r.writeLoose("tag", []byte("object "+c1.String()+"\ntype commit\ntag v1\n\nrelease\n"))
+	r.setRef("refs/tags/v1", tag)
+
+	repo, err := Open(r.path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+
+	for ref, want := range map[string]Hash{"": c3, "HEAD": c3, "main": c3, "refs/heads/old": c2, "v1": c1, c2.String(): c2} {
+		got, err := repo.Resolve(ref)
+		if err != nil || got != want {
+			t.Fatalf("Resolve(%q) = %s, %v; want %s", ref, got, err, want)
+		}
+	}
+	for _, ref := range []string{"missing", "../../etc/passwd", strings.Repeat("0", 40)} {
+		if _, err := repo.Resolve(ref); !errors.Is(err, ErrRefNotFound) {
+			t.Fatalf("Resolve(%q) = %v, want ErrRefNotFound", ref, err)
+		}
+	}
+
+	commits, err := repo.Commits(c3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := commitSummaries(commits)
+	want := []string{
+		"alice@x.io 2025-03-14T13:00:00+03:00 first",
+		"bob@x.io 2025-03-14T14:00:00+03:00 second line",
+		"alice@x.io 2025-03-14T15:00:00+03:00 third",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("commits:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestOpenPackedRepository(t *testing.T) {
+	r := newTestRepo(t, t.TempDir())
+	d1 := commitData(nil, "alice@x.io", base, "first packed commit with a long enough subject")
+	d2 := commitData(nil, "alice@x.io", base, "first packed commit with a long enough subject, amended")
+	d3 := commitData(nil, "alice@x.io", base, "first packed commit with a long enough subject, amended twice")
+	e1 := fullEntry(objectCommit, "commit", d1)
+	// c2 — OFS_DELTA от c1, c3 — REF_DELTA от c2: цепочка из двух дельт разных видов
+	e2 := packEntry{data: makeDelta(d1, d2), hash: objectHash("commit", d2), ofsBase: 0}
+	e3 := packEntry{data: makeDelta(d2, d3), hash: objectHash("commit", d3), ofsBase: -1, refBase: &e2.hash}
+	r.writePack([]packEntry{e1, e2, e3})
+	// Ссылки только в packed-refs, как после git pack-refs
+	r.writeFile("packed-refs", "# pack-refs with: peeled fully-peeled sorted\n"+
+		e1.hash.String()+" refs/heads/main\n"+
+		e2.hash.String()+" refs/tags/ofs\n"+
+		e3.hash.String()+" refs/tags/ref\n")
+
+	repo, err := Open(r.path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+
+	for ref, want := range map[string]packEntry{"main": e1, "ofs": e2, "ref": e3} {
+		h, err := repo.Resolve(ref)
+		if err != nil || h != want.hash {
+			t.Fatalf("Resolve(%q) = %s, %v; want %s", ref, h, err, want.hash)
+		}
+		commits, err := repo.Commits(h)
+		if err != nil {
+			t.Fatalf("Commits(%s): %v", ref, err)
+		}
+		wantCommit, _ := parseCommit(want.hash, commitDataOf(t, want, d1, d2, d3))
+		if len(commits) != 1 || commits[0].Subject != wantCommit.Subject {
+			t.Fatalf("Commits(%s) = %+v, want subject %q", ref, commits, wantCommit.Subject)
+		}
+	}
+}
+
+func TestOpenBareRepositoryWithAlternates(t *testing.T) {
+	root := t.TempDir()
+	shared := newTestRepo(t, filepath.Join(root, "shared"))
+	c1 := shared.writeLoose("commit", commitData(nil, "alice@x.io", base, "shared"))
+
+	// bare-репозиторий: HEAD и objects прямо в каталоге, объекты берутся из alternates
+	bare := filepath.Join(root, "bare.git")
+	for _, dir := range []string{"objects/info", "refs/heads"} {
+		if err := os.MkdirAll(filepath.Join(bare, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(bare, "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(bare, "refs", "heads", "main"), c1.String()+"\n")
+	writeFile(t, filepath.Join(bare, "objects", "info", "alternates"), "../../shared/.git/objects\n")
+
+	repo, err := Open(bare, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+	h, err := repo.Resolve("")
+	if err != nil || h != c1 {
+		t.Fatalf("Resolve = %s, %v; want %s", h, err, c1)
+	}
+}
+
+func TestOpenOutsideRoot(t *testing.T) {
+	outside := newTestRepo(t, filepath.Join(t.TempDir(), "secret"))
+	c1 := outside.writeLoose("commit", commitData(nil, "alice@x.io", base, "secret"))
+	outside.setRef("refs/heads/main", c1)
+	outsideDir, err := filepath.EvalSymlinks(outside.gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name  string
+		setup func(root string) string
+	}{
+		{
+			name: "worktree gitdir",
+			setup: func(root string) string {
+				path := filepath.Join(root, "wt")
+				os.MkdirAll(path, 0o755)
+				writeFile(t, filepath.Join(path, ".git"), "gitdir: "+outsideDir+"\n")
+				return path
+			},
+		},
+		{
+			name: "commondir",
+			setup: func(root string) string {
+				r := newTestRepo(t, filepath.Join(root, "cd"))
+				r.writeFile("commondir", outsideDir+"\n")
+				return r.path
+			},
+		},
+		{
+			name: "alternates",
+			setup: func(root string) string {
+				r := newTestRepo(t, filepath.Join(root, "alt"))
+				r.writeFile("objects/info/alternates", filepath.Join(outsideDir, "objects")+"\n")
+				return r.path
+			},
+		},
+		{
+			name: "symlinked objects",
+			setup: func(root string) string {
+				r := newTestRepo(t, filepath.Join(root, "sym"))
+				os.RemoveAll(filepath.Join(r.gitDir, "objects"))
+				if err := os.Symlink(filepath.Join(outsideDir, "objects"), filepath.Join(r.gitDir, "objects")); err != nil {
+					t.Fatal(err)
+				}
+				return r.path
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			path := tc.setup(root)
+
+			_, err := Open(path, root)
+			if !errors.Is(err, ErrOutsideRoot) {
+				t.Fatalf("Open = %v, want ErrOutsideRoot", err)
+			}
+			if strings.Contains(err.Error(), outsideDir) {
+				t.Fatalf("error reveals the path outside the root: %v", err)
+			}
+			// Без root те же ссылки разрешены
+			if repo, err := Open(path, ""); err == nil {
+				repo.Close()
+			}
+		})
+	}
+}
+
+func TestResolveSymrefOutsideRepository(t *testing.T) {
+	r := newTestRepo(t, t.TempDir())
+	r.writeFile("HEAD", "ref: ../../../etc/passwd\n")
+	repo, err := Open(r.path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+	if _, err := repo.Resolve(""); !errors.Is(err, ErrRefNotFound) {
+		t.Fatalf("Resolve = %v, want ErrRefNotFound", err)
+	}
+}
+
+func TestOpenCorruptPackIndex(t *testing.T) {
+	d1 := commitData(nil, "alice@x.io", base, "first")
+	d2 := commitData(nil, "alice@x.io", base, "second")
+	entries := []packEntry{fullEntry(objectCommit, "commit", d1), fullEntry(objectCommit, "commit", d2)}
+
+	cases := []struct {
+		name    string
+		corrupt func(idx []byte) []byte
+	}{
+		{name: "bad magic", corrupt: func(idx []byte) []byte { idx[0] = 0; return idx }},
+		{name: "version 1", corrupt: func(idx []byte) []byte { binary.BigEndian.PutUint32(idx[4:], 1); return idx }},
+		{name: "truncated header", corrupt: func(idx []byte) []byte { return idx[:100] }},
+		{name: "truncated names", corrupt: func(idx []byte) []byte { return idx[:8+256*4+30] }},
+		{
+			name: "object count beyond the file",
+			corrupt: func(idx []byte) []byte {
+				binary.BigEndian.PutUint32(idx[8+255*4:], 1<<30)
+				return idx
+			},
+		},
+		{
+			name: "decreasing fanout",
+			corrupt: func(idx []byte) []byte {
+				// Первая корзина обещает больше объектов, чем всего в индексе
+				binary.BigEndian.PutUint32(idx[8:], 1000)
+				return idx
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newTestRepo(t, t.TempDir())
+			idxPath := r.writePack(entries)
+			idx, err := os.ReadFile(idxPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, idxPath, string(tc.corrupt(idx)))
+
+			if repo, err := Open(r.path, ""); err == nil {
+				repo.Close()
+				t.Fatal("Open accepted a corrupt pack index")
+			}
+		})
+	}
+}
+
+func TestReadCorruptPack(t *testing.T) {
+	d1 := commitData(nil, "alice@x.io", base, "first")
+	e1 := fullEntry(objectCommit, "commit", d1)
+
+	t.Run("truncated pack", func(t *testing.T) {
+		r := newTestRepo(t, t.TempDir())
+		r.writePack([]packEntry{e1})
+		packPath := filepath.Join(r.gitDir, "objects", "pack", "pack-test.pack")
+		pack, _ := os.ReadFile(packPath)
+		writeFile(t, packPath, string(pack[:20]))
+
+		repo, err := Open(r.path, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer repo.Close()
+		if _, err := repo.Commits(e1.hash); err == nil {
+			t.Fatal("read a commit from a truncated pack")
+		}
+	})
+
+	t.Run("delta with a huge result size", func(t *testing.T) {
+		var delta bytes.Buffer
+		writeDeltaVarint(&delta, uint64(len(d1)))
+		writeDeltaVarint(&delta, 1<<40)
+		delta.Write([]byte{1, 'x'})
+
+		r := newTestRepo(t, t.TempDir())
+		e2 := packEntry{data: delta.Bytes(), hash: Hash{0x01}, ofsBase: 0}
+		r.writePack([]packEntry{e1, e2})
+		repo, err := Open(r.path, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer repo.Close()
+		if _, err := repo.Commits(e2.hash); err == nil {
+			t.Fatal("applied a delta with a huge result size")
+		}
+	})
+}
+
+func TestApplyDelta(t *testing.T) {
+	baseData := []byte("hello, world")
+	delta := func(baseSize, targetSize uint64, ops ...byte) []byte {
+		var b bytes.Buffer
+		writeDeltaVarint(&b, baseSize)
+		writeDeltaVarint(&b, targetSize)
+		b.Write(ops)
+		return b.Bytes()
+	}
+
+	cases := []struct {
+		name    string
+		delta   []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "copy and insert", delta: delta(12, 9, 0x90, 5, 4, ' ', 'y', 'o', 'u'), want: "hello you"},
+		{name: "copy with offset", delta: delta(12, 5, 0x91, 7, 5), want: "world"},
+		{name: "base size mismatch", delta: delta(11, 5, 0x90, 5), wantErr: true},
+		{name: "copy outside the base", delta: delta(12, 5, 0x91, 10, 5), wantErr: true},
+		{name: "result larger than declared", delta: delta(12, 3, 0x90, 5), wantErr: true},
+		{name: "result smaller than declared", delta: delta(12, 10, 0x90, 5), wantErr: true},
+		{name: "declared size out of range", delta: delta(12, 1<<40, 0x90, 5), wantErr: true},
+		{name: "truncated insert", delta: delta(12, 5, 5, 'a'), wantErr: true},
+		{name: "reserved opcode", delta: delta(12, 5, 0), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := applyDelta(baseData, tc.delta)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("applyDelta = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil || string(got) != tc.want {
+				t.Fatalf("applyDelta = %q, %v; want %q", got, err, tc.want)
+			}
+		})
+	}
+}
+
+// commitSummaries возвращает коммиты строками "email время subject" в порядке времени
+func commitSummaries(commits []Commit) []string {
+	sort.Slice(commits, func(i, j int) bool { return commits[i].AuthorTime.Before(commits[j].AuthorTime) })
+	lines := make([]string, 0, len(commits))
+	for _, c := range commits {
+		lines = append(lines, c.AuthorEmail+" "+c.AuthorTime.Format(time.RFC3339)+" "+c.Subject)
+	}
+	return lines
+}
+
+// commitDataOf возвращает исходное тело коммита объекта pack-файла
+func commitDataOf(t *testing.T, e packEntry, candidates ...[]byte) []byte {
+	t.Helper()
+	for _, data := range candidates {
+		if objectHash("commit", data) == e.hash {
+			return data
+		}
+	}
+	t.Fatalf("no data for %s", e.hash)
+	return nil
+}
+
+// writeFile записывает файл по пути
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,36 @@
+package archive
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ExportHandler отвечает за обработку запроса на выгрузку архива аккаунта
+type ExportHandler struct {
+	usecase *usecases.ExportAccountUsecase
+}
+
+// NewExportHandler создает новый экземпляр ExportHandler
+func NewExportHandler(usecase *usecases.ExportAccountUsecase) *ExportHandler {
+	return &ExportHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на выгрузку всех данных текущего пользователя файлом JSON
+func (h *ExportHandler) Handle(c *gin.Context) {
+	archive, err := h.usecase.Execute(auth.UserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export account"})
+		return
+	}
+
+	name := "perf-assist-export-" + archive.ExportedAt.Format("20060102-150405") + ".json"
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	c.JSON(http.StatusOK, archive)
+}
@@ -0,0 +1,62 @@
+package archive
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// maxArchiveImportBytes ограничивает размер загружаемого архива
+const maxArchiveImportBytes = 20 << 20
+
+// ImportHandler отвечает за обработку запроса на восстановление данных из архива
+type ImportHandler struct {
+	usecase *usecases.ImportAccountUsecase
+}
+
+// NewImportHandler создает новый экземпляр ImportHandler
+func NewImportHandler(usecase *usecases.ImportAccountUsecase) *ImportHandler {
+	return &ImportHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на импорт архива из тела запроса.
+// Стратегия разрешения конфликтов передаётся query-параметром strategy
+func (h *ImportHandler) Handle(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxArchiveImportBytes)
+	var archive usecases.AccountArchive
+	err := c.ShouldBindJSON(&archive)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "archive must be at most 20 MB"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.ImportAccountCommand{
+		UserID:   auth.UserID(c),
+		Strategy: usecases.ImportStrategy(c.Query("strategy")),
+		Archive:  archive,
+	}
+
+	report, err := h.usecase.Execute(cmd)
+	switch {
+	case errors.Is(err, usecases.ErrInvalidImportStrategy),
+		errors.Is(err, usecases.ErrInvalidArchive):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to import account"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,56 @@
+package archive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+func TestImportHandlerBodyLimit(t *testing.T) {
+	summaries := repositories.NewInMemoryPerfSummaryRepository()
+	u := usecases.NewImportAccountUsecase(repositories.NewInMemoryEntriesRepository(), repositories.NewInMemoryTagsRepository(), repositories.NewInMemoryGoalsRepository(), repositories.NewInMemoryGoalEntryLinksRepository(), summaries, repositories.NewInMemoryUsersRepository())
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(auth.Middleware(nil, nil, "u1"))
+	r.POST("/import", NewImportHandler(u).Handle)
+
+	// archive возвращает корректный архив с перф-саммари, текст которого дополнен до size байт
+	archive := func(size int) string {
+		head := `{"format":"perf-assist-archive","version":1,"perf_summaries":[{"period_start":"2026-01-01","period_end":"2026-01-31","summary_text":"`
+		tail := `"}]}`
+		return head + strings.Repeat("x", size-len(head)-len(tail)) + tail
+	}
+
+	cases := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantSaved  int
+	}{
+		{name: "at the limit", body: archive(maxArchiveImportBytes), wantStatus: http.StatusOK, wantSaved: 1},
+		{name: "over the limit", body: archive(maxArchiveImportBytes + 1), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "invalid JSON", body: `{"format":`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			before, _ := summaries.ListByUser("u1")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(tc.body)))
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d: %.200s", w.Code, tc.wantStatus, w.Body.String())
+			}
+			after, _ := summaries.ListByUser("u1")
+			if len(after)-len(before) != tc.wantSaved {
+				t.Fatalf("saved %d summaries, want %d", len(after)-len(before), tc.wantSaved)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package archive
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// Deps содержит зависимости для archive handlers
+type Deps struct {
+	ExportAccountUsecase *usecases.ExportAccountUsecase
+	ImportAccountUsecase *usecases.ImportAccountUsecase
+}
+
+// RegisterRoutes регистрирует ручки резервного копирования и восстановления данных пользователя
+func RegisterRoutes(r *gin.RouterGroup, deps Deps) {
+	exportHandler := NewExportHandler(deps.ExportAccountUsecase)
+	importHandler := NewImportHandler(deps.ImportAccountUsecase)
+
+	r.GET("/export", exportHandler.Handle)
+	r.POST("/import", importHandler.Handle)
+}
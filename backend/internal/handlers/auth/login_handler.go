@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// LoginHandler отвечает за обработку запроса на вход
+type LoginHandler struct {
+	usecase *usecases.LoginUserUsecase
+}
+
+// NewLoginHandler создает новый экземпляр LoginHandler
+func NewLoginHandler(usecase *usecases.LoginUserUsecase) *LoginHandler {
+	return &LoginHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на вход по email и паролю
+func (h *LoginHandler) Handle(c *gin.Context) {
+	var req loginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.LoginUserCommand{
+		Email:    req.Email,
+		Password: req.Password,
+	}
+
+	session, err := h.usecase.Execute(cmd)
+	if errors.Is(err, usecases.ErrInvalidCredentials) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log in"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSessionResponse(session))
+}
+
+// loginRequest представляет структуру запроса для входа
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
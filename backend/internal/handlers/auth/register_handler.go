@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// RegisterHandler отвечает за обработку запроса на регистрацию
+type RegisterHandler struct {
+	usecase *usecases.RegisterUserUsecase
+}
+
+// NewRegisterHandler создает новый экземпляр RegisterHandler
+func NewRegisterHandler(usecase *usecases.RegisterUserUsecase) *RegisterHandler {
+	return &RegisterHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на регистрацию и сразу возвращает сессию нового пользователя
+func (h *RegisterHandler) Handle(c *gin.Context) {
+	var req registerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.RegisterUserCommand{
+		Email:    req.Email,
+		Password: req.Password,
+		Name:     req.Name,
+	}
+
+	session, err := h.usecase.Execute(cmd)
+	switch {
+	case errors.Is(err, usecases.ErrInvalidEmail),
+		errors.Is(err, usecases.ErrPasswordTooShort),
+		errors.Is(err, usecases.ErrPasswordTooLong),
+		errors.Is(err, usecases.ErrUserNameTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, usecases.ErrEmailTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register user"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toSessionResponse(session))
+}
+
+// registerRequest представляет структуру запроса для регистрации
+type registerRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+}
@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// Deps содержит зависимости для auth handlers
+type Deps struct {
+	RegisterUserUsecase *usecases.RegisterUserUsecase
+	LoginUserUsecase    *usecases.LoginUserUsecase
+}
+
+// RegisterRoutes регистрирует публичные ручки регистрации и входа /auth/*
+func RegisterRoutes(r *gin.RouterGroup, deps Deps) {
+	registerHandler := NewRegisterHandler(deps.RegisterUserUsecase)
+	loginHandler := NewLoginHandler(deps.LoginUserUsecase)
+
+	r.POST("/auth/register", registerHandler.Handle)
+	r.POST("/auth/login", loginHandler.Handle)
+}
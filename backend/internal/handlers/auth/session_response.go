@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// SessionResponse — выданная сессия. Токен передаётся в заголовке Authorization: Bearer <token>
+type SessionResponse struct {
+	Token     string            `json:"token"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	User      repositories.User `json:"user"`
+}
+
+// toSessionResponse преобразует сессию usecase в ответ API
+func toSessionResponse(session usecases.AuthSession) SessionResponse {
+	return SessionResponse{
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt,
+		User:      session.User,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
 	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
 	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
 )
@@ -30,25 +31,27 @@ func (h *CreateEntryHandler) Handle(c *gin.Context) {
 	}
 
 	cmd := usecases.CreateEntryCommand{
-		UserID:  req.UserID,
+		UserID:  auth.UserID(c),
 		Date:    req.Date,
 		Type:    req.Type,
 		RawText: req.RawText,
+		Tags:    req.Tags,
 	}
 
 	entry, err := h.usecase.Execute(cmd)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create entry"})
+		writeEntryError(c, err, "failed to create entry")
 		return
 	}
 
 	c.JSON(http.StatusCreated, entry)
 }
 
-// createEntryRequest представляет структуру запроса для создания записи
+// createEntryRequest представляет структуру запроса для создания записи.
+// Без поля tags теги перезаписываемой записи не меняются, [] снимает все теги
 type createEntryRequest struct {
-	UserID  string                 `json:"user_id"`
 	Date    string                 `json:"date"`
 	Type    repositories.EntryType `json:"type"`
 	RawText string                 `json:"raw_text"`
+	Tags    []string               `json:"tags"`
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
 	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
 )
 
@@ -37,12 +38,12 @@ func (h *DeleteEntryHandler) Handle(c *gin.Context) {
 	}
 
 	cmd := usecases.DeleteEntryCommand{
+		UserID:   auth.UserID(c),
 		IDOrDate: idOrDate,
 	}
 
-	err := h.usecase.Execute(cmd)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete entry"})
+	if err := h.usecase.Execute(cmd); err != nil {
+		writeEntryError(c, err, "failed to delete entry")
 		return
 	}
 
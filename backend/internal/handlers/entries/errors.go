@@ -0,0 +1,34 @@
+package entries
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// writeEntryError маппит ошибки usecases записей в HTTP-ответ
+func writeEntryError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "entry not found"})
+	case errors.Is(err, usecases.ErrTagTooLong),
+		errors.Is(err, usecases.ErrTooManyTags),
+		errors.Is(err, usecases.ErrEmptySearchQuery),
+		errors.Is(err, usecases.ErrNoMarkdownEntries),
+		errors.Is(err, usecases.ErrTooManyMarkdownEntries),
+		errors.Is(err, usecases.ErrInvalidGitRepo),
+		errors.Is(err, usecases.ErrGitRefNotFound),
+		errors.Is(err, usecases.ErrGitAuthorEmailRequired),
+		errors.Is(err, usecases.ErrInvalidGitImportPeriod):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrSemanticSearchDisabled),
+		errors.Is(err, usecases.ErrGitImportDisabled):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
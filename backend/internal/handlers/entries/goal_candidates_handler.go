@@ -0,0 +1,39 @@
+package entries
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// GoalCandidatesHandler отвечает за обработку запроса кандидатов в цели, сгруппированных по эмбеддингам
+type GoalCandidatesHandler struct {
+	usecase *usecases.GroupGoalCandidatesUsecase
+}
+
+// NewGoalCandidatesHandler создает новый экземпляр GoalCandidatesHandler
+func NewGoalCandidatesHandler(usecase *usecases.GroupGoalCandidatesUsecase) *GoalCandidatesHandler {
+	return &GoalCandidatesHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на группировку записей периода в кандидатов в цели
+func (h *GoalCandidatesHandler) Handle(c *gin.Context) {
+	query := usecases.GroupGoalCandidatesQuery{
+		UserID: auth.UserID(c),
+		From:   c.Query("from"),
+		To:     c.Query("to"),
+	}
+
+	result, err := h.usecase.Execute(query)
+	if err != nil {
+		writeEntryError(c, err, "failed to group goal candidates")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,65 @@
+package entries
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ImportGitHandler отвечает за обработку запроса на импорт фактов из истории git-репозитория
+type ImportGitHandler struct {
+	usecase *usecases.ImportGitHistoryUsecase
+}
+
+// NewImportGitHandler создает новый экземпляр ImportGitHandler
+func NewImportGitHandler(usecase *usecases.ImportGitHistoryUsecase) *ImportGitHandler {
+	return &ImportGitHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на импорт коммитов. С preview=true возвращает найденные дни, ничего не сохраняя
+func (h *ImportGitHandler) Handle(c *gin.Context) {
+	preview, err := strconv.ParseBool(c.DefaultQuery("preview", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "preview must be true or false"})
+		return
+	}
+
+	var req importGitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.ImportGitHistoryCommand{
+		UserID:      auth.UserID(c),
+		RepoPath:    req.RepoPath,
+		AuthorEmail: req.AuthorEmail,
+		Ref:         req.Ref,
+		From:        req.From,
+		To:          req.To,
+		Preview:     preview,
+	}
+
+	result, err := h.usecase.Execute(cmd)
+	if err != nil {
+		writeEntryError(c, err, "failed to import git history")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// importGitRequest представляет структуру запроса на импорт коммитов
+type importGitRequest struct {
+	RepoPath    string `json:"repo_path"`
+	AuthorEmail string `json:"author_email"`
+	Ref         string `json:"ref"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+}
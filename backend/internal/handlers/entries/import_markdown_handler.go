@@ -0,0 +1,63 @@
+package entries
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// maxMarkdownImportBytes ограничивает размер загружаемого дневника
+const maxMarkdownImportBytes = 5 << 20
+
+// ImportMarkdownHandler отвечает за обработку запроса на импорт дневника в Markdown
+type ImportMarkdownHandler struct {
+	usecase *usecases.ImportMarkdownEntriesUsecase
+}
+
+// NewImportMarkdownHandler создает новый экземпляр ImportMarkdownHandler
+func NewImportMarkdownHandler(usecase *usecases.ImportMarkdownEntriesUsecase) *ImportMarkdownHandler {
+	return &ImportMarkdownHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на импорт: тело запроса — содержимое Markdown-файла.
+// С preview=true возвращает найденные записи, ничего не сохраняя
+func (h *ImportMarkdownHandler) Handle(c *gin.Context) {
+	preview, err := strconv.ParseBool(c.DefaultQuery("preview", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "preview must be true or false"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxMarkdownImportBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "markdown file must be at most 5 MB"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	cmd := usecases.ImportMarkdownEntriesCommand{
+		UserID:   auth.UserID(c),
+		Markdown: string(body),
+		Preview:  preview,
+	}
+
+	result, err := h.usecase.Execute(cmd)
+	if err != nil {
+		writeEntryError(c, err, "failed to import entries")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
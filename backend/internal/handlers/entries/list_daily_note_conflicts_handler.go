@@ -0,0 +1,41 @@
+package entries
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ListDailyNoteConflictsHandler отвечает за обработку запроса журнала конфликтов синхронизации с ежедневными заметками
+type ListDailyNoteConflictsHandler struct {
+	usecase *usecases.ListDailyNoteConflictsUsecase
+}
+
+// NewListDailyNoteConflictsHandler создает новый экземпляр ListDailyNoteConflictsHandler
+func NewListDailyNoteConflictsHandler(usecase *usecases.ListDailyNoteConflictsUsecase) *ListDailyNoteConflictsHandler {
+	return &ListDailyNoteConflictsHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос журнала конфликтов с необязательным ?limit=
+func (h *ListDailyNoteConflictsHandler) Handle(c *gin.Context) {
+	limit, ok := queryLimit(c)
+	if !ok {
+		return
+	}
+
+	conflicts, err := h.usecase.Execute(usecases.ListDailyNoteConflictsQuery{
+		UserID: auth.UserID(c),
+		Limit:  limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list daily note conflicts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, conflicts)
+}
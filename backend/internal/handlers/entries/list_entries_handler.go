@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
 	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
 )
 
@@ -25,12 +26,14 @@ func (h *ListEntriesHandler) Handle(c *gin.Context) {
 	query := usecases.ListEntriesQuery{
 		From:   c.Query("from"),
 		To:     c.Query("to"),
-		UserID: c.Query("user_id"),
+		UserID: auth.UserID(c),
+		// ?tags=impact,team и ?tags=impact&tags=team равнозначны
+		Tags: c.QueryArray("tags"),
 	}
 
 	entries, err := h.usecase.Execute(query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list entries"})
+		writeEntryError(c, err, "failed to list entries")
 		return
 	}
 
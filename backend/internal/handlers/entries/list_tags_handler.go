@@ -0,0 +1,38 @@
+package entries
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ListTagsHandler отвечает за обработку запроса на получение тегов для автодополнения
+type ListTagsHandler struct {
+	usecase *usecases.ListTagsUsecase
+}
+
+// NewListTagsHandler создает новый экземпляр ListTagsHandler
+func NewListTagsHandler(usecase *usecases.ListTagsUsecase) *ListTagsHandler {
+	return &ListTagsHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение тегов пользователя с числом записей
+func (h *ListTagsHandler) Handle(c *gin.Context) {
+	query := usecases.ListTagsQuery{
+		UserID: auth.UserID(c),
+		Prefix: c.Query("prefix"),
+	}
+
+	tags, err := h.usecase.Execute(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
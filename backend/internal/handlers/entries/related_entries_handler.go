@@ -0,0 +1,43 @@
+package entries
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// RelatedEntriesHandler отвечает за обработку запроса записей, похожих на данную
+type RelatedEntriesHandler struct {
+	usecase *usecases.ListRelatedEntriesUsecase
+}
+
+// NewRelatedEntriesHandler создает новый экземпляр RelatedEntriesHandler
+func NewRelatedEntriesHandler(usecase *usecases.ListRelatedEntriesUsecase) *RelatedEntriesHandler {
+	return &RelatedEntriesHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение похожих записей
+func (h *RelatedEntriesHandler) Handle(c *gin.Context) {
+	limit, ok := queryLimit(c)
+	if !ok {
+		return
+	}
+	query := usecases.ListRelatedEntriesQuery{
+		UserID:  auth.UserID(c),
+		EntryID: c.Param("id"),
+		Limit:   limit,
+	}
+
+	related, err := h.usecase.Execute(c.Request.Context(), query)
+	if err != nil {
+		writeEntryError(c, err, "failed to list related entries")
+		return
+	}
+
+	c.JSON(http.StatusOK, related)
+}
@@ -8,21 +8,46 @@ import (
 
 // Deps содержит зависимости для entries handlers
 type Deps struct {
-	CreateEntryUsecase *usecases.CreateEntryUsecase
-	ListEntriesUsecase *usecases.ListEntriesUsecase
-	UpdateEntryUsecase *usecases.UpdateEntryUsecase
-	DeleteEntryUsecase *usecases.DeleteEntryUsecase
+	CreateEntryUsecase            *usecases.CreateEntryUsecase
+	ListEntriesUsecase            *usecases.ListEntriesUsecase
+	UpdateEntryUsecase            *usecases.UpdateEntryUsecase
+	DeleteEntryUsecase            *usecases.DeleteEntryUsecase
+	ListTagsUsecase               *usecases.ListTagsUsecase
+	SearchEntriesUsecase          *usecases.SearchEntriesUsecase
+	SemanticSearchEntriesUsecase  *usecases.SemanticSearchEntriesUsecase
+	ListRelatedEntriesUsecase     *usecases.ListRelatedEntriesUsecase
+	GroupGoalCandidatesUsecase    *usecases.GroupGoalCandidatesUsecase
+	ImportMarkdownEntriesUsecase  *usecases.ImportMarkdownEntriesUsecase
+	ImportGitHistoryUsecase       *usecases.ImportGitHistoryUsecase
+	ListDailyNoteConflictsUsecase *usecases.ListDailyNoteConflictsUsecase
 }
 
-// RegisterRoutes регистрирует ручки /entries и /entries/:idOrDate.
+// RegisterRoutes регистрирует ручки /entries, /entries/search, /entries/search/semantic, /entries/goal-candidates,
+// /entries/import/markdown, /entries/import/git, /entries/:idOrDate, /entries/:id/related, /tags и /daily-notes/conflicts.
 func RegisterRoutes(r *gin.RouterGroup, deps Deps) {
 	createHandler := NewCreateEntryHandler(deps.CreateEntryUsecase)
 	listHandler := NewListEntriesHandler(deps.ListEntriesUsecase)
 	updateHandler := NewUpdateEntryHandler(deps.UpdateEntryUsecase)
 	deleteHandler := NewDeleteEntryHandler(deps.DeleteEntryUsecase)
+	listTagsHandler := NewListTagsHandler(deps.ListTagsUsecase)
+	searchHandler := NewSearchEntriesHandler(deps.SearchEntriesUsecase)
+	semanticSearchHandler := NewSemanticSearchEntriesHandler(deps.SemanticSearchEntriesUsecase)
+	relatedHandler := NewRelatedEntriesHandler(deps.ListRelatedEntriesUsecase)
+	goalCandidatesHandler := NewGoalCandidatesHandler(deps.GroupGoalCandidatesUsecase)
+	importMarkdownHandler := NewImportMarkdownHandler(deps.ImportMarkdownEntriesUsecase)
+	importGitHandler := NewImportGitHandler(deps.ImportGitHistoryUsecase)
+	dailyNoteConflictsHandler := NewListDailyNoteConflictsHandler(deps.ListDailyNoteConflictsUsecase)
 
 	r.POST("/entries", createHandler.Handle)
 	r.GET("/entries", listHandler.Handle)
+	r.GET("/entries/search", searchHandler.Handle)
+	r.GET("/entries/search/semantic", semanticSearchHandler.Handle)
+	r.GET("/entries/goal-candidates", goalCandidatesHandler.Handle)
+	r.POST("/entries/import/markdown", importMarkdownHandler.Handle)
+	r.POST("/entries/import/git", importGitHandler.Handle)
+	r.GET("/entries/:id/related", relatedHandler.Handle)
 	r.PUT("/entries/:idOrDate", updateHandler.Handle)
 	r.DELETE("/entries/:idOrDate", deleteHandler.Handle)
+	r.GET("/tags", listTagsHandler.Handle)
+	r.GET("/daily-notes/conflicts", dailyNoteConflictsHandler.Handle)
 }
@@ -0,0 +1,59 @@
+package entries
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// SearchEntriesHandler отвечает за обработку запроса полнотекстового поиска по записям
+type SearchEntriesHandler struct {
+	usecase *usecases.SearchEntriesUsecase
+}
+
+// NewSearchEntriesHandler создает новый экземпляр SearchEntriesHandler
+func NewSearchEntriesHandler(usecase *usecases.SearchEntriesUsecase) *SearchEntriesHandler {
+	return &SearchEntriesHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на поиск записей пользователя
+func (h *SearchEntriesHandler) Handle(c *gin.Context) {
+	limit, ok := queryLimit(c)
+	if !ok {
+		return
+	}
+	query := usecases.SearchEntriesQuery{
+		UserID: auth.UserID(c),
+		Q:      c.Query("q"),
+		Limit:  limit,
+	}
+
+	results, err := h.usecase.Execute(query)
+	if err != nil {
+		writeEntryError(c, err, "failed to search entries")
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// queryLimit читает необязательный ?limit=. Отсутствующий лимит — 0 (значение по умолчанию usecase);
+// при некорректном значении отвечает 400 и возвращает false
+func queryLimit(c *gin.Context) (int, bool) {
+	raw := c.Query("limit")
+	if raw == "" {
+		return 0, true
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+		return 0, false
+	}
+	return limit, true
+}
@@ -0,0 +1,43 @@
+package entries
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// SemanticSearchEntriesHandler отвечает за обработку запроса семантического поиска по записям
+type SemanticSearchEntriesHandler struct {
+	usecase *usecases.SemanticSearchEntriesUsecase
+}
+
+// NewSemanticSearchEntriesHandler создает новый экземпляр SemanticSearchEntriesHandler
+func NewSemanticSearchEntriesHandler(usecase *usecases.SemanticSearchEntriesUsecase) *SemanticSearchEntriesHandler {
+	return &SemanticSearchEntriesHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на поиск записей по смыслу
+func (h *SemanticSearchEntriesHandler) Handle(c *gin.Context) {
+	limit, ok := queryLimit(c)
+	if !ok {
+		return
+	}
+	query := usecases.SemanticSearchEntriesQuery{
+		UserID: auth.UserID(c),
+		Q:      c.Query("q"),
+		Limit:  limit,
+	}
+
+	results, err := h.usecase.Execute(c.Request.Context(), query)
+	if err != nil {
+		writeEntryError(c, err, "failed to search entries")
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
 	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
 )
 
@@ -36,21 +37,25 @@ func (h *UpdateEntryHandler) Handle(c *gin.Context) {
 	}
 
 	cmd := usecases.UpdateEntryCommand{
+		UserID:  auth.UserID(c),
 		ID:      id,
 		RawText: req.RawText,
+		Tags:    req.Tags,
 	}
 
 	entry, err := h.usecase.Execute(cmd)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update entry"})
+		writeEntryError(c, err, "failed to update entry")
 		return
 	}
 
 	c.JSON(http.StatusOK, entry)
 }
 
-// updateEntryRequest представляет структуру запроса для обновления записи
+// updateEntryRequest представляет структуру запроса для обновления записи.
+// Без поля tags теги записи не меняются, [] снимает все теги
 type updateEntryRequest struct {
-	ID      string `json:"id"`
-	RawText string `json:"raw_text"`
+	ID      string   `json:"id"`
+	RawText string   `json:"raw_text"`
+	Tags    []string `json:"tags"`
 }
@@ -0,0 +1,51 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ChangeGoalStatusHandler отвечает за обработку запроса на смену статуса цели
+type ChangeGoalStatusHandler struct {
+	usecase *usecases.ChangeGoalStatusUsecase
+}
+
+// NewChangeGoalStatusHandler создает новый экземпляр ChangeGoalStatusHandler
+func NewChangeGoalStatusHandler(usecase *usecases.ChangeGoalStatusUsecase) *ChangeGoalStatusHandler {
+	return &ChangeGoalStatusHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на смену статуса цели
+func (h *ChangeGoalStatusHandler) Handle(c *gin.Context) {
+	var req changeGoalStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.ChangeGoalStatusCommand{
+		UserID: auth.UserID(c),
+		ID:     c.Param("id"),
+		Status: req.Status,
+	}
+
+	goal, err := h.usecase.Execute(cmd)
+	if err != nil {
+		writeGoalError(c, err, "failed to change goal status")
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+// changeGoalStatusRequest представляет структуру запроса для смены статуса цели
+type changeGoalStatusRequest struct {
+	Status repositories.GoalStatus `json:"status"`
+}
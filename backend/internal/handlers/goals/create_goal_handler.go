@@ -0,0 +1,54 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// CreateGoalHandler отвечает за обработку запроса на создание цели
+type CreateGoalHandler struct {
+	usecase *usecases.CreateGoalUsecase
+}
+
+// NewCreateGoalHandler создает новый экземпляр CreateGoalHandler
+func NewCreateGoalHandler(usecase *usecases.CreateGoalUsecase) *CreateGoalHandler {
+	return &CreateGoalHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на создание цели
+func (h *CreateGoalHandler) Handle(c *gin.Context) {
+	var req createGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.CreateGoalCommand{
+		UserID:      auth.UserID(c),
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+	}
+
+	goal, err := h.usecase.Execute(cmd)
+	if err != nil {
+		writeGoalError(c, err, "failed to create goal")
+		return
+	}
+
+	c.JSON(http.StatusCreated, goal)
+}
+
+// createGoalRequest представляет структуру запроса для создания цели
+type createGoalRequest struct {
+	Title       string                  `json:"title"`
+	Description string                  `json:"description"`
+	Status      repositories.GoalStatus `json:"status"`
+}
@@ -0,0 +1,37 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// DeleteGoalHandler отвечает за обработку запроса на удаление цели
+type DeleteGoalHandler struct {
+	usecase *usecases.DeleteGoalUsecase
+}
+
+// NewDeleteGoalHandler создает новый экземпляр DeleteGoalHandler
+func NewDeleteGoalHandler(usecase *usecases.DeleteGoalUsecase) *DeleteGoalHandler {
+	return &DeleteGoalHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на удаление цели
+func (h *DeleteGoalHandler) Handle(c *gin.Context) {
+	cmd := usecases.DeleteGoalCommand{
+		UserID: auth.UserID(c),
+		ID:     c.Param("id"),
+	}
+
+	if err := h.usecase.Execute(cmd); err != nil {
+		writeGoalError(c, err, "failed to delete goal")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
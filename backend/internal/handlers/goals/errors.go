@@ -0,0 +1,30 @@
+package goals
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// writeGoalError маппит ошибки usecases целей в HTTP-ответ
+func writeGoalError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usecases.ErrEntryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "entry not found"})
+	case errors.Is(err, repositories.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "goal not found"})
+	case errors.Is(err, usecases.ErrGoalTitleRequired),
+		errors.Is(err, usecases.ErrGoalTitleTooLong),
+		errors.Is(err, usecases.ErrInvalidGoalStatus),
+		errors.Is(err, usecases.ErrInvalidRelevanceScore):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrInvalidGoalStatusTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
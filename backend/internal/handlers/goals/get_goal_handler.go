@@ -0,0 +1,38 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// GetGoalHandler отвечает за обработку запроса на получение цели
+type GetGoalHandler struct {
+	usecase *usecases.GetGoalUsecase
+}
+
+// NewGetGoalHandler создает новый экземпляр GetGoalHandler
+func NewGetGoalHandler(usecase *usecases.GetGoalUsecase) *GetGoalHandler {
+	return &GetGoalHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение цели по ID
+func (h *GetGoalHandler) Handle(c *gin.Context) {
+	query := usecases.GetGoalQuery{
+		UserID: auth.UserID(c),
+		ID:     c.Param("id"),
+	}
+
+	goal, err := h.usecase.Execute(query)
+	if err != nil {
+		writeGoalError(c, err, "failed to get goal")
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
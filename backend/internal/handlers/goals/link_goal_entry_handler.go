@@ -0,0 +1,54 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// LinkGoalEntryHandler отвечает за обработку запроса на привязку записи к цели
+type LinkGoalEntryHandler struct {
+	usecase *usecases.LinkGoalEntryUsecase
+}
+
+// NewLinkGoalEntryHandler создает новый экземпляр LinkGoalEntryHandler
+func NewLinkGoalEntryHandler(usecase *usecases.LinkGoalEntryUsecase) *LinkGoalEntryHandler {
+	return &LinkGoalEntryHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на привязку записи к цели
+func (h *LinkGoalEntryHandler) Handle(c *gin.Context) {
+	var req linkGoalEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.LinkGoalEntryCommand{
+		UserID:         auth.UserID(c),
+		GoalID:         c.Param("id"),
+		EntryID:        req.EntryID,
+		RelevanceScore: req.RelevanceScore,
+		Note:           req.Note,
+	}
+
+	link, err := h.usecase.Execute(cmd)
+	if err != nil {
+		writeGoalError(c, err, "failed to link entry to goal")
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// linkGoalEntryRequest представляет структуру запроса для привязки записи к цели
+type linkGoalEntryRequest struct {
+	EntryID        string   `json:"entry_id"`
+	RelevanceScore *float64 `json:"relevance_score"`
+	Note           string   `json:"note"`
+}
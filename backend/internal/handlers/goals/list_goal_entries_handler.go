@@ -0,0 +1,57 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ListGoalEntriesHandler отвечает за обработку запроса на получение записей цели
+type ListGoalEntriesHandler struct {
+	usecase *usecases.ListGoalEntriesUsecase
+}
+
+// NewListGoalEntriesHandler создает новый экземпляр ListGoalEntriesHandler
+func NewListGoalEntriesHandler(usecase *usecases.ListGoalEntriesUsecase) *ListGoalEntriesHandler {
+	return &ListGoalEntriesHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение записей цели
+func (h *ListGoalEntriesHandler) Handle(c *gin.Context) {
+	query := usecases.ListGoalEntriesQuery{
+		UserID: auth.UserID(c),
+		GoalID: c.Param("id"),
+	}
+
+	goalEntries, err := h.usecase.Execute(query)
+	if err != nil {
+		writeGoalError(c, err, "failed to list goal entries")
+		return
+	}
+
+	resp := make([]goalEntryResponse, 0, len(goalEntries))
+	for _, ge := range goalEntries {
+		resp = append(resp, goalEntryResponse{
+			LinkID:         ge.Link.ID,
+			RelevanceScore: ge.Link.RelevanceScore,
+			Note:           ge.Link.Note,
+			Entry:          ge.Entry,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// goalEntryResponse представляет запись, привязанную к цели
+type goalEntryResponse struct {
+	LinkID         string             `json:"link_id"`
+	RelevanceScore float64            `json:"relevance_score"`
+	Note           string             `json:"note"`
+	Entry          repositories.Entry `json:"entry"`
+}
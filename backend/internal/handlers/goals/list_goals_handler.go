@@ -0,0 +1,39 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ListGoalsHandler отвечает за обработку запроса на получение списка целей
+type ListGoalsHandler struct {
+	usecase *usecases.ListGoalsUsecase
+}
+
+// NewListGoalsHandler создает новый экземпляр ListGoalsHandler
+func NewListGoalsHandler(usecase *usecases.ListGoalsUsecase) *ListGoalsHandler {
+	return &ListGoalsHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение списка целей
+func (h *ListGoalsHandler) Handle(c *gin.Context) {
+	query := usecases.ListGoalsQuery{
+		UserID: auth.UserID(c),
+		Status: repositories.GoalStatus(c.Query("status")),
+	}
+
+	goals, err := h.usecase.Execute(query)
+	if err != nil {
+		writeGoalError(c, err, "failed to list goals")
+		return
+	}
+
+	c.JSON(http.StatusOK, goals)
+}
@@ -0,0 +1,44 @@
+package goals
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// Deps содержит зависимости для goals handlers
+type Deps struct {
+	CreateGoalUsecase       *usecases.CreateGoalUsecase
+	ListGoalsUsecase        *usecases.ListGoalsUsecase
+	GetGoalUsecase          *usecases.GetGoalUsecase
+	UpdateGoalUsecase       *usecases.UpdateGoalUsecase
+	ChangeGoalStatusUsecase *usecases.ChangeGoalStatusUsecase
+	DeleteGoalUsecase       *usecases.DeleteGoalUsecase
+	LinkGoalEntryUsecase    *usecases.LinkGoalEntryUsecase
+	UnlinkGoalEntryUsecase  *usecases.UnlinkGoalEntryUsecase
+	ListGoalEntriesUsecase  *usecases.ListGoalEntriesUsecase
+}
+
+// RegisterRoutes регистрирует ручки /goals, /goals/:id, /goals/:id/status
+// и ручки связей целей с записями /goals/:id/entries.
+func RegisterRoutes(r *gin.RouterGroup, deps Deps) {
+	createHandler := NewCreateGoalHandler(deps.CreateGoalUsecase)
+	listHandler := NewListGoalsHandler(deps.ListGoalsUsecase)
+	getHandler := NewGetGoalHandler(deps.GetGoalUsecase)
+	updateHandler := NewUpdateGoalHandler(deps.UpdateGoalUsecase)
+	changeStatusHandler := NewChangeGoalStatusHandler(deps.ChangeGoalStatusUsecase)
+	deleteHandler := NewDeleteGoalHandler(deps.DeleteGoalUsecase)
+	linkEntryHandler := NewLinkGoalEntryHandler(deps.LinkGoalEntryUsecase)
+	unlinkEntryHandler := NewUnlinkGoalEntryHandler(deps.UnlinkGoalEntryUsecase)
+	listEntriesHandler := NewListGoalEntriesHandler(deps.ListGoalEntriesUsecase)
+
+	r.POST("/goals", createHandler.Handle)
+	r.GET("/goals", listHandler.Handle)
+	r.GET("/goals/:id", getHandler.Handle)
+	r.PUT("/goals/:id", updateHandler.Handle)
+	r.PUT("/goals/:id/status", changeStatusHandler.Handle)
+	r.DELETE("/goals/:id", deleteHandler.Handle)
+	r.GET("/goals/:id/entries", listEntriesHandler.Handle)
+	r.POST("/goals/:id/entries", linkEntryHandler.Handle)
+	r.DELETE("/goals/:id/entries/:entryId", unlinkEntryHandler.Handle)
+}
@@ -0,0 +1,38 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// UnlinkGoalEntryHandler отвечает за обработку запроса на отвязку записи от цели
+type UnlinkGoalEntryHandler struct {
+	usecase *usecases.UnlinkGoalEntryUsecase
+}
+
+// NewUnlinkGoalEntryHandler создает новый экземпляр UnlinkGoalEntryHandler
+func NewUnlinkGoalEntryHandler(usecase *usecases.UnlinkGoalEntryUsecase) *UnlinkGoalEntryHandler {
+	return &UnlinkGoalEntryHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на отвязку записи от цели
+func (h *UnlinkGoalEntryHandler) Handle(c *gin.Context) {
+	cmd := usecases.UnlinkGoalEntryCommand{
+		UserID:  auth.UserID(c),
+		GoalID:  c.Param("id"),
+		EntryID: c.Param("entryId"),
+	}
+
+	if err := h.usecase.Execute(cmd); err != nil {
+		writeGoalError(c, err, "failed to unlink entry from goal")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "unlinked"})
+}
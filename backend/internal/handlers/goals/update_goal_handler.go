@@ -0,0 +1,52 @@
+package goals
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// UpdateGoalHandler отвечает за обработку запроса на обновление цели
+type UpdateGoalHandler struct {
+	usecase *usecases.UpdateGoalUsecase
+}
+
+// NewUpdateGoalHandler создает новый экземпляр UpdateGoalHandler
+func NewUpdateGoalHandler(usecase *usecases.UpdateGoalUsecase) *UpdateGoalHandler {
+	return &UpdateGoalHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на обновление цели
+func (h *UpdateGoalHandler) Handle(c *gin.Context) {
+	var req updateGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.UpdateGoalCommand{
+		UserID:      auth.UserID(c),
+		ID:          c.Param("id"),
+		Title:       req.Title,
+		Description: req.Description,
+	}
+
+	goal, err := h.usecase.Execute(cmd)
+	if err != nil {
+		writeGoalError(c, err, "failed to update goal")
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+// updateGoalRequest представляет структуру запроса для обновления цели
+type updateGoalRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,91 @@
+package perf
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// SummaryJobResponse представляет структуру ответа задачи генерации перф-саммари
+type SummaryJobResponse struct {
+	ID          string                        `json:"id"`
+	Status      repositories.SummaryJobStatus `json:"status"`
+	Progress    int                           `json:"progress"`
+	PeriodStart string                        `json:"period_start"`
+	PeriodEnd   string                        `json:"period_end"`
+	Tags        []string                      `json:"tags"`
+	Error       string                        `json:"error,omitempty"`
+	Result      *PerfSummaryResponse          `json:"result,omitempty"`
+	CreatedAt   time.Time                     `json:"created_at"`
+	UpdatedAt   time.Time                     `json:"updated_at"`
+}
+
+// CreateSummaryJobHandler отвечает за обработку запроса на постановку генерации в очередь
+type CreateSummaryJobHandler struct {
+	usecase *usecases.EnqueueSummaryJobUsecase
+}
+
+// NewCreateSummaryJobHandler создает новый экземпляр CreateSummaryJobHandler
+func NewCreateSummaryJobHandler(usecase *usecases.EnqueueSummaryJobUsecase) *CreateSummaryJobHandler {
+	return &CreateSummaryJobHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на постановку генерации перф-саммари в очередь
+func (h *CreateSummaryJobHandler) Handle(c *gin.Context) {
+	var req perfSummaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.GeneratePerfSummaryCommand{
+		UserID:      auth.UserID(c),
+		PeriodStart: req.StartDate,
+		PeriodEnd:   req.EndDate,
+		Role:        req.Role,
+		Tags:        req.Tags,
+	}
+
+	job, err := h.usecase.Execute(cmd)
+	if errors.Is(err, usecases.ErrInvalidSummaryPeriod) || errors.Is(err, usecases.ErrTagTooLong) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to enqueue perf summary job"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toSummaryJobResponse(usecases.SummaryJobResult{Job: job}))
+}
+
+// toSummaryJobResponse маппит задачу в HTTP-ответ
+func toSummaryJobResponse(result usecases.SummaryJobResult) SummaryJobResponse {
+	resp := SummaryJobResponse{
+		ID:          result.Job.ID,
+		Status:      result.Job.Status,
+		Progress:    result.Job.Progress,
+		PeriodStart: result.Job.PeriodStart,
+		PeriodEnd:   result.Job.PeriodEnd,
+		Tags:        result.Job.Tags,
+		Error:       result.Job.Error,
+		CreatedAt:   result.Job.CreatedAt,
+		UpdatedAt:   result.Job.UpdatedAt,
+	}
+	if resp.Tags == nil {
+		resp.Tags = []string{}
+	}
+	if result.Summary != nil {
+		summary := toPerfSummaryResponse(*result.Summary)
+		resp.Result = &summary
+	}
+	return resp
+}
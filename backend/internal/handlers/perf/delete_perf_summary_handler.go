@@ -0,0 +1,44 @@
+package perf
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// DeletePerfSummaryHandler отвечает за обработку запроса на удаление перф-саммари
+type DeletePerfSummaryHandler struct {
+	usecase *usecases.DeletePerfSummaryUsecase
+}
+
+// NewDeletePerfSummaryHandler создает новый экземпляр DeletePerfSummaryHandler
+func NewDeletePerfSummaryHandler(usecase *usecases.DeletePerfSummaryUsecase) *DeletePerfSummaryHandler {
+	return &DeletePerfSummaryHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на удаление перф-саммари по ID
+func (h *DeletePerfSummaryHandler) Handle(c *gin.Context) {
+	cmd := usecases.DeletePerfSummaryCommand{
+		UserID: auth.UserID(c),
+		ID:     c.Param("id"),
+	}
+
+	err := h.usecase.Execute(cmd)
+	if errors.Is(err, repositories.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "perf summary not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete perf summary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
@@ -0,0 +1,44 @@
+package perf
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// PerfSummaryIssue представляет нарушение схемы в ответе модели
+type PerfSummaryIssue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// PerfSummaryErrorResponse представляет структуру ошибки генерации перф-саммари.
+// Details заполняется, если модель так и не вернула корректный JSON.
+type PerfSummaryErrorResponse struct {
+	Error    string             `json:"error"`
+	Attempts int                `json:"attempts,omitempty"`
+	Details  []PerfSummaryIssue `json:"details,omitempty"`
+}
+
+// toPerfSummaryError маппит ошибку генерации перф-саммари в HTTP-статус и тело ответа
+func toPerfSummaryError(err error, fallback string) (int, PerfSummaryErrorResponse) {
+	if errors.Is(err, usecases.ErrInvalidSummaryPeriod) || errors.Is(err, usecases.ErrTagTooLong) {
+		return http.StatusBadRequest, PerfSummaryErrorResponse{Error: err.Error()}
+	}
+
+	var validationErr *usecases.PerfSummaryValidationError
+	if !errors.As(err, &validationErr) {
+		return http.StatusInternalServerError, PerfSummaryErrorResponse{Error: fallback}
+	}
+
+	resp := PerfSummaryErrorResponse{
+		Error:    "llm answer failed validation",
+		Attempts: validationErr.Attempts,
+		Details:  make([]PerfSummaryIssue, 0, len(validationErr.Issues)),
+	}
+	for _, issue := range validationErr.Issues {
+		resp.Details = append(resp.Details, PerfSummaryIssue{Path: issue.Path, Message: issue.Message})
+	}
+	return http.StatusUnprocessableEntity, resp
+}
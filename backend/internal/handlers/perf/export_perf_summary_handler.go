@@ -0,0 +1,52 @@
+package perf
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/export"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ExportPerfSummaryHandler отвечает за обработку запроса на экспорт перф-саммари в файл
+type ExportPerfSummaryHandler struct {
+	usecase *usecases.ExportPerfSummaryUsecase
+}
+
+// NewExportPerfSummaryHandler создает новый экземпляр ExportPerfSummaryHandler
+func NewExportPerfSummaryHandler(usecase *usecases.ExportPerfSummaryUsecase) *ExportPerfSummaryHandler {
+	return &ExportPerfSummaryHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на экспорт перф-саммари по ID в формате из query-параметра format
+func (h *ExportPerfSummaryHandler) Handle(c *gin.Context) {
+	query := usecases.ExportPerfSummaryQuery{
+		UserID: auth.UserID(c),
+		ID:     c.Param("id"),
+		Format: export.Format(c.Query("format")),
+	}
+
+	file, err := h.usecase.Execute(query)
+	if errors.Is(err, repositories.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "perf summary not found"})
+		return
+	}
+	if errors.Is(err, export.ErrUnsupportedFormat) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export perf summary"})
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
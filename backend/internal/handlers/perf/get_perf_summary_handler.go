@@ -0,0 +1,44 @@
+package perf
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// GetPerfSummaryHandler отвечает за обработку запроса на получение перф-саммари
+type GetPerfSummaryHandler struct {
+	usecase *usecases.GetPerfSummaryUsecase
+}
+
+// NewGetPerfSummaryHandler создает новый экземпляр GetPerfSummaryHandler
+func NewGetPerfSummaryHandler(usecase *usecases.GetPerfSummaryUsecase) *GetPerfSummaryHandler {
+	return &GetPerfSummaryHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение перф-саммари по ID
+func (h *GetPerfSummaryHandler) Handle(c *gin.Context) {
+	query := usecases.GetPerfSummaryQuery{
+		UserID: auth.UserID(c),
+		ID:     c.Param("id"),
+	}
+
+	summary, err := h.usecase.Execute(query)
+	if errors.Is(err, repositories.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "perf summary not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get perf summary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toPerfSummaryResponse(summary))
+}
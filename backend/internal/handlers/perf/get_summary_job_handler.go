@@ -0,0 +1,44 @@
+package perf
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// GetSummaryJobHandler отвечает за обработку запроса на получение статуса задачи
+type GetSummaryJobHandler struct {
+	usecase *usecases.GetSummaryJobUsecase
+}
+
+// NewGetSummaryJobHandler создает новый экземпляр GetSummaryJobHandler
+func NewGetSummaryJobHandler(usecase *usecases.GetSummaryJobUsecase) *GetSummaryJobHandler {
+	return &GetSummaryJobHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение статуса, прогресса и результата задачи по ID
+func (h *GetSummaryJobHandler) Handle(c *gin.Context) {
+	query := usecases.GetSummaryJobQuery{
+		UserID: auth.UserID(c),
+		ID:     c.Param("id"),
+	}
+
+	result, err := h.usecase.Execute(query)
+	if errors.Is(err, repositories.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "summary job not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get summary job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSummaryJobResponse(result))
+}
@@ -0,0 +1,42 @@
+package perf
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ListPerfSummariesHandler отвечает за обработку запроса на получение истории перф-саммари
+type ListPerfSummariesHandler struct {
+	usecase *usecases.ListPerfSummariesUsecase
+}
+
+// NewListPerfSummariesHandler создает новый экземпляр ListPerfSummariesHandler
+func NewListPerfSummariesHandler(usecase *usecases.ListPerfSummariesUsecase) *ListPerfSummariesHandler {
+	return &ListPerfSummariesHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение истории перф-саммари
+func (h *ListPerfSummariesHandler) Handle(c *gin.Context) {
+	query := usecases.ListPerfSummariesQuery{
+		UserID: auth.UserID(c),
+	}
+
+	summaries, err := h.usecase.Execute(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list perf summaries"})
+		return
+	}
+
+	resp := make([]PerfSummaryResponse, 0, len(summaries))
+	for _, s := range summaries {
+		resp = append(resp, toPerfSummaryResponse(s))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
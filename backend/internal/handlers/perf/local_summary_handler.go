@@ -0,0 +1,76 @@
+package perf
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// LocalSummaryResponse представляет структуру ответа саммари дня или недели.
+// Cached показывает, что записи периода не менялись и саммари взято из кэша
+type LocalSummaryResponse struct {
+	PeriodStart    string                            `json:"period_start"`
+	PeriodEnd      string                            `json:"period_end"`
+	Bullets        []repositories.LocalSummaryBullet `json:"bullets"`
+	CandidateGoals []string                          `json:"candidate_goals"`
+	Cached         bool                              `json:"cached"`
+	CreatedAt      time.Time                         `json:"created_at"`
+}
+
+// localSummaryRequest представляет структуру запроса саммари дня или недели
+type localSummaryRequest struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// LocalSummaryHandler отвечает за обработку запроса саммари дня или недели
+type LocalSummaryHandler struct {
+	usecase *usecases.GenerateLocalSummaryUsecase
+}
+
+// NewLocalSummaryHandler создает новый экземпляр LocalSummaryHandler
+func NewLocalSummaryHandler(usecase *usecases.GenerateLocalSummaryUsecase) *LocalSummaryHandler {
+	return &LocalSummaryHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос саммари дня или недели
+func (h *LocalSummaryHandler) Handle(c *gin.Context) {
+	var req localSummaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.GenerateLocalSummaryCommand{
+		UserID:      auth.UserID(c),
+		PeriodStart: req.StartDate,
+		PeriodEnd:   req.EndDate,
+	}
+
+	summary, cached, err := h.usecase.Execute(c.Request.Context(), cmd)
+	if errors.Is(err, usecases.ErrInvalidLocalSummaryPeriod) || errors.Is(err, usecases.ErrLocalSummaryTooLarge) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate local summary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, LocalSummaryResponse{
+		PeriodStart:    summary.PeriodStart,
+		PeriodEnd:      summary.PeriodEnd,
+		Bullets:        summary.Bullets,
+		CandidateGoals: summary.CandidateGoals,
+		Cached:         cached,
+		CreatedAt:      summary.CreatedAt,
+	})
+}
@@ -0,0 +1,64 @@
+package perf
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// slowProvider отвечает через delay — как модель, которая думает дольше WriteTimeout сервера
+type slowProvider struct {
+	inner llm.Provider
+	delay time.Duration
+}
+
+func (p *slowProvider) Complete(ctx context.Context, req llm.CompletionRequest) (llm.CompletionResponse, error) {
+	select {
+	case <-ctx.Done():
+		return llm.CompletionResponse{}, ctx.Err()
+	case <-time.After(p.delay):
+	}
+	return p.inner.Complete(ctx, req)
+}
+
+func TestPerfSummaryOutlivesWriteTimeout(t *testing.T) {
+	entries := repositories.NewInMemoryEntriesRepository()
+	if _, err := entries.Create(repositories.Entry{ID: "e1", UserID: streamTestUser, Date: "2026-01-05", Type: repositories.EntryTypeFact, RawText: "Shipped login"}); err != nil {
+		t.Fatal(err)
+	}
+	provider := &slowProvider{inner: llm.NewFakeProvider(), delay: 300 * time.Millisecond}
+	generate := usecases.NewGeneratePerfSummaryUsecase(entries, repositories.NewInMemoryPerfSummaryRepository(), repositories.NewInMemoryUsersRepository(), repositories.NewInMemoryTagsRepository(), provider, llm.Budget{ContextTokens: 100000, OutputTokens: 512})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(auth.Middleware(nil, nil, streamTestUser))
+	r.POST("/perf/summary", NewPerfSummaryHandler(generate).Handle)
+	server := httptest.NewUnstartedServer(r)
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/perf/summary", "application/json", strings.NewReader(`{"start_date":"2026-01-01","end_date":"2026-01-31"}`))
+	if err != nil {
+		t.Fatalf("response lost after WriteTimeout: %v", err)
+	}
+	defer resp.Body.Close()
+	var summary PerfSummaryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || len(summary.Goals) != 1 {
+		t.Fatalf("status = %d, summary = %+v", resp.StatusCode, summary)
+	}
+}
@@ -2,13 +2,45 @@ package perf
 
 import (
 	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
 )
 
 // Deps содержит зависимости для perf handlers
-type Deps struct{}
+type Deps struct {
+	GeneratePerfSummaryUsecase  *usecases.GeneratePerfSummaryUsecase
+	ListPerfSummariesUsecase    *usecases.ListPerfSummariesUsecase
+	GetPerfSummaryUsecase       *usecases.GetPerfSummaryUsecase
+	DeletePerfSummaryUsecase    *usecases.DeletePerfSummaryUsecase
+	EnqueueSummaryJobUsecase    *usecases.EnqueueSummaryJobUsecase
+	GetSummaryJobUsecase        *usecases.GetSummaryJobUsecase
+	GenerateLocalSummaryUsecase *usecases.GenerateLocalSummaryUsecase
+	ExportPerfSummaryUsecase    *usecases.ExportPerfSummaryUsecase
+}
 
-// RegisterRoutes регистрирует mock-ручку для перф-саммари.
+// RegisterRoutes регистрирует ручки для генерации, истории и экспорта перф-саммари и саммари дня или недели.
+// Путь /perf/summary:mock сохранён для совместимости с текущим фронтендом.
 func RegisterRoutes(r *gin.RouterGroup, deps Deps) {
-	handler := NewPerfSummaryHandler()
-	r.POST("/perf/summary:mock", handler.Handle)
+	generateHandler := NewPerfSummaryHandler(deps.GeneratePerfSummaryUsecase)
+	streamHandler := NewStreamPerfSummaryHandler(deps.GeneratePerfSummaryUsecase)
+	listHandler := NewListPerfSummariesHandler(deps.ListPerfSummariesUsecase)
+	getHandler := NewGetPerfSummaryHandler(deps.GetPerfSummaryUsecase)
+	deleteHandler := NewDeletePerfSummaryHandler(deps.DeletePerfSummaryUsecase)
+	createJobHandler := NewCreateSummaryJobHandler(deps.EnqueueSummaryJobUsecase)
+	getJobHandler := NewGetSummaryJobHandler(deps.GetSummaryJobUsecase)
+	localSummaryHandler := NewLocalSummaryHandler(deps.GenerateLocalSummaryUsecase)
+	exportHandler := NewExportPerfSummaryHandler(deps.ExportPerfSummaryUsecase)
+
+	r.POST("/perf/summary", generateHandler.Handle)
+	r.POST("/perf/summary:mock", generateHandler.Handle)
+	// SSE работает только через GET, но генерирует и сохраняет саммари — для API-токена нужна запись
+	r.GET("/perf/summary/stream", auth.RequireScope(auth.ScopePerfWrite), streamHandler.Handle)
+	r.POST("/perf/summary/jobs", createJobHandler.Handle)
+	r.GET("/perf/summary/jobs/:id", getJobHandler.Handle)
+	r.GET("/perf/summaries", listHandler.Handle)
+	r.GET("/perf/summaries/:id", getHandler.Handle)
+	r.DELETE("/perf/summaries/:id", deleteHandler.Handle)
+	r.GET("/perf/summaries/:id/export", exportHandler.Handle)
+	r.POST("/summaries/local", localSummaryHandler.Handle)
 }
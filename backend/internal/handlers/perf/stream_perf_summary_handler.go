@@ -0,0 +1,96 @@
+package perf
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// StreamPerfSummaryHandler отвечает за генерацию перф-саммари с отдачей прогресса через Server-Sent Events
+type StreamPerfSummaryHandler struct {
+	usecase *usecases.GeneratePerfSummaryUsecase
+}
+
+// NewStreamPerfSummaryHandler создает новый экземпляр StreamPerfSummaryHandler
+func NewStreamPerfSummaryHandler(usecase *usecases.GeneratePerfSummaryUsecase) *StreamPerfSummaryHandler {
+	return &StreamPerfSummaryHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на потоковую генерацию перф-саммари.
+// События: entries_loaded, token, goal_drafted, chunk_summarized, затем done или error.
+func (h *StreamPerfSummaryHandler) Handle(c *gin.Context) {
+	cmd := usecases.GeneratePerfSummaryCommand{
+		UserID:      auth.UserID(c),
+		PeriodStart: c.Query("start_date"),
+		PeriodEnd:   c.Query("end_date"),
+		Role:        c.Query("role"),
+		Tags:        c.QueryArray("tags"),
+	}
+
+	// Генерация может идти дольше WriteTimeout сервера — снимаем дедлайн для этого ответа
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("perf summary stream: failed to reset write deadline: %v", err)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Отключаем буферизацию в nginx, иначе события придут одним куском
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	summary, err := h.usecase.ExecuteWithProgress(c.Request.Context(), cmd, func(event usecases.PerfSummaryEvent) {
+		writeSSE(c, string(event.Type), toPerfSummaryEventPayload(event))
+	})
+	if err != nil {
+		_, payload := toPerfSummaryError(err, "failed to generate perf summary")
+		writeSSE(c, "error", payload)
+		return
+	}
+
+	writeSSE(c, "done", toPerfSummaryResponse(summary))
+}
+
+// perfSummaryEventPayload представляет данные SSE-события прогресса
+type perfSummaryEventPayload struct {
+	EntriesCount *int      `json:"entries_count,omitempty"`
+	Chunk        int       `json:"chunk,omitempty"`
+	TotalChunks  int       `json:"total_chunks,omitempty"`
+	Token        string    `json:"token,omitempty"`
+	Goal         *PerfGoal `json:"goal,omitempty"`
+}
+
+// toPerfSummaryEventPayload маппит событие usecase в данные SSE-события
+func toPerfSummaryEventPayload(event usecases.PerfSummaryEvent) perfSummaryEventPayload {
+	payload := perfSummaryEventPayload{
+		Chunk:       event.Chunk,
+		TotalChunks: event.TotalChunks,
+		Token:       event.Token,
+	}
+	if event.Type == usecases.PerfSummaryEventEntriesLoaded {
+		count := event.EntriesCount
+		payload.EntriesCount = &count
+	}
+	if event.Goal != nil {
+		goal := toPerfGoalResponse(*event.Goal)
+		payload.Goal = &goal
+	}
+	return payload
+}
+
+// writeSSE отправляет одно событие и сразу сбрасывает буфер клиенту
+func writeSSE(c *gin.Context, event string, data any) {
+	c.Render(-1, sse.Event{
+		Event: event,
+		Data:  data,
+	})
+	c.Writer.Flush()
+}
@@ -0,0 +1,238 @@
+package perf
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/prompts"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+const streamTestUser = "u1"
+
+// sseEvent — одно событие из потока ответа
+type sseEvent struct {
+	name string
+	data string
+}
+
+// streamPerfSummary поднимает сервер с ручкой потоковой генерации и возвращает события ответа на запрос с query
+func streamPerfSummary(t *testing.T, provider llm.Provider, budget llm.Budget, entries []repositories.Entry, query string) []sseEvent {
+	t.Helper()
+	entriesRepo := repositories.NewInMemoryEntriesRepository()
+	for _, e := range entries {
+		e.UserID = streamTestUser
+		if _, err := entriesRepo.Create(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	generate := usecases.NewGeneratePerfSummaryUsecase(entriesRepo, repositories.NewInMemoryPerfSummaryRepository(), repositories.NewInMemoryUsersRepository(), repositories.NewInMemoryTagsRepository(), provider, budget)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(auth.Middleware(nil, nil, streamTestUser))
+	r.GET("/perf/summary/stream", NewStreamPerfSummaryHandler(generate).Handle)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/perf/summary/stream?" + query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		t.Fatalf("status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []sseEvent
+	for _, block := range strings.Split(strings.TrimSpace(string(body)), "\n\n") {
+		var event sseEvent
+		for _, line := range strings.Split(block, "\n") {
+			if name, ok := strings.CutPrefix(line, "event:"); ok {
+				event.name = name
+			}
+			if data, ok := strings.CutPrefix(line, "data:"); ok {
+				event.data = data
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// eventOrder возвращает названия событий через пробел, схлопывая подряд идущие повторы
+func eventOrder(events []sseEvent) string {
+	var names []string
+	for i, e := range events {
+		if i > 0 && events[i-1].name == e.name {
+			continue
+		}
+		names = append(names, e.name)
+	}
+	return strings.Join(names, " ")
+}
+
+// decodeEvent разбирает данные события в v
+func decodeEvent(t *testing.T, e sseEvent, v any) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(e.data), v); err != nil {
+		t.Fatalf("event %s: %v in %q", e.name, err, e.data)
+	}
+}
+
+func TestStreamPerfSummary(t *testing.T) {
+	short := []repositories.Entry{
+		{ID: "e1", Date: "2026-01-05", Type: repositories.EntryTypeFact, RawText: "Shipped login"},
+		{ID: "e2", Date: "2026-01-06", Type: repositories.EntryTypeFact, RawText: "Fixed logout"},
+	}
+	// Три недели длинных записей не помещаются в бюджет одним запросом
+	long := []repositories.Entry{
+		{ID: "e1", Date: "2026-01-05", Type: repositories.EntryTypeFact, RawText: strings.Repeat("migrated the billing service. ", 30)},
+		{ID: "e2", Date: "2026-01-13", Type: repositories.EntryTypeFact, RawText: strings.Repeat("removed the old billing queue. ", 30)},
+		{ID: "e3", Date: "2026-01-20", Type: repositories.EntryTypeFact, RawText: strings.Repeat("moved invoices to the new service. ", 30)},
+	}
+	promptTokens := llm.EstimateTokens(prompts.PerfSummaryPrompt)
+
+	cases := []struct {
+		name       string
+		budget     llm.Budget
+		entries    []repositories.Entry
+		wantOrder  string
+		wantChunks int
+	}{
+		{
+			name:       "whole period",
+			budget:     llm.Budget{ContextTokens: 100000, OutputTokens: 512},
+			entries:    short,
+			wantOrder:  "entries_loaded token goal_drafted chunk_summarized done",
+			wantChunks: 1,
+		},
+		{
+			name:       "weekly chunks",
+			budget:     llm.Budget{ContextTokens: promptTokens + 900, OutputTokens: 400},
+			entries:    long,
+			wantOrder:  "entries_loaded chunk_summarized token goal_drafted chunk_summarized done",
+			wantChunks: 4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			events := streamPerfSummary(t, llm.NewFakeProvider(), tc.budget, tc.entries, "start_date=2026-01-01&end_date=2026-01-31")
+			if got := eventOrder(events); got != tc.wantOrder {
+				t.Fatalf("events = %q, want %q", got, tc.wantOrder)
+			}
+
+			var loaded perfSummaryEventPayload
+			decodeEvent(t, events[0], &loaded)
+			if loaded.EntriesCount == nil || *loaded.EntriesCount != len(tc.entries) {
+				t.Fatalf("entries_loaded = %s", events[0].data)
+			}
+
+			var tokens strings.Builder
+			var drafted []string
+			chunk := 0
+			for _, e := range events[1 : len(events)-1] {
+				var payload perfSummaryEventPayload
+				decodeEvent(t, e, &payload)
+				switch e.name {
+				case "token":
+					tokens.WriteString(payload.Token)
+				case "goal_drafted":
+					drafted = append(drafted, payload.Goal.Title)
+				case "chunk_summarized":
+					// Недели приходят по порядку, последним — сборка целей
+					chunk++
+					if payload.Chunk != chunk || payload.TotalChunks != tc.wantChunks {
+						t.Fatalf("chunk_summarized = %s, want chunk %d of %d", e.data, chunk, tc.wantChunks)
+					}
+				}
+			}
+			if chunk != tc.wantChunks {
+				t.Fatalf("got %d chunk_summarized events, want %d", chunk, tc.wantChunks)
+			}
+
+			// Токены складываются в ответ модели, а done отдаёт сохранённое саммари с теми же целями
+			var answer struct {
+				Goals []struct {
+					Title string `json:"title"`
+				} `json:"goals"`
+			}
+			if err := json.Unmarshal([]byte(tokens.String()), &answer); err != nil {
+				t.Fatalf("tokens do not form the answer: %v", err)
+			}
+			var done PerfSummaryResponse
+			decodeEvent(t, events[len(events)-1], &done)
+			if done.ID == "" || len(done.Goals) != len(answer.Goals) || len(drafted) != len(done.Goals) {
+				t.Fatalf("done = %s, drafted %q", events[len(events)-1].data, drafted)
+			}
+			for i, g := range done.Goals {
+				if g.Title != answer.Goals[i].Title || g.Title != drafted[i] {
+					t.Fatalf("goal %d = %q, answer %q, drafted %q", i, g.Title, answer.Goals[i].Title, drafted[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStreamPerfSummaryError(t *testing.T) {
+	entries := []repositories.Entry{{ID: "e1", Date: "2026-01-05", Type: repositories.EntryTypeFact, RawText: "Shipped login"}}
+	invalid := llm.ScriptedStep{Content: `{"goals":[{"title":"","outputs":[],"outcomes":[]}]}`}
+
+	cases := []struct {
+		name      string
+		query     string
+		steps     []llm.ScriptedStep
+		wantOrder string
+		want      PerfSummaryErrorResponse
+	}{
+		{
+			name:      "invalid period",
+			query:     "start_date=2026-02-01&end_date=2026-01-31",
+			wantOrder: "error",
+			want:      PerfSummaryErrorResponse{Error: usecases.ErrInvalidSummaryPeriod.Error()},
+		},
+		{
+			name:      "provider fails",
+			query:     "start_date=2026-01-01&end_date=2026-01-31",
+			steps:     []llm.ScriptedStep{{Err: errors.New("model is unavailable")}},
+			wantOrder: "entries_loaded error",
+			want:      PerfSummaryErrorResponse{Error: "failed to generate perf summary"},
+		},
+		{
+			name:      "answer fails validation",
+			query:     "start_date=2026-01-01&end_date=2026-01-31",
+			steps:     []llm.ScriptedStep{invalid, invalid, invalid},
+			wantOrder: "entries_loaded token error",
+			want:      PerfSummaryErrorResponse{Error: "llm answer failed validation", Attempts: 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			events := streamPerfSummary(t, llm.NewScriptedProvider(tc.steps...), llm.Budget{ContextTokens: 100000, OutputTokens: 512}, entries, tc.query)
+			if got := eventOrder(events); got != tc.wantOrder {
+				t.Fatalf("events = %q, want %q", got, tc.wantOrder)
+			}
+			var got PerfSummaryErrorResponse
+			decodeEvent(t, events[len(events)-1], &got)
+			if got.Error != tc.want.Error || got.Attempts != tc.want.Attempts {
+				t.Fatalf("error = %s, want %+v", events[len(events)-1].data, tc.want)
+			}
+		})
+	}
+}
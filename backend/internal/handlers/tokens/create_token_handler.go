@@ -0,0 +1,68 @@
+package tokens
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// CreateTokenHandler отвечает за обработку запроса на выпуск API-токена
+type CreateTokenHandler struct {
+	usecase *usecases.CreateAPITokenUsecase
+}
+
+// NewCreateTokenHandler создает новый экземпляр CreateTokenHandler
+func NewCreateTokenHandler(usecase *usecases.CreateAPITokenUsecase) *CreateTokenHandler {
+	return &CreateTokenHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на выпуск токена. Значение токена возвращается только в этом ответе
+func (h *CreateTokenHandler) Handle(c *gin.Context) {
+	var req createTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.CreateAPITokenCommand{
+		UserID: auth.UserID(c),
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	}
+
+	created, err := h.usecase.Execute(cmd)
+	switch {
+	case errors.Is(err, usecases.ErrAPITokenNameRequired),
+		errors.Is(err, usecases.ErrAPITokenNameTooLong),
+		errors.Is(err, usecases.ErrInvalidAPITokenScope):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create api token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreatedTokenResponse{
+		APIToken: created.Token,
+		Token:    created.Secret,
+	})
+}
+
+// createTokenRequest представляет структуру запроса для выпуска токена
+type createTokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreatedTokenResponse — выпущенный токен вместе с его значением
+type CreatedTokenResponse struct {
+	repositories.APIToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,33 @@
+package tokens
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// ListTokensHandler отвечает за обработку запроса на получение списка API-токенов
+type ListTokensHandler struct {
+	usecase *usecases.ListAPITokensUsecase
+}
+
+// NewListTokensHandler создает новый экземпляр ListTokensHandler
+func NewListTokensHandler(usecase *usecases.ListAPITokensUsecase) *ListTokensHandler {
+	return &ListTokensHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение активных токенов текущего пользователя
+func (h *ListTokensHandler) Handle(c *gin.Context) {
+	tokens, err := h.usecase.Execute(usecases.ListAPITokensQuery{UserID: auth.UserID(c)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list api tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
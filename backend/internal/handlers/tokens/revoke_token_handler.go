@@ -0,0 +1,44 @@
+package tokens
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// RevokeTokenHandler отвечает за обработку запроса на отзыв API-токена
+type RevokeTokenHandler struct {
+	usecase *usecases.RevokeAPITokenUsecase
+}
+
+// NewRevokeTokenHandler создает новый экземпляр RevokeTokenHandler
+func NewRevokeTokenHandler(usecase *usecases.RevokeAPITokenUsecase) *RevokeTokenHandler {
+	return &RevokeTokenHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на отзыв токена по ID
+func (h *RevokeTokenHandler) Handle(c *gin.Context) {
+	cmd := usecases.RevokeAPITokenCommand{
+		UserID: auth.UserID(c),
+		ID:     c.Param("id"),
+	}
+
+	err := h.usecase.Execute(cmd)
+	if errors.Is(err, repositories.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "api token not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke api token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "revoked"})
+}
@@ -0,0 +1,25 @@
+package tokens
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// Deps содержит зависимости для tokens handlers
+type Deps struct {
+	CreateAPITokenUsecase *usecases.CreateAPITokenUsecase
+	ListAPITokensUsecase  *usecases.ListAPITokensUsecase
+	RevokeAPITokenUsecase *usecases.RevokeAPITokenUsecase
+}
+
+// RegisterRoutes регистрирует ручки управления персональными API-токенами /users/me/tokens
+func RegisterRoutes(r *gin.RouterGroup, deps Deps) {
+	createHandler := NewCreateTokenHandler(deps.CreateAPITokenUsecase)
+	listHandler := NewListTokensHandler(deps.ListAPITokensUsecase)
+	revokeHandler := NewRevokeTokenHandler(deps.RevokeAPITokenUsecase)
+
+	r.POST("/users/me/tokens", createHandler.Handle)
+	r.GET("/users/me/tokens", listHandler.Handle)
+	r.DELETE("/users/me/tokens/:id", revokeHandler.Handle)
+}
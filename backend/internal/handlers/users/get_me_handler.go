@@ -0,0 +1,35 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// GetMeHandler отвечает за обработку запроса на получение профиля текущего пользователя
+type GetMeHandler struct {
+	usecase *usecases.GetUserProfileUsecase
+}
+
+// NewGetMeHandler создает новый экземпляр GetMeHandler
+func NewGetMeHandler(usecase *usecases.GetUserProfileUsecase) *GetMeHandler {
+	return &GetMeHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на получение профиля
+func (h *GetMeHandler) Handle(c *gin.Context) {
+	userID := auth.UserID(c)
+
+	user, err := h.usecase.Execute(usecases.GetUserProfileQuery{UserID: userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get user profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,22 @@
+package users
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// Deps содержит зависимости для users handlers
+type Deps struct {
+	GetUserProfileUsecase    *usecases.GetUserProfileUsecase
+	UpdateUserProfileUsecase *usecases.UpdateUserProfileUsecase
+}
+
+// RegisterRoutes регистрирует ручки профиля текущего пользователя /users/me
+func RegisterRoutes(r *gin.RouterGroup, deps Deps) {
+	getMeHandler := NewGetMeHandler(deps.GetUserProfileUsecase)
+	updateMeHandler := NewUpdateMeHandler(deps.UpdateUserProfileUsecase)
+
+	r.GET("/users/me", getMeHandler.Handle)
+	r.PUT("/users/me", updateMeHandler.Handle)
+}
@@ -0,0 +1,65 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// UpdateMeHandler отвечает за обработку запроса на обновление профиля текущего пользователя
+type UpdateMeHandler struct {
+	usecase *usecases.UpdateUserProfileUsecase
+}
+
+// NewUpdateMeHandler создает новый экземпляр UpdateMeHandler
+func NewUpdateMeHandler(usecase *usecases.UpdateUserProfileUsecase) *UpdateMeHandler {
+	return &UpdateMeHandler{
+		usecase: usecase,
+	}
+}
+
+// Handle обрабатывает запрос на обновление профиля. Переданные поля заменяются, остальные не меняются
+func (h *UpdateMeHandler) Handle(c *gin.Context) {
+	userID := auth.UserID(c)
+
+	var req updateMeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		return
+	}
+
+	cmd := usecases.UpdateUserProfileCommand{
+		UserID:                userID,
+		Name:                  req.Name,
+		Role:                  req.Role,
+		PerfCycleLengthMonths: req.PerfCycleLengthMonths,
+		Locale:                req.Locale,
+	}
+
+	user, err := h.usecase.Execute(cmd)
+	switch {
+	case errors.Is(err, usecases.ErrUserNameTooLong),
+		errors.Is(err, usecases.ErrInvalidUserRole),
+		errors.Is(err, usecases.ErrInvalidPerfCycleLength),
+		errors.Is(err, usecases.ErrInvalidUserLocale):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update user profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// updateMeRequest представляет структуру запроса для обновления профиля
+type updateMeRequest struct {
+	Name                  *string `json:"name"`
+	Role                  *string `json:"role"`
+	PerfCycleLengthMonths *int    `json:"perf_cycle_length_months"`
+	Locale                *string `json:"locale"`
+}
@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Интервалы фоновой обработки: опрос новых записей и пауза после ошибки провайдера
+const (
+	defaultBatchPollInterval = 5 * time.Second
+	defaultBatchErrorBackoff = time.Minute
+)
+
+// BatchProcessor обрабатывает очередную пачку записей.
+// Возвращает false, если обрабатывать нечего
+type BatchProcessor interface {
+	Execute(ctx context.Context) (bool, error)
+}
+
+// BatchWorker в фоне обрабатывает записи пачками: обогащение моделью, индексацию эмбеддингов.
+// Очередью служат сами записи без результата, поэтому после рестарта ничего не теряется.
+// Если провайдер недоступен, воркер делает паузу и пробует снова — запись при этом работает как обычно.
+type BatchWorker struct {
+	name         string
+	processor    BatchProcessor
+	pollInterval time.Duration
+	errorBackoff time.Duration
+
+	stop   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewBatchWorker создает новый экземпляр BatchWorker; name используется в логах
+func NewBatchWorker(name string, processor BatchProcessor) *BatchWorker {
+	return &BatchWorker{
+		name:         name,
+		processor:    processor,
+		pollInterval: defaultBatchPollInterval,
+		errorBackoff: defaultBatchErrorBackoff,
+	}
+}
+
+// Start запускает воркер
+func (w *BatchWorker) Start() {
+	w.stop = make(chan struct{})
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+
+	w.wg.Add(1)
+	go w.work()
+}
+
+// Shutdown останавливает воркер. Текущий запрос к модели отменяется сразу:
+// запись останется необработанной и будет взята после следующего запуска
+func (w *BatchWorker) Shutdown(ctx context.Context) error {
+	close(w.stop)
+	w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work обрабатывает записи пачками, пока они есть, затем ждёт pollInterval
+func (w *BatchWorker) work() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+
+		ran, err := w.processor.Execute(w.ctx)
+		wait := w.pollInterval
+		switch {
+		case err != nil && w.ctx.Err() != nil:
+			return
+		case err != nil:
+			log.Printf("%s: %v; retrying in %s", w.name, err, w.errorBackoff)
+			wait = w.errorBackoff
+		case ran:
+			continue
+		}
+
+		select {
+		case <-w.stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
+)
+
+// defaultPollInterval — как часто свободный воркер проверяет очередь
+const defaultPollInterval = time.Second
+
+// WorkerPool выполняет задачи генерации перф-саммари из очереди в фоне.
+// Очередь хранится в репозитории: задачи, прерванные остановкой сервера, возвращаются в очередь,
+// а задачи упавшего инстанса подхватываются, когда истекает их аренда.
+type WorkerPool struct {
+	runner       *usecases.RunSummaryJobUsecase
+	workers      int
+	pollInterval time.Duration
+
+	stop      chan struct{}
+	jobCtx    context.Context
+	cancelJob context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewWorkerPool создает новый экземпляр WorkerPool
+func NewWorkerPool(runner *usecases.RunSummaryJobUsecase, workers int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	return &WorkerPool{
+		runner:       runner,
+		workers:      workers,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// Start запускает воркеры
+func (p *WorkerPool) Start() {
+	p.stop = make(chan struct{})
+	p.jobCtx, p.cancelJob = context.WithCancel(context.Background())
+
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+}
+
+// Shutdown перестаёт брать новые задачи и ждёт завершения текущих.
+// Если ctx истекает раньше, текущие задачи отменяются и возвращаются в очередь.
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancelJob()
+		return nil
+	case <-ctx.Done():
+		p.cancelJob()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// work выполняет задачи, пока очередь не пуста, затем ждёт pollInterval
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		ran, err := p.runner.Execute(p.jobCtx)
+		if err != nil {
+			log.Printf("summary jobs: %v", err)
+		}
+		if ran && err == nil {
+			continue
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package journal разбирает дневники, которые пользователи вели до перехода на сервис.
+// Поддерживается Markdown в формате mock_entries.md: заголовок с датой, под ним абзацы **Plan:** и **Fact:**
+package journal
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Entry представляет запись, найденную в дневнике. Line — номер строки с меткой Plan/Fact
+type Entry struct {
+	Date    string
+	Type    repositories.EntryType
+	RawText string
+	Line    int
+}
+
+// Warning описывает фрагмент дневника, который не удалось разобрать и который в импорт не попадёт
+type Warning struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+var (
+	headingPattern = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*$`)
+	// labelPattern находит метку записи: **Plan:**, **Fact**:, - **План:** и т.п.
+	labelPattern   = regexp.MustCompile(`(?i)^\s*(?:[-*]\s+)?\*\*\s*(plan|fact|план|факт)\s*:?\s*\*\*\s*:?\s*(.*)$`)
+	isoDatePattern = regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2})\b`)
+	rulePattern    = regexp.MustCompile(`^\s*(?:-{3,}|\*{3,}|_{3,})\s*$`)
+)
+
+// entryTypes сопоставляет метку записи с её типом
+var entryTypes = map[string]repositories.EntryType{
+	"plan": repositories.EntryTypePlan,
+	"план": repositories.EntryTypePlan,
+	"fact": repositories.EntryTypeFact,
+	"факт": repositories.EntryTypeFact,
+}
+
+// monthNames содержит полные и сокращённые названия месяцев на английском и русском,
+// по-русски — в именительном и родительном падеже ("декабрь", "15 декабря")
+var monthNames = map[string]time.Month{
+	"january": time.January, "jan": time.January, "январь": time.January, "января": time.January, "янв": time.January,
+	"february": time.February, "feb": time.February, "февраль": time.February, "февраля": time.February, "фев": time.February,
+	"march": time.March, "mar": time.March, "март": time.March, "марта": time.March, "мар": time.March,
+	"april": time.April, "apr": time.April, "апрель": time.April, "апреля": time.April, "апр": time.April,
+	"may": time.May, "май": time.May, "мая": time.May,
+	"june": time.June, "jun": time.June, "июнь": time.June, "июня": time.June, "июн": time.June,
+	"july": time.July, "jul": time.July, "июль": time.July, "июля": time.July, "июл": time.July,
+	"august": time.August, "aug": time.August, "август": time.August, "августа": time.August, "авг": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September, "сентябрь": time.September, "сентября": time.September, "сен": time.September, "сент": time.September,
+	"october": time.October, "oct": time.October, "октябрь": time.October, "октября": time.October, "окт": time.October,
+	"november": time.November, "nov": time.November, "ноябрь": time.November, "ноября": time.November, "ноя": time.November,
+	"december": time.December, "dec": time.December, "декабрь": time.December, "декабря": time.December, "дек": time.December,
+}
+
+// ParseMarkdown разбирает дневник в Markdown и возвращает записи в порядке появления.
+// Записи одного типа за одну дату объединяются, так как в сервисе такая запись может быть только одна.
+// Заголовок, который не является датой, закрывает текущий день: метки под ним попадают в предупреждения
+func ParseMarkdown(text string) ([]Entry, []Warning) {
+	p := &markdownParser{index: make(map[string]int)}
+	for i, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		p.line(i+1, line)
+	}
+	p.flush()
+	return p.entries, p.warnings
+}
+
+// markdownParser хранит состояние разбора: текущий день и запись, в которую дописываются строки
+type markdownParser struct {
+	entries  []Entry
+	warnings []Warning
+	// index хранит позицию записи в entries по ключу дата/тип
+	index map[string]int
+
+	date    string
+	current *Entry
+	lines   []string
+}
+
+// line обрабатывает очередную строку дневника
+func (p *markdownParser) line(n int, line string) {
+	if m := headingPattern.FindStringSubmatch(line); m != nil {
+		p.flush()
+		date, ok := parseDate(m[1])
+		if !ok {
+			p.date = ""
+			return
+		}
+		p.date = date
+		return
+	}
+
+	if rulePattern.MatchString(line) {
+		p.flush()
+		return
+	}
+
+	if m := labelPattern.FindStringSubmatch(line); m != nil {
+		p.flush()
+		entryType := entryTypes[strings.ToLower(m[1])]
+		if p.date == "" {
+			p.warn(n, fmt.Sprintf("%s entry is not under a date heading and is ignored", entryType))
+			return
+		}
+		p.current = &Entry{Date: p.date, Type: entryType, Line: n}
+		p.lines = []string{m[2]}
+		return
+	}
+
+	if p.current != nil {
+		p.lines = append(p.lines, line)
+		return
+	}
+	if p.date != "" && strings.TrimSpace(line) != "" {
+		p.warn(n, "text is not under a **Plan:** or **Fact:** label and is ignored")
+	}
+}
+
+// flush сохраняет текущую запись, если она есть
+func (p *markdownParser) flush() {
+	if p.current == nil {
+		return
+	}
+	entry := *p.current
+	entry.RawText = strings.TrimSpace(strings.Join(p.lines, "\n"))
+	p.current, p.lines = nil, nil
+
+	if entry.RawText == "" {
+		p.warn(entry.Line, fmt.Sprintf("%s entry for %s is empty and is ignored", entry.Type, entry.Date))
+		return
+	}
+
+	key := entry.Date + "/" + string(entry.Type)
+	if i, ok := p.index[key]; ok {
+		p.warn(entry.Line, fmt.Sprintf("second %s entry for %s is appended to the one on line %d", entry.Type, entry.Date, p.entries[i].Line))
+		p.entries[i].RawText += "\n\n" + entry.RawText
+		return
+	}
+	p.index[key] = len(p.entries)
+	p.entries = append(p.entries, entry)
+}
+
+// warn добавляет предупреждение к строке n
+func (p *markdownParser) warn(n int, message string) {
+	p.warnings = append(p.warnings, Warning{Line: n, Message: message})
+}
+
+// parseDate распознаёт дату в заголовке: ISO (2025-12-15), английскую (December 15, 2025; 15 Dec 2025)
+// или русскую (15 декабря 2025 г.). День недели и прочие слова в заголовке не мешают
+func parseDate(heading string) (string, bool) {
+	if m := isoDatePattern.FindStringSubmatch(heading); m != nil {
+		if _, err := time.Parse("2006-01-02", m[1]); err == nil {
+			return m[1], true
+		}
+		return "", false
+	}
+
+	var day, year int
+	var month time.Month
+	words := strings.FieldsFunc(strings.ToLower(heading), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if m, ok := monthNames[w]; ok {
+			if month != 0 {
+				return "", false
+			}
+			month = m
+			continue
+		}
+		n, err := strconv.Atoi(w)
+		if err != nil {
+			continue
+		}
+		switch {
+		case len(w) == 4 && year == 0:
+			year = n
+		case len(w) <= 2 && day == 0 && n >= 1 && n <= 31:
+			day = n
+		default:
+			return "", false
+		}
+	}
+	if day == 0 || month == 0 || year == 0 {
+		return "", false
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return "", false
+	}
+	return date.Format("2006-01-02"), true
+}
@@ -0,0 +1,123 @@
+package journal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseDate(t *testing.T) {
+	cases := []struct {
+		heading string
+		want    string
+	}{
+		{heading: "2025-12-15", want: "2025-12-15"},
+		{heading: "Monday, 2025-12-15 (sprint 3)", want: "2025-12-15"},
+		{heading: "2025-02-30", want: ""},
+		{heading: "December 15, 2025", want: "2025-12-15"},
+		{heading: "Mon, 15 Dec 2025", want: "2025-12-15"},
+		{heading: "Sept 1 2025", want: "2025-09-01"},
+		{heading: "May 9, 2025", want: "2025-05-09"},
+		{heading: "15 декабря 2025", want: "2025-12-15"},
+		{heading: "15 декабря 2025 г.", want: "2025-12-15"},
+		{heading: "Понедельник, 1 сент. 2025", want: "2025-09-01"},
+		{heading: "Декабрь 15, 2025", want: "2025-12-15"},
+		{heading: "31 июня 2025", want: ""},
+		{heading: "December 2025", want: ""},
+		{heading: "15 December", want: ""},
+		{heading: "15 декабря 2025 — 16 декабря 2025", want: ""},
+		{heading: "12 15 2025", want: ""},
+		{heading: "Итоги недели", want: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.heading, func(t *testing.T) {
+			got, ok := parseDate(tc.heading)
+			if got != tc.want || ok != (tc.want != "") {
+				t.Fatalf("parseDate(%q) = %q, %v, want %q", tc.heading, got, ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	cases := []struct {
+		name     string
+		markdown string
+		// wantEntries — записи в виде "строка дата тип: текст"
+		wantEntries []string
+		// wantWarnings — предупреждения в виде "строка: сообщение"
+		wantWarnings []string
+	}{
+		{
+			name:        "english labels",
+			markdown:    "### December 15, 2025\n**Plan:** Ship login\n**Fact:** Shipped login\n",
+			wantEntries: []string{"2 2025-12-15 plan: Ship login", "3 2025-12-15 fact: Shipped login"},
+		},
+		{
+			name:        "russian labels and heading",
+			markdown:    "## 16 декабря 2025\n- **План:** Починить выход\n- **Факт**: Починил выход\n",
+			wantEntries: []string{"2 2025-12-16 plan: Починить выход", "3 2025-12-16 fact: Починил выход"},
+		},
+		{
+			name:        "label case and colon inside",
+			markdown:    "# 2025-12-17\n**FACT:**\nReviewed PRs\n\n**plan**\n",
+			wantEntries: []string{"2 2025-12-17 fact: Reviewed PRs"},
+			wantWarnings: []string{
+				"5: plan entry for 2025-12-17 is empty and is ignored",
+			},
+		},
+		{
+			name:        "multiline entry until the rule",
+			markdown:    "### 2025-12-18\n**Fact:** Shipped login\n- web\n- mobile\n\n---\nFooter notes\n",
+			wantEntries: []string{"2 2025-12-18 fact: Shipped login\n- web\n- mobile"},
+			wantWarnings: []string{
+				"7: text is not under a **Plan:** or **Fact:** label and is ignored",
+			},
+		},
+		{
+			name:     "entries without a date",
+			markdown: "Intro text\n**Fact:** Before any heading\n## Notes\n**Plan:** Under a non-date heading\n",
+			wantWarnings: []string{
+				"2: fact entry is not under a date heading and is ignored",
+				"4: plan entry is not under a date heading and is ignored",
+			},
+		},
+		{
+			name:        "same date twice",
+			markdown:    "### 2025-12-19\n**Fact:** Morning\n### 19 Dec 2025\n**Fact:** Evening\n",
+			wantEntries: []string{"2 2025-12-19 fact: Morning\n\nEvening"},
+			wantWarnings: []string{
+				"4: second fact entry for 2025-12-19 is appended to the one on line 2",
+			},
+		},
+		{
+			name:        "crlf line endings",
+			markdown:    "### 2025-12-20\r\n**Plan:** Plan A\r\n",
+			wantEntries: []string{"2 2025-12-20 plan: Plan A"},
+		},
+		{
+			name:     "empty journal",
+			markdown: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, warnings := ParseMarkdown(tc.markdown)
+			var gotEntries, gotWarnings []string
+			for _, e := range entries {
+				gotEntries = append(gotEntries, fmt.Sprintf("%d %s %s: %s", e.Line, e.Date, e.Type, e.RawText))
+			}
+			for _, w := range warnings {
+				gotWarnings = append(gotWarnings, fmt.Sprintf("%d: %s", w.Line, w.Message))
+			}
+			if !reflect.DeepEqual(gotEntries, tc.wantEntries) {
+				t.Fatalf("entries = %q, want %q", gotEntries, tc.wantEntries)
+			}
+			if !reflect.DeepEqual(gotWarnings, tc.wantWarnings) {
+				t.Fatalf("warnings = %q, want %q", gotWarnings, tc.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"unicode/utf8"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/config"
+)
+
+// Budget описывает, сколько токенов модель может принять за один запрос
+type Budget struct {
+	// ContextTokens — размер контекстного окна модели
+	ContextTokens int
+	// OutputTokens — сколько токенов окна резервируется под ответ
+	OutputTokens int
+}
+
+// defaultBudgets — размеры окна по умолчанию для каждого провайдера.
+// У фейка окно намеренно маленькое, чтобы map-reduce срабатывал уже на локальных данных.
+var defaultBudgets = map[string]Budget{
+	ProviderFake:   {ContextTokens: 4096, OutputTokens: 512},
+	ProviderOpenAI: {ContextTokens: 128000, OutputTokens: 4096},
+	ProviderOllama: {ContextTokens: 8192, OutputTokens: 2048},
+}
+
+// NewBudget возвращает бюджет токенов для провайдера из конфигурации.
+// LLM_CONTEXT_TOKENS и LLM_MAX_OUTPUT_TOKENS переопределяют значения по умолчанию.
+func NewBudget(cfg *config.Config) Budget {
+	budget, ok := defaultBudgets[cfg.LLMProvider]
+	if !ok {
+		budget = defaultBudgets[ProviderFake]
+	}
+	if cfg.LLMContextTokens > 0 {
+		budget.ContextTokens = cfg.LLMContextTokens
+	}
+	if cfg.LLMMaxOutputTokens > 0 {
+		budget.OutputTokens = cfg.LLMMaxOutputTokens
+	}
+	return budget
+}
+
+// InputTokens возвращает, сколько токенов можно потратить на сообщения запроса
+func (b Budget) InputTokens() int {
+	return max(b.ContextTokens-b.OutputTokens, 0)
+}
+
+// Fits сообщает, помещаются ли сообщения в бюджет
+func (b Budget) Fits(messages []Message) bool {
+	return EstimateMessagesTokens(messages) <= b.InputTokens()
+}
+
+// EstimateTokens грубо оценивает число токенов в тексте без токенизатора модели.
+// Берём три руны на токен: для кириллицы это консервативная оценка, для латиницы — с запасом.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 2) / 3
+}
+
+// EstimateMessagesTokens оценивает размер запроса с учётом служебных токенов каждого сообщения
+func EstimateMessagesTokens(messages []Message) int {
+	const perMessageOverhead = 4
+
+	total := 0
+	for _, m := range messages {
+		total += EstimateTokens(m.Content) + perMessageOverhead
+	}
+	return total
+}
@@ -0,0 +1,12 @@
+package llm
+
+import "context"
+
+// Embedder определяет интерфейс для получения эмбеддингов текста.
+// Векторы разных моделей несравнимы, поэтому Model участвует в ключе хранения
+type Embedder interface {
+	// Embed возвращает по вектору на каждый текст в том же порядке
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Model возвращает имя модели эмбеддингов
+	Model() string
+}
@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/config"
+)
+
+// Имена провайдеров, поддерживаемые в LLM_PROVIDER
+const (
+	ProviderFake   = "fake"
+	ProviderOpenAI = "openai"
+	ProviderOllama = "ollama"
+	// ProviderNone отключает эмбеддинги (EMBEDDING_PROVIDER=none)
+	ProviderNone = "none"
+)
+
+// Модели эмбеддингов по умолчанию, если EMBEDDING_MODEL не задан
+const (
+	defaultOpenAIEmbeddingModel = "text-embedding-3-small"
+	defaultOllamaEmbeddingModel = "nomic-embed-text"
+)
+
+// NewProvider создает LLM-провайдер по настройкам из конфигурации.
+// Провайдер выбирается явно: без LLM_PROVIDER возвращается ошибка, чтобы фейк не попал в работу незаметно
+func NewProvider(cfg *config.Config) (Provider, error) {
+	timeout := time.Duration(cfg.LLMTimeoutSeconds) * time.Second
+
+	switch cfg.LLMProvider {
+	case "":
+		return nil, fmt.Errorf("LLM_PROVIDER is not set: use %q, %q or %q for local development and tests", ProviderOpenAI, ProviderOllama, ProviderFake)
+	case ProviderFake:
+		return NewFakeProvider(), nil
+	case ProviderOpenAI:
+		if cfg.LLMModel == "" {
+			return nil, fmt.Errorf("LLM_MODEL is required for provider %q", cfg.LLMProvider)
+		}
+		return NewOpenAIProvider(cfg.LLMBaseURL, cfg.LLMAPIKey, cfg.LLMModel, timeout), nil
+	case ProviderOllama:
+		if cfg.LLMModel == "" {
+			return nil, fmt.Errorf("LLM_MODEL is required for provider %q", cfg.LLMProvider)
+		}
+		return NewOllamaProvider(cfg.LLMBaseURL, cfg.LLMModel, timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM provider %q", cfg.LLMProvider)
+	}
+}
+
+// ProviderModel возвращает провайдера и модель из конфигурации одной строкой, например "openai/gpt-4o-mini".
+// Ею помечаются сохранённые результаты модели, чтобы после смены модели их можно было пересчитать
+func ProviderModel(cfg *config.Config) string {
+	switch cfg.LLMProvider {
+	case ProviderFake:
+		return ProviderFake
+	default:
+		return cfg.LLMProvider + "/" + cfg.LLMModel
+	}
+}
+
+// NewEmbedder создает провайдер эмбеддингов по настройкам из конфигурации.
+// Без EMBEDDING_PROVIDER используется тот же провайдер, что и для LLM, с его адресом и ключом.
+// Для EMBEDDING_PROVIDER=none возвращает nil: семантический поиск отключён
+func NewEmbedder(cfg *config.Config) (Embedder, error) {
+	timeout := time.Duration(cfg.LLMTimeoutSeconds) * time.Second
+
+	provider, baseURL, apiKey := cfg.EmbeddingProvider, cfg.EmbeddingBaseURL, cfg.EmbeddingAPIKey
+	if provider == "" || provider == cfg.LLMProvider {
+		provider = cfg.LLMProvider
+		if baseURL == "" {
+			baseURL = cfg.LLMBaseURL
+		}
+		if apiKey == "" {
+			apiKey = cfg.LLMAPIKey
+		}
+	}
+
+	switch provider {
+	case ProviderNone:
+		return nil, nil
+	case ProviderFake:
+		return NewFakeEmbedder(), nil
+	case ProviderOpenAI:
+		return NewOpenAIEmbedder(baseURL, apiKey, embeddingModel(cfg, defaultOpenAIEmbeddingModel), timeout), nil
+	case ProviderOllama:
+		return NewOllamaEmbedder(baseURL, embeddingModel(cfg, defaultOllamaEmbeddingModel), timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", provider)
+	}
+}
+
+// embeddingModel возвращает EMBEDDING_MODEL или модель провайдера по умолчанию
+func embeddingModel(cfg *config.Config, defaultModel string) string {
+	if cfg.EmbeddingModel != "" {
+		return cfg.EmbeddingModel
+	}
+	return defaultModel
+}
@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// fakeEmbeddingDimensions — размерность векторов FakeEmbedder
+const fakeEmbeddingDimensions = 256
+
+// FakeEmbedder реализует Embedder без обращения к внешней модели.
+// Вектор строится хешированием слов и их буквенных триграмм, поэтому близки
+// тексты с общими словами и однокоренными формами ("кэширование" и "кэширования"),
+// но не перефразировки. Используется для локальной разработки и тестов.
+type FakeEmbedder struct{}
+
+// NewFakeEmbedder создает новый экземпляр FakeEmbedder
+func NewFakeEmbedder() *FakeEmbedder {
+	return &FakeEmbedder{}
+}
+
+// Model возвращает имя модели эмбеддингов
+func (e *FakeEmbedder) Model() string {
+	return "fake-hashing-256"
+}
+
+// Embed строит детерминированные нормированные векторы для текстов
+func (e *FakeEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = fakeEmbedding(text)
+	}
+	return vectors, nil
+}
+
+// fakeEmbedding раскладывает слова текста и их триграммы по измерениям вектора
+func fakeEmbedding(text string) []float32 {
+	vector := make([]float32, fakeEmbeddingDimensions)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		addFakeFeature(vector, word, 1)
+		runes := []rune("^" + word + "$")
+		for i := 0; i+3 <= len(runes); i++ {
+			addFakeFeature(vector, string(runes[i:i+3]), 0.5)
+		}
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vector
+	}
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vector {
+		vector[i] *= scale
+	}
+	return vector
+}
+
+// addFakeFeature добавляет признак в измерение, выбранное хешем
+func addFakeFeature(vector []float32, feature string, weight float32) {
+	h := fnv.New32a()
+	h.Write([]byte(feature))
+	vector[h.Sum32()%fakeEmbeddingDimensions] += weight
+}
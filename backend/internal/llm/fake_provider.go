@@ -0,0 +1,218 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// entryLinePattern соответствует строке записи во входных данных промпта:
+// "- 2025-12-15 (fact) [R3]: текст записи" (метка источника необязательна)
+var entryLinePattern = regexp.MustCompile(`^- (\d{4}-\d{2}-\d{2}) \((plan|fact)\)(?: \[(\w+)\])?: (.+)$`)
+
+// FakeProvider реализует Provider без обращения к внешней модели.
+// Ответ строится детерминированно из записей, переданных в последнем
+// пользовательском сообщении: факты группируются по месяцам, каждый месяц
+// становится отдельной целью. На промпт локального саммари фейк отвечает
+// списком фактов, на промпт обогащения — текстом записи и её #хэштегами.
+// Используется для локальной разработки и тестов.
+type FakeProvider struct{}
+
+// fakeTokenSize — размер фрагмента (в рунах), которыми FakeProvider стримит ответ
+const fakeTokenSize = 8
+
+// NewFakeProvider создает новый экземпляр FakeProvider
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+// fakeGoal повторяет формат цели из perf_summary_prompt.md
+type fakeGoal struct {
+	Title    string       `json:"title"`
+	Context  string       `json:"context"`
+	Outputs  []fakeBullet `json:"outputs"`
+	Outcomes []fakeBullet `json:"outcomes"`
+}
+
+// fakeBullet — пункт ответа со ссылкой на метку строки входа, из которой он взят
+type fakeBullet struct {
+	Text    string   `json:"text"`
+	Sources []string `json:"sources"`
+}
+
+// newFakeBullet создает пункт из факта; факт без метки остаётся без источников
+func newFakeBullet(text, label string) fakeBullet {
+	b := fakeBullet{Text: text, Sources: []string{}}
+	if label != "" {
+		b.Sources = append(b.Sources, label)
+	}
+	return b
+}
+
+// Complete формирует ответ в JSON-формате {"goals": [...]}
+func (p *FakeProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return CompletionResponse{}, err
+	}
+
+	var systemPrompt, userPrompt string
+	for _, m := range req.Messages {
+		switch m.Role {
+		case RoleSystem:
+			systemPrompt = m.Content
+		case RoleUser:
+			// Записи приходят в первом сообщении; следующие — просьбы исправить ответ
+			if userPrompt == "" {
+				userPrompt = m.Content
+			}
+		}
+	}
+
+	// Промпт обогащения просит формат {"projects": [...], "outputs": [...], ...}
+	if strings.Contains(systemPrompt, `"projects"`) {
+		return fakeEnrichment(userPrompt)
+	}
+
+	var facts []fakeBullet
+	factsByMonth := make(map[string][]fakeBullet)
+	for _, line := range strings.Split(userPrompt, "\n") {
+		match := entryLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil || match[2] != "fact" {
+			continue
+		}
+		month := match[1][:7]
+		fact := newFakeBullet(truncateRunes(match[4], fakeMaxBulletRunes), match[3])
+		facts = append(facts, fact)
+		factsByMonth[month] = append(factsByMonth[month], fact)
+	}
+
+	months := make([]string, 0, len(factsByMonth))
+	for month := range factsByMonth {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+
+	// Промпт локального саммари просит формат {"summary": [...], "candidate_goals": [...]}
+	if strings.Contains(systemPrompt, `"candidate_goals"`) {
+		return fakeLocalSummary(facts, months)
+	}
+
+	goals := make([]fakeGoal, 0, len(months))
+	for _, month := range months {
+		facts := factsByMonth[month]
+		goal := fakeGoal{
+			Title:    "Работа за " + month,
+			Context:  "Цель собрана автоматически из фактов за " + month + ".",
+			Outputs:  facts[:min(len(facts), fakeMaxGoalBullets)],
+			Outcomes: []fakeBullet{},
+		}
+		// В результаты попадают только факты с цифрами — так фейк не выдумывает метрики
+		for _, fact := range facts {
+			if strings.ContainsAny(fact.Text, "0123456789") && len(goal.Outcomes) < fakeMaxGoalBullets {
+				goal.Outcomes = append(goal.Outcomes, fact)
+			}
+		}
+		goals = append(goals, goal)
+	}
+
+	body, err := json.Marshal(map[string][]fakeGoal{"goals": goals})
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+
+	return CompletionResponse{Content: string(body)}, nil
+}
+
+// Ограничения ответов фейка: как и настоящая модель, он сокращает материал, а не пересказывает всё
+const (
+	fakeLocalSummaryBullets = 7
+	fakeMaxGoalBullets      = 10
+	fakeMaxBulletRunes      = 300
+)
+
+// fakeLocalSummary отвечает на промпт локального саммари: первые факты периода становятся пунктами
+func fakeLocalSummary(facts []fakeBullet, months []string) (CompletionResponse, error) {
+	summary := facts[:min(len(facts), fakeLocalSummaryBullets)]
+	if summary == nil {
+		summary = []fakeBullet{}
+	}
+
+	candidates := make([]string, 0, len(months))
+	for _, month := range months {
+		candidates = append(candidates, "Работа за "+month)
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"summary":         summary,
+		"candidate_goals": candidates,
+	})
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+
+	return CompletionResponse{Content: string(body)}, nil
+}
+
+// hashtagPattern соответствует хэштегу в тексте записи: #tech-debt
+var hashtagPattern = regexp.MustCompile(`#([\p{L}\p{N}_-]+)`)
+
+// fakeEnrichment отвечает на промпт обогащения: текст записи становится output,
+// факт с цифрами — ещё и outcome, а хэштеги из текста — тегами
+func fakeEnrichment(userPrompt string) (CompletionResponse, error) {
+	answer := map[string][]string{
+		"projects": {},
+		"outputs":  {},
+		"outcomes": {},
+		"tags":     {},
+	}
+	for _, line := range strings.Split(userPrompt, "\n") {
+		match := entryLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		text := truncateRunes(match[4], fakeMaxBulletRunes)
+		answer["outputs"] = append(answer["outputs"], text)
+		if match[2] == "fact" && strings.ContainsAny(text, "0123456789") {
+			answer["outcomes"] = append(answer["outcomes"], text)
+		}
+		for _, tag := range hashtagPattern.FindAllStringSubmatch(match[4], -1) {
+			answer["tags"] = append(answer["tags"], strings.ToLower(tag[1]))
+		}
+	}
+
+	body, err := json.Marshal(answer)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+
+	return CompletionResponse{Content: string(body)}, nil
+}
+
+// truncateRunes обрезает строку до limit рун
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// CompleteStream отдаёт тот же ответ, что и Complete, фрагментами по fakeTokenSize рун
+func (p *FakeProvider) CompleteStream(ctx context.Context, req CompletionRequest, onToken TokenHandler) (CompletionResponse, error) {
+	resp, err := p.Complete(ctx, req)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+
+	runes := []rune(resp.Content)
+	for start := 0; start < len(runes); start += fakeTokenSize {
+		end := min(start+fakeTokenSize, len(runes))
+		if err := onToken(string(runes[start:end])); err != nil {
+			return CompletionResponse{}, err
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,55 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OllamaEmbedder реализует Embedder поверх локального Ollama (/api/embed)
+type OllamaEmbedder struct {
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+// NewOllamaEmbedder создает новый экземпляр OllamaEmbedder
+func NewOllamaEmbedder(baseURL, model string, timeout time.Duration) *OllamaEmbedder {
+	if baseURL == "" {
+		baseURL = defaultOllamaBaseURL
+	}
+	return &OllamaEmbedder{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		model:   model,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type ollamaEmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type ollamaEmbedResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
+// Model возвращает имя модели эмбеддингов
+func (e *OllamaEmbedder) Model() string {
+	return e.model
+}
+
+// Embed отправляет тексты одним запросом в /api/embed
+func (e *OllamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	var resp ollamaEmbedResponse
+	body := ollamaEmbedRequest{Model: e.model, Input: texts}
+	if err := postJSON(ctx, e.client, e.baseURL+"/api/embed", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("ollama: got %d embeddings for %d inputs", len(resp.Embeddings), len(texts))
+	}
+	return resp.Embeddings, nil
+}
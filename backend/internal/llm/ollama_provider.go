@@ -0,0 +1,135 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultOllamaBaseURL — адрес локального Ollama по умолчанию
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// OllamaProvider реализует Provider поверх локального Ollama (/api/chat).
+// Позволяет запускать Perf Assist полностью self-hosted без внешних API.
+type OllamaProvider struct {
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+// NewOllamaProvider создает новый экземпляр OllamaProvider
+func NewOllamaProvider(baseURL, model string, timeout time.Duration) *OllamaProvider {
+	if baseURL == "" {
+		baseURL = defaultOllamaBaseURL
+	}
+	return &OllamaProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		model:   model,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Format   string          `json:"format,omitempty"`
+	Options  ollamaOptions   `json:"options"`
+}
+
+type ollamaChatResponse struct {
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+}
+
+// Complete отправляет запрос в /api/chat без стриминга
+func (p *OllamaProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	body := p.buildRequest(req)
+
+	var resp ollamaChatResponse
+	if err := postJSON(ctx, p.client, p.baseURL+"/api/chat", nil, body, &resp); err != nil {
+		return CompletionResponse{}, err
+	}
+
+	return CompletionResponse{
+		Content: resp.Message.Content,
+		Usage: Usage{
+			PromptTokens:     resp.PromptEvalCount,
+			CompletionTokens: resp.EvalCount,
+		},
+	}, nil
+}
+
+// CompleteStream отправляет запрос в /api/chat со stream=true.
+// Ollama отвечает потоком JSON-объектов, по одному на строку; последний содержит done=true.
+func (p *OllamaProvider) CompleteStream(ctx context.Context, req CompletionRequest, onToken TokenHandler) (CompletionResponse, error) {
+	body := p.buildRequest(req)
+	body.Stream = true
+
+	httpResp, err := openStream(ctx, p.client, p.baseURL+"/api/chat", nil, body)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+	defer httpResp.Body.Close()
+
+	var result CompletionResponse
+	var content strings.Builder
+	decoder := json.NewDecoder(httpResp.Body)
+	for {
+		var chunk ollamaChatResponse
+		if err := decoder.Decode(&chunk); err != nil {
+			return CompletionResponse{}, fmt.Errorf("ollama: invalid stream chunk: %w", err)
+		}
+		if chunk.Message.Content != "" {
+			content.WriteString(chunk.Message.Content)
+			if err := onToken(chunk.Message.Content); err != nil {
+				return CompletionResponse{}, err
+			}
+		}
+		if chunk.Done {
+			result.Usage = Usage{
+				PromptTokens:     chunk.PromptEvalCount,
+				CompletionTokens: chunk.EvalCount,
+			}
+			break
+		}
+	}
+
+	result.Content = content.String()
+	return result, nil
+}
+
+// buildRequest маппит CompletionRequest в тело запроса /api/chat
+func (p *OllamaProvider) buildRequest(req CompletionRequest) ollamaChatRequest {
+	body := ollamaChatRequest{
+		Model:    p.model,
+		Messages: make([]ollamaMessage, 0, len(req.Messages)),
+		Stream:   false,
+		Options: ollamaOptions{
+			Temperature: req.Temperature,
+			NumPredict:  req.MaxTokens,
+		},
+	}
+	for _, m := range req.Messages {
+		body.Messages = append(body.Messages, ollamaMessage{Role: string(m.Role), Content: m.Content})
+	}
+	if req.JSONMode {
+		body.Format = "json"
+	}
+	return body
+}
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAIEmbedder реализует Embedder поверх OpenAI-совместимого Embeddings API
+type OpenAIEmbedder struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+// NewOpenAIEmbedder создает новый экземпляр OpenAIEmbedder
+func NewOpenAIEmbedder(baseURL, apiKey, model string, timeout time.Duration) *OpenAIEmbedder {
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &OpenAIEmbedder{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type openAIEmbeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Model возвращает имя модели эмбеддингов
+func (e *OpenAIEmbedder) Model() string {
+	return e.model
+}
+
+// Embed отправляет тексты одним запросом в /embeddings
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	headers := map[string]string{}
+	if e.apiKey != "" {
+		headers["Authorization"] = "Bearer " + e.apiKey
+	}
+
+	var resp openAIEmbeddingsResponse
+	body := openAIEmbeddingsRequest{Model: e.model, Input: texts}
+	if err := postJSON(ctx, e.client, e.baseURL+"/embeddings", headers, body, &resp); err != nil {
+		return nil, err
+	}
+
+	// Порядок data не гарантирован — раскладываем по index
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("openai: embedding index %d out of range", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if len(v) == 0 {
+			return nil, fmt.Errorf("openai: missing embedding for input %d", i)
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,209 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultOpenAIBaseURL используется, если адрес OpenAI-совместимого API не задан
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIProvider реализует Provider поверх OpenAI-совместимого Chat Completions API.
+// Подходит для OpenAI, а также для vLLM, LM Studio, OpenRouter и других совместимых серверов.
+type OpenAIProvider struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+// NewOpenAIProvider создает новый экземпляр OpenAIProvider
+func NewOpenAIProvider(baseURL, apiKey, model string, timeout time.Duration) *OpenAIProvider {
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &OpenAIProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+type openAIChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	Temperature    float64               `json:"temperature"`
+	MaxTokens      int                   `json:"max_tokens,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+	Stream         bool                  `json:"stream,omitempty"`
+}
+
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta openAIMessage `json:"delta"`
+	} `json:"choices"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// Complete отправляет запрос в /chat/completions
+func (p *OpenAIProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	body := p.buildRequest(req)
+
+	var resp openAIChatResponse
+	if err := postJSON(ctx, p.client, p.baseURL+"/chat/completions", p.headers(), body, &resp); err != nil {
+		return CompletionResponse{}, err
+	}
+	if len(resp.Choices) == 0 {
+		return CompletionResponse{}, fmt.Errorf("openai: empty choices in response")
+	}
+
+	return CompletionResponse{
+		Content: resp.Choices[0].Message.Content,
+		Usage: Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+		},
+	}, nil
+}
+
+// CompleteStream отправляет запрос в /chat/completions со stream=true и
+// разбирает ответ в формате server-sent events ("data: {...}", "data: [DONE]")
+func (p *OpenAIProvider) CompleteStream(ctx context.Context, req CompletionRequest, onToken TokenHandler) (CompletionResponse, error) {
+	body := p.buildRequest(req)
+	body.Stream = true
+
+	httpResp, err := openStream(ctx, p.client, p.baseURL+"/chat/completions", p.headers(), body)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+	defer httpResp.Body.Close()
+
+	var content strings.Builder
+	scanner := bufio.NewScanner(httpResp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		data, ok := strings.CutPrefix(line, "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return CompletionResponse{}, fmt.Errorf("openai: invalid stream chunk: %w", err)
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		token := chunk.Choices[0].Delta.Content
+		content.WriteString(token)
+		if err := onToken(token); err != nil {
+			return CompletionResponse{}, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return CompletionResponse{}, err
+	}
+
+	return CompletionResponse{Content: content.String()}, nil
+}
+
+// headers возвращает заголовки авторизации для запросов к API
+func (p *OpenAIProvider) headers() map[string]string {
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.apiKey
+	}
+	return headers
+}
+
+// buildRequest маппит CompletionRequest в тело запроса Chat Completions
+func (p *OpenAIProvider) buildRequest(req CompletionRequest) openAIChatRequest {
+	body := openAIChatRequest{
+		Model:       p.model,
+		Messages:    make([]openAIMessage, 0, len(req.Messages)),
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	}
+	for _, m := range req.Messages {
+		body.Messages = append(body.Messages, openAIMessage{Role: string(m.Role), Content: m.Content})
+	}
+	if req.JSONMode {
+		body.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
+	}
+	return body
+}
+
+// postJSON отправляет JSON-запрос и декодирует JSON-ответ.
+// Ответы со статусом не 2xx превращаются в ошибку с началом тела ответа.
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, in, out any) error {
+	httpResp, err := openStream(ctx, client, url, headers, in)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	return json.NewDecoder(httpResp.Body).Decode(out)
+}
+
+// openStream отправляет JSON-запрос и возвращает успешный ответ с непрочитанным телом.
+// Вызывающий обязан закрыть httpResp.Body.
+func openStream(ctx context.Context, client *http.Client, url string, headers map[string]string, in any) (*http.Response, error) {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		defer httpResp.Body.Close()
+		snippet, _ := io.ReadAll(io.LimitReader(httpResp.Body, 512))
+		return nil, fmt.Errorf("llm request to %s failed with status %d: %s", url, httpResp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	return httpResp, nil
+}
@@ -0,0 +1,68 @@
+package llm
+
+import (
+	"context"
+	"strings"
+)
+
+// Role определяет автора сообщения в диалоге с моделью
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+// Message представляет одно сообщение в запросе к модели
+type Message struct {
+	Role    Role
+	Content string
+}
+
+// CompletionRequest представляет запрос на генерацию ответа модели
+type CompletionRequest struct {
+	Messages    []Message
+	Temperature float64
+	// MaxTokens ограничивает длину ответа; 0 — ограничение провайдера по умолчанию
+	MaxTokens int
+	// JSONMode просит провайдера вернуть валидный JSON-объект
+	JSONMode bool
+}
+
+// Usage содержит статистику использования токенов, если провайдер её возвращает
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// CompletionResponse представляет ответ модели
+type CompletionResponse struct {
+	Content string
+	Usage   Usage
+}
+
+// Provider определяет интерфейс для работы с LLM-провайдером
+type Provider interface {
+	Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error)
+}
+
+// SystemUserMessages собирает типичную пару сообщений system + user
+func SystemUserMessages(system, user string) []Message {
+	return []Message{
+		{Role: RoleSystem, Content: system},
+		{Role: RoleUser, Content: user},
+	}
+}
+
+// ExtractJSON вырезает JSON-объект из ответа модели.
+// Модели часто оборачивают ответ в ```json ... ``` или добавляют текст вокруг,
+// поэтому берём всё от первой открывающей до последней закрывающей фигурной скобки.
+func ExtractJSON(content string) string {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start == -1 || end == -1 || end < start {
+		return strings.TrimSpace(content)
+	}
+	return content[start : end+1]
+}
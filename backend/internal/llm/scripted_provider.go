@@ -0,0 +1,64 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrScriptExhausted возвращается, когда у ScriptedProvider закончились заготовленные ответы
+var ErrScriptExhausted = errors.New("scripted provider: no more responses")
+
+// ScriptedStep описывает один заготовленный ответ ScriptedProvider
+type ScriptedStep struct {
+	Content string
+	Err     error
+}
+
+// ScriptedProvider возвращает заранее заданные ответы по очереди и запоминает
+// все полученные запросы. Используется в тестах для проверки промптов и
+// сценариев с ошибками модели.
+type ScriptedProvider struct {
+	mu       sync.Mutex
+	steps    []ScriptedStep
+	requests []CompletionRequest
+}
+
+// NewScriptedProvider создает новый экземпляр ScriptedProvider
+func NewScriptedProvider(steps ...ScriptedStep) *ScriptedProvider {
+	return &ScriptedProvider{
+		steps: steps,
+	}
+}
+
+// Complete возвращает следующий заготовленный ответ
+func (p *ScriptedProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, req)
+
+	if err := ctx.Err(); err != nil {
+		return CompletionResponse{}, err
+	}
+	if len(p.steps) == 0 {
+		return CompletionResponse{}, ErrScriptExhausted
+	}
+
+	step := p.steps[0]
+	p.steps = p.steps[1:]
+	if step.Err != nil {
+		return CompletionResponse{}, step.Err
+	}
+	return CompletionResponse{Content: step.Content}, nil
+}
+
+// Requests возвращает копию всех полученных запросов
+func (p *ScriptedProvider) Requests() []CompletionRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make([]CompletionRequest, len(p.requests))
+	copy(result, p.requests)
+	return result
+}
@@ -0,0 +1,33 @@
+package llm
+
+import (
+	"context"
+)
+
+// TokenHandler вызывается для каждого фрагмента ответа модели по мере генерации.
+// Возврат ошибки прерывает стриминг.
+type TokenHandler func(token string) error
+
+// StreamingProvider реализуется провайдерами, которые умеют отдавать ответ по частям
+type StreamingProvider interface {
+	Provider
+	// CompleteStream вызывает onToken для каждого фрагмента и возвращает полный ответ
+	CompleteStream(ctx context.Context, req CompletionRequest, onToken TokenHandler) (CompletionResponse, error)
+}
+
+// Stream генерирует ответ с поддержкой стриминга. Если провайдер не умеет
+// стримить, ответ запрашивается целиком и отдаётся одним фрагментом.
+func Stream(ctx context.Context, provider Provider, req CompletionRequest, onToken TokenHandler) (CompletionResponse, error) {
+	if sp, ok := provider.(StreamingProvider); ok {
+		return sp.CompleteStream(ctx, req, onToken)
+	}
+
+	resp, err := provider.Complete(ctx, req)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+	if err := onToken(resp.Content); err != nil {
+		return CompletionResponse{}, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,20 @@
+package prompts
+
+import (
+	_ "embed"
+)
+
+// PerfSummaryPrompt содержит системный промпт для генерации перф-саммари
+//
+//go:embed perf_summary_prompt.md
+var PerfSummaryPrompt string
+
+// LocalSummaryPrompt содержит системный промпт для краткого саммари дня или недели
+//
+//go:embed local_summary_prompt.md
+var LocalSummaryPrompt string
+
+// EntryEnrichmentPrompt содержит системный промпт для обогащения отдельной записи
+//
+//go:embed entry_enrichment_prompt.md
+var EntryEnrichmentPrompt string
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// APIToken — персональный API-токен пользователя. Сам токен не хранится, только его SHA-256 хеш
+type APIToken struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Name        string     `json:"name"`
+	TokenHash   string     `json:"-"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"-"`
+}
+
+// APITokensRepository определяет интерфейс для работы с персональными API-токенами
+type APITokensRepository interface {
+	Create(token APIToken) error
+	// GetByHash возвращает неотозванный токен по хешу. Вызывается до аутентификации,
+	// поэтому это единственный метод, не ограниченный владельцем
+	GetByHash(hash string) (APIToken, error)
+	// ListByUser возвращает неотозванные токены пользователя, новые первыми
+	ListByUser(userID string) ([]APIToken, error)
+	// Revoke отзывает токен пользователя; чужой или уже отозванный токен — ErrNotFound
+	Revoke(userID, id string, at time.Time) error
+	TouchLastUsed(userID, id string, at time.Time) error
+}
+
+// InMemoryAPITokensRepository реализует APITokensRepository с использованием in-memory хранилища
+type InMemoryAPITokensRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]APIToken
+}
+
+// NewInMemoryAPITokensRepository создает новый экземпляр InMemoryAPITokensRepository
+func NewInMemoryAPITokensRepository() *InMemoryAPITokensRepository {
+	return &InMemoryAPITokensRepository{
+		tokens: make(map[string]APIToken),
+	}
+}
+
+// Create добавляет токен
+func (r *InMemoryAPITokensRepository) Create(token APIToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.ID] = token
+	return nil
+}
+
+// GetByHash возвращает неотозванный токен по хешу
+func (r *InMemoryAPITokensRepository) GetByHash(hash string) (APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.tokens {
+		if t.TokenHash == hash && t.RevokedAt == nil {
+			return t, nil
+		}
+	}
+	return APIToken{}, ErrNotFound
+}
+
+// ListByUser возвращает неотозванные токены пользователя, новые первыми
+func (r *InMemoryAPITokensRepository) ListByUser(userID string) ([]APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []APIToken{}
+	for _, t := range r.tokens {
+		if t.UserID == userID && t.RevokedAt == nil {
+			result = append(result, t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// Revoke отзывает токен пользователя
+func (r *InMemoryAPITokensRepository) Revoke(userID, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tokens[id]
+	if !ok || t.UserID != userID || t.RevokedAt != nil {
+		return ErrNotFound
+	}
+	t.RevokedAt = &at
+	r.tokens[id] = t
+	return nil
+}
+
+// TouchLastUsed обновляет момент последнего использования токена
+func (r *InMemoryAPITokensRepository) TouchLastUsed(userID, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tokens[id]
+	if !ok || t.UserID != userID {
+		return ErrNotFound
+	}
+	t.LastUsedAt = &at
+	r.tokens[id] = t
+	return nil
+}
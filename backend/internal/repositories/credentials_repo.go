@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+)
+
+// Credentials — данные для входа по email и паролю. Пароль хранится только в виде bcrypt-хеша
+type Credentials struct {
+	UserID       string
+	Email        string
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// CredentialsRepository определяет интерфейс для работы с учётными данными пользователей
+type CredentialsRepository interface {
+	// Create возвращает ErrAlreadyExists, если email или пользователь уже зарегистрированы
+	Create(credentials Credentials) error
+	// GetByEmail вызывается при входе, до аутентификации, и поэтому не ограничен владельцем
+	GetByEmail(email string) (Credentials, error)
+}
+
+// InMemoryCredentialsRepository реализует CredentialsRepository с использованием in-memory хранилища
+type InMemoryCredentialsRepository struct {
+	mu      sync.RWMutex
+	byEmail map[string]Credentials
+}
+
+// NewInMemoryCredentialsRepository создает новый экземпляр InMemoryCredentialsRepository
+func NewInMemoryCredentialsRepository() *InMemoryCredentialsRepository {
+	return &InMemoryCredentialsRepository{
+		byEmail: make(map[string]Credentials),
+	}
+}
+
+// Create добавляет учётные данные пользователя
+func (r *InMemoryCredentialsRepository) Create(credentials Credentials) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for email, existing := range r.byEmail {
+		if email == credentials.Email || existing.UserID == credentials.UserID {
+			return ErrAlreadyExists
+		}
+	}
+	r.byEmail[credentials.Email] = credentials
+	return nil
+}
+
+// GetByEmail возвращает учётные данные по email
+func (r *InMemoryCredentialsRepository) GetByEmail(email string) (Credentials, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	credentials, ok := r.byEmail[email]
+	if !ok {
+		return Credentials{}, ErrNotFound
+	}
+	return credentials, nil
+}
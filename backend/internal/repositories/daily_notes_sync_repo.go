@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DailyNoteSyncState запоминает, в каком виде запись и раздел ежедневной заметки были согласованы в последний раз.
+// Хеши хранятся для каждой стороны отдельно: так видно, какая из них изменилась с тех пор
+type DailyNoteSyncState struct {
+	UserID    string
+	Date      string
+	Type      EntryType
+	FileHash  string
+	EntryHash string
+	SyncedAt  time.Time
+}
+
+// Стороны синхронизации, одна из которых выигрывает конфликт
+const (
+	DailyNoteSideFile  = "file"
+	DailyNoteSideEntry = "entry"
+)
+
+// DailyNoteConflict описывает конфликт: запись и раздел заметки изменились независимо друг от друга.
+// Побеждает сторона, изменённая позже (Winner); проигравший текст сохраняется здесь, чтобы его можно было вернуть.
+// EntryUpdatedAt равен nil, если запись была удалена
+type DailyNoteConflict struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	Date           string     `json:"date"`
+	Type           EntryType  `json:"type"`
+	Winner         string     `json:"winner"`
+	FileText       string     `json:"file_text"`
+	EntryText      string     `json:"entry_text"`
+	FileModifiedAt time.Time  `json:"file_modified_at"`
+	EntryUpdatedAt *time.Time `json:"entry_updated_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// DailyNotesSyncRepository определяет интерфейс для состояния синхронизации с ежедневными заметками и журнала конфликтов
+type DailyNotesSyncRepository interface {
+	ListStates(userID string) ([]DailyNoteSyncState, error)
+	// GetState возвращает состояние для даты и типа записи или ErrNotFound, если они ещё не согласовывались
+	GetState(userID, date string, entryType EntryType) (DailyNoteSyncState, error)
+	// SaveState сохраняет состояние, заменяя прежнее для той же даты и типа
+	SaveState(state DailyNoteSyncState) error
+	DeleteState(userID, date string, entryType EntryType) error
+	AddConflict(conflict DailyNoteConflict) error
+	// ListConflicts возвращает до limit конфликтов пользователя, новые первыми
+	ListConflicts(userID string, limit int) ([]DailyNoteConflict, error)
+}
+
+// InMemoryDailyNotesSyncRepository реализует DailyNotesSyncRepository с использованием in-memory хранилища
+type InMemoryDailyNotesSyncRepository struct {
+	mu        sync.RWMutex
+	states    map[string]DailyNoteSyncState
+	conflicts []DailyNoteConflict
+}
+
+// NewInMemoryDailyNotesSyncRepository создает новый экземпляр InMemoryDailyNotesSyncRepository
+func NewInMemoryDailyNotesSyncRepository() *InMemoryDailyNotesSyncRepository {
+	return &InMemoryDailyNotesSyncRepository{
+		states: make(map[string]DailyNoteSyncState),
+	}
+}
+
+// ListStates возвращает состояния синхронизации пользователя
+func (r *InMemoryDailyNotesSyncRepository) ListStates(userID string) ([]DailyNoteSyncState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []DailyNoteSyncState
+	for _, s := range r.states {
+		if s.UserID == userID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// GetState возвращает состояние синхронизации для даты и типа записи
+func (r *InMemoryDailyNotesSyncRepository) GetState(userID, date string, entryType EntryType) (DailyNoteSyncState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state, ok := r.states[dailyNoteStateKey(userID, date, entryType)]
+	if !ok {
+		return DailyNoteSyncState{}, ErrNotFound
+	}
+	return state, nil
+}
+
+// SaveState сохраняет состояние синхронизации
+func (r *InMemoryDailyNotesSyncRepository) SaveState(state DailyNoteSyncState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[dailyNoteStateKey(state.UserID, state.Date, state.Type)] = state
+	return nil
+}
+
+// DeleteState удаляет состояние синхронизации
+func (r *InMemoryDailyNotesSyncRepository) DeleteState(userID, date string, entryType EntryType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.states, dailyNoteStateKey(userID, date, entryType))
+	return nil
+}
+
+// AddConflict добавляет конфликт в журнал
+func (r *InMemoryDailyNotesSyncRepository) AddConflict(conflict DailyNoteConflict) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.conflicts = append(r.conflicts, conflict)
+	return nil
+}
+
+// ListConflicts возвращает конфликты пользователя, новые первыми
+func (r *InMemoryDailyNotesSyncRepository) ListConflicts(userID string, limit int) ([]DailyNoteConflict, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []DailyNoteConflict{}
+	for _, c := range r.conflicts {
+		if c.UserID == userID {
+			result = append(result, c)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// dailyNoteStateKey собирает ключ состояния из пользователя, даты и типа записи
+func dailyNoteStateKey(userID, date string, entryType EntryType) string {
+	return userID + "|" + date + "|" + string(entryType)
+}
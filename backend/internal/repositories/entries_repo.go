@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -11,13 +13,42 @@ const (
 	EntryTypeFact EntryType = "fact"
 )
 
+// Entry представляет запись дневника. Tags хранятся в TagsRepository и заполняются usecases.
+// LLMEnriched равен nil, пока запись не обогащена моделью; при изменении RawText он сбрасывается.
+// UpdatedAt — время последнего изменения текста; Create и Update берут его из переданной записи
 type Entry struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Date      string    `json:"date"`
-	Type      EntryType `json:"type"`
-	RawText   string    `json:"raw_text"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          string           `json:"id"`
+	UserID      string           `json:"user_id"`
+	Date        string           `json:"date"`
+	Type        EntryType        `json:"type"`
+	RawText     string           `json:"raw_text"`
+	Tags        []string         `json:"tags"`
+	LLMEnriched *EntryEnrichment `json:"llm_enriched"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type EntryEnrichmentStatus string
+
+const (
+	EntryEnrichmentStatusDone   EntryEnrichmentStatus = "done"
+	EntryEnrichmentStatusFailed EntryEnrichmentStatus = "failed"
+)
+
+// EntryEnrichment содержит то, что модель извлекла из текста записи: проекты,
+// кандидаты в outputs/outcomes перф-саммари и предлагаемые теги.
+// При статусе failed списки пустые, а Error объясняет, почему ответ модели не принят.
+// Model — провайдер и модель, которые сделали обогащение; Attempts — сколько раз подряд провайдер вернул ошибку
+type EntryEnrichment struct {
+	Status     EntryEnrichmentStatus `json:"status"`
+	Projects   []string              `json:"projects"`
+	Outputs    []string              `json:"outputs"`
+	Outcomes   []string              `json:"outcomes"`
+	Tags       []string              `json:"tags"`
+	Error      string                `json:"error,omitempty"`
+	Model      string                `json:"model,omitempty"`
+	Attempts   int                   `json:"attempts,omitempty"`
+	EnrichedAt time.Time             `json:"enriched_at"`
 }
 
 type CreateEntryRequest struct {
@@ -27,19 +58,36 @@ type CreateEntryRequest struct {
 	RawText string    `json:"raw_text"`
 }
 
-// EntriesRepository определяет интерфейс для работы с entries
+// EntriesRepository определяет интерфейс для работы с entries.
+// Все методы ограничены владельцем: запись другого пользователя для них не существует (ErrNotFound)
 type EntriesRepository interface {
-	Create(entry Entry) error
+	// Create сохраняет запись владельца entry.UserID и возвращает сохранённую запись.
+	// Запись того же типа за ту же дату перезаписывается и сохраняет свой ID
+	Create(entry Entry) (Entry, error)
+	GetByID(userID, id string) (Entry, error)
 	ListByUserAndDate(userID, date string) ([]Entry, error)
 	ListByUserAndPeriod(userID, from, to string) ([]Entry, error)
-	ListAll() ([]Entry, error)
-	Update(entry Entry) error
-	DeleteByID(id string) error
-	DeleteByDate(date string) error
+	// ListByUserUpdatedSince возвращает записи пользователя, текст которых записан не раньше since
+	ListByUserUpdatedSince(userID string, since time.Time) ([]Entry, error)
+	// Update меняет текст и updated_at записи пользователя; пустой текст запись не удаляет
+	Update(userID string, entry Entry) error
+	DeleteByID(userID, id string) error
+	DeleteByDate(userID, date string) error
+	// SetEnrichment сохраняет обогащение, только если текст записи всё ещё равен rawText.
+	// Если запись удалена или её текст успел измениться, возвращает ErrNotFound
+	SetEnrichment(userID, id, rawText string, enrichment EntryEnrichment) error
+	// Search возвращает до limit записей пользователя, подходящих под поисковый запрос, самые релевантные первыми
+	Search(userID, query string, limit int) ([]EntrySearchResult, error)
+
+	// ListPendingEnrichment используется фоновым обогащением и видит записи всех пользователей:
+	// возвращает до limit записей без обогащения, с обогащением другой модели или с ошибкой провайдера,
+	// которую ещё можно повторить (меньше maxAttempts попыток). Записи с меньшим числом попыток идут первыми, затем старые
+	ListPendingEnrichment(model string, maxAttempts, limit int) ([]Entry, error)
 }
 
 // InMemoryEntriesRepository реализует EntriesRepository с использованием in-memory хранилища
 type InMemoryEntriesRepository struct {
+	mu                sync.RWMutex
 	entriesByUserDate map[string]map[string][]Entry
 }
 
@@ -50,8 +98,11 @@ func NewInMemoryEntriesRepository() *InMemoryEntriesRepository {
 	}
 }
 
-// Create добавляет новую запись
-func (r *InMemoryEntriesRepository) Create(entry Entry) error {
+// Create добавляет новую запись или перезаписывает запись того же типа за ту же дату
+func (r *InMemoryEntriesRepository) Create(entry Entry) (Entry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if _, ok := r.entriesByUserDate[entry.UserID]; !ok {
 		r.entriesByUserDate[entry.UserID] = make(map[string][]Entry)
 	}
@@ -61,6 +112,11 @@ func (r *InMemoryEntriesRepository) Create(entry Entry) error {
 	foundSameType := false
 	for _, e := range existing {
 		if e.Type == entry.Type {
+			entry.ID = e.ID
+			// Обогащение остаётся актуальным, только если текст не изменился
+			if e.RawText == entry.RawText {
+				entry.LLMEnriched = e.LLMEnriched
+			}
 			updated = append(updated, entry)
 			foundSameType = true
 		} else {
@@ -72,14 +128,32 @@ func (r *InMemoryEntriesRepository) Create(entry Entry) error {
 	}
 
 	r.entriesByUserDate[entry.UserID][entry.Date] = updated
-	return nil
+	return entry, nil
+}
+
+// GetByID возвращает запись пользователя по ID
+func (r *InMemoryEntriesRepository) GetByID(userID, id string) (Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entries := range r.entriesByUserDate[userID] {
+		for _, e := range entries {
+			if e.ID == id {
+				return e, nil
+			}
+		}
+	}
+	return Entry{}, ErrNotFound
 }
 
 // ListByUserAndDate возвращает записи для конкретного пользователя и даты
 func (r *InMemoryEntriesRepository) ListByUserAndDate(userID, date string) ([]Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	if byDate, ok := r.entriesByUserDate[userID]; ok {
 		if entries, ok := byDate[date]; ok {
-			return entries, nil
+			return append([]Entry(nil), entries...), nil
 		}
 	}
 	return []Entry{}, nil
@@ -87,6 +161,9 @@ func (r *InMemoryEntriesRepository) ListByUserAndDate(userID, date string) ([]En
 
 // ListByUserAndPeriod возвращает записи для пользователя за период
 func (r *InMemoryEntriesRepository) ListByUserAndPeriod(userID, from, to string) ([]Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var result []Entry
 	if byDate, ok := r.entriesByUserDate[userID]; ok {
 		for date, entries := range byDate {
@@ -98,74 +175,135 @@ func (r *InMemoryEntriesRepository) ListByUserAndPeriod(userID, from, to string)
 	return result, nil
 }
 
-// ListAll возвращает все записи
-func (r *InMemoryEntriesRepository) ListAll() ([]Entry, error) {
+// ListByUserUpdatedSince возвращает записи пользователя, изменённые не раньше since
+func (r *InMemoryEntriesRepository) ListByUserUpdatedSince(userID string, since time.Time) ([]Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var result []Entry
-	for _, byDate := range r.entriesByUserDate {
-		for _, entries := range byDate {
-			result = append(result, entries...)
+	for _, entries := range r.entriesByUserDate[userID] {
+		for _, e := range entries {
+			if !e.UpdatedAt.Before(since) {
+				result = append(result, e)
+			}
 		}
 	}
 	return result, nil
 }
 
-// Update обновляет запись
-func (r *InMemoryEntriesRepository) Update(entry Entry) error {
-	for userID, byDate := range r.entriesByUserDate {
-		for date, entries := range byDate {
-			for i, e := range entries {
-				if e.ID == entry.ID {
-					e.RawText = entry.RawText
-					entries[i] = e
-					if e.RawText == "" {
-						entries = append(entries[:i], entries[i+1:]...)
-					}
-					if len(entries) == 0 {
-						delete(byDate, date)
-					} else {
-						byDate[date] = entries
-					}
-					r.entriesByUserDate[userID] = byDate
-					return nil
+// Update обновляет запись пользователя. Изменение текста сбрасывает обогащение;
+// пустой текст, как и в Postgres, запись не удаляет
+func (r *InMemoryEntriesRepository) Update(userID string, entry Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entries := range r.entriesByUserDate[userID] {
+		for i, e := range entries {
+			if e.ID == entry.ID {
+				if e.RawText != entry.RawText {
+					e.LLMEnriched = nil
 				}
+				e.RawText = entry.RawText
+				e.UpdatedAt = entry.UpdatedAt
+				entries[i] = e
+				return nil
 			}
 		}
 	}
-	return nil
+	return ErrNotFound
 }
 
-// DeleteByID удаляет запись по ID
-func (r *InMemoryEntriesRepository) DeleteByID(id string) error {
-	for userID, byDate := range r.entriesByUserDate {
-		for date, entries := range byDate {
-			for i, e := range entries {
-				if e.ID == id {
-					entries = append(entries[:i], entries[i+1:]...)
-					if len(entries) == 0 {
-						delete(byDate, date)
-					} else {
-						byDate[date] = entries
-					}
-					r.entriesByUserDate[userID] = byDate
-					return nil
+// DeleteByID удаляет запись пользователя по ID
+func (r *InMemoryEntriesRepository) DeleteByID(userID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byDate := r.entriesByUserDate[userID]
+	for date, entries := range byDate {
+		for i, e := range entries {
+			if e.ID == id {
+				entries = append(entries[:i], entries[i+1:]...)
+				if len(entries) == 0 {
+					delete(byDate, date)
+				} else {
+					byDate[date] = entries
 				}
+				return nil
 			}
 		}
 	}
-	return nil
+	return ErrNotFound
 }
 
-// DeleteByDate удаляет все записи по дате
-func (r *InMemoryEntriesRepository) DeleteByDate(date string) error {
+// DeleteByDate удаляет все записи пользователя за дату
+func (r *InMemoryEntriesRepository) DeleteByDate(userID, date string) error {
 	if len(date) != 10 {
 		return nil // Неверный формат даты
 	}
 
-	for userID, byDate := range r.entriesByUserDate {
-		if _, ok := byDate[date]; ok {
-			delete(byDate, date)
-			r.entriesByUserDate[userID] = byDate
-		}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if byDate, ok := r.entriesByUserDate[userID]; ok {
+		delete(byDate, date)
 	}
 	return nil
 }
+
+// SetEnrichment сохраняет обогащение записи пользователя, если её текст не изменился
+func (r *InMemoryEntriesRepository) SetEnrichment(userID, id, rawText string, enrichment EntryEnrichment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entries := range r.entriesByUserDate[userID] {
+		for i, e := range entries {
+			if e.ID == id && e.RawText == rawText {
+				entries[i].LLMEnriched = &enrichment
+				return nil
+			}
+		}
+	}
+	return ErrNotFound
+}
+
+// ListPendingEnrichment возвращает записи всех пользователей, которые нужно обогатить моделью model
+func (r *InMemoryEntriesRepository) ListPendingEnrichment(model string, maxAttempts, limit int) ([]Entry, error) {
+	return r.listPending(limit, func(e Entry) bool {
+		enr := e.LLMEnriched
+		return enr == nil || enr.Model != model ||
+			(enr.Status == EntryEnrichmentStatusFailed && enr.Attempts > 0 && enr.Attempts < maxAttempts)
+	}, func(e Entry) int {
+		if e.LLMEnriched == nil || e.LLMEnriched.Model != model {
+			return 0
+		}
+		return e.LLMEnriched.Attempts
+	}), nil
+}
+
+// listPending возвращает до limit записей всех пользователей, подходящих под match:
+// записи с меньшим числом неудачных попыток attempts первыми, при равенстве — старые
+func (r *InMemoryEntriesRepository) listPending(limit int, match func(Entry) bool, attempts func(Entry) int) []Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []Entry
+	for _, byDate := range r.entriesByUserDate {
+		for _, entries := range byDate {
+			for _, e := range entries {
+				if match(e) {
+					result = append(result, e)
+				}
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if ai, aj := attempts(result[i]), attempts(result[j]); ai != aj {
+			return ai < aj
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
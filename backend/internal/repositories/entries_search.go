@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"html"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// EntrySearchResult представляет запись, найденную полнотекстовым поиском.
+// Snippet — фрагмент текста с HTML-экранированием, совпадения обёрнуты в <mark>
+type EntrySearchResult struct {
+	Entry   Entry   `json:"entry"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// Маркеры совпадений в сниппете до экранирования: управляющие символы не встречаются в тексте записей,
+// поэтому их можно безопасно заменить на теги после html.EscapeString
+const (
+	snippetStartSel = "\x01"
+	snippetStopSel  = "\x02"
+)
+
+// Размер сниппета в словах для in-memory поиска
+const (
+	snippetWords       = 30
+	snippetWordsBefore = 8
+)
+
+// renderSnippet экранирует сниппет и заменяет маркеры совпадений на <mark>
+func renderSnippet(snippet string) string {
+	snippet = html.EscapeString(snippet)
+	snippet = strings.ReplaceAll(snippet, snippetStartSel, "<mark>")
+	return strings.ReplaceAll(snippet, snippetStopSel, "</mark>")
+}
+
+// Search ищет записи пользователя, содержащие все слова запроса.
+// Это упрощённый аналог полнотекстового поиска Postgres: слова сравниваются по префиксу-основе
+// (см. searchStem), ранг — доля совпавших слов в тексте
+func (r *InMemoryEntriesRepository) Search(userID, query string, limit int) ([]EntrySearchResult, error) {
+	stems := searchStems(query)
+	if len(stems) == 0 {
+		return []EntrySearchResult{}, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []EntrySearchResult{}
+	for _, entries := range r.entriesByUserDate[userID] {
+		for _, e := range entries {
+			words := strings.Fields(e.RawText)
+			matched := make([]bool, len(words))
+			found := make(map[string]bool, len(stems))
+			hits := 0
+			for i, w := range words {
+				for _, stem := range stems {
+					if strings.HasPrefix(searchWord(w), stem) {
+						matched[i] = true
+						found[stem] = true
+					}
+				}
+				if matched[i] {
+					hits++
+				}
+			}
+			if len(found) != len(stems) {
+				continue
+			}
+			result = append(result, EntrySearchResult{
+				Entry:   e,
+				Rank:    float64(hits) / float64(len(words)),
+				Snippet: renderSnippet(buildSnippet(words, matched)),
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rank != result[j].Rank {
+			return result[i].Rank > result[j].Rank
+		}
+		return result[i].Entry.Date > result[j].Entry.Date
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// searchWord приводит слово текста к виду для сравнения: нижний регистр, без знаков препинания по краям
+func searchWord(word string) string {
+	return strings.TrimFunc(strings.ToLower(word), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchStems разбивает запрос на слова и возвращает их основы без повторов
+func searchStems(query string) []string {
+	var stems []string
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		stem := searchStem(w)
+		if !slices.Contains(stems, stem) {
+			stems = append(stems, stem)
+		}
+	}
+	return stems
+}
+
+// searchStem грубо отбрасывает окончание: от слова длиннее 4 букв остаётся около двух третей,
+// чего хватает, чтобы "кэширование" находило "кэширования", а "caching" — "cache"
+func searchStem(word string) string {
+	runes := []rune(word)
+	if len(runes) <= 4 {
+		return word
+	}
+	keep := max(4, 2*len(runes)/3)
+	return string(runes[:keep])
+}
+
+// buildSnippet вырезает окно слов вокруг первого совпадения и отмечает совпавшие слова маркерами
+func buildSnippet(words []string, matched []bool) string {
+	first := 0
+	for i, m := range matched {
+		if m {
+			first = i
+			break
+		}
+	}
+	start := max(0, first-snippetWordsBefore)
+	end := min(len(words), start+snippetWords)
+
+	parts := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		if matched[i] {
+			parts = append(parts, snippetStartSel+words[i]+snippetStopSel)
+		} else {
+			parts = append(parts, words[i])
+		}
+	}
+
+	snippet := strings.Join(parts, " ")
+	if start > 0 {
+		snippet = "… " + snippet
+	}
+	if end < len(words) {
+		snippet += " …"
+	}
+	return snippet
+}
@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EntryEmbedding представляет вектор текста записи, посчитанный моделью Model.
+// TextHash — EntryTextHash текста, по которому посчитан вектор: при изменении текста вектор устаревает
+type EntryEmbedding struct {
+	EntryID   string
+	UserID    string
+	Model     string
+	TextHash  string
+	Vector    []float32
+	CreatedAt time.Time
+}
+
+// EntryEmbeddingFailure представляет ошибку провайдера при расчёте вектора текста записи с хешем TextHash
+type EntryEmbeddingFailure struct {
+	EntryID  string
+	UserID   string
+	Model    string
+	TextHash string
+	Error    string
+	FailedAt time.Time
+}
+
+// EntrySimilarity представляет запись, близкую к вектору запроса.
+// Score — косинусная близость от -1 до 1
+type EntrySimilarity struct {
+	EntryID string
+	Score   float64
+}
+
+// EntryTextHash возвращает хеш текста записи; совпадает с md5(raw_text) в Postgres
+func EntryTextHash(rawText string) string {
+	sum := md5.Sum([]byte(rawText))
+	return hex.EncodeToString(sum[:])
+}
+
+// EntryEmbeddingsRepository определяет интерфейс для хранения эмбеддингов записей.
+// Все методы, кроме ListPending, ограничены владельцем
+type EntryEmbeddingsRepository interface {
+	// Upsert сохраняет вектор, только если запись существует и её текст всё ещё соответствует TextHash.
+	// Иначе возвращает ErrNotFound
+	Upsert(embedding EntryEmbedding) error
+	GetByEntryID(userID, entryID, model string) (EntryEmbedding, error)
+	// Nearest возвращает до limit записей пользователя, ближайших к vector, кроме excludeEntryID
+	Nearest(userID, model string, vector []float32, limit int, excludeEntryID string) ([]EntrySimilarity, error)
+
+	// RecordFailure засчитывает записи неудачную попытку посчитать вектор. Попытки считаются для текущего
+	// текста: после изменения текста счёт начинается заново. Если запись удалена или изменена, возвращает ErrNotFound
+	RecordFailure(failure EntryEmbeddingFailure) error
+
+	// ListPending используется фоновой индексацией и видит записи всех пользователей:
+	// возвращает до limit записей без актуального вектора модели model, кроме тех, на которых провайдер
+	// ошибся maxAttempts раз. Записи с меньшим числом попыток идут первыми, затем старые
+	ListPending(model string, maxAttempts, limit int) ([]Entry, error)
+}
+
+// InMemoryEntryEmbeddingsRepository реализует EntryEmbeddingsRepository с использованием in-memory хранилища.
+// Записи берутся из InMemoryEntriesRepository, поиск ближайших — перебором
+type InMemoryEntryEmbeddingsRepository struct {
+	mu      sync.RWMutex
+	entries *InMemoryEntriesRepository
+	// embeddings хранит векторы: model -> entry_id -> вектор
+	embeddings map[string]map[string]EntryEmbedding
+	// failures хранит неудачные попытки: model -> entry_id -> хеш текста и число попыток
+	failures map[string]map[string]embeddingAttempts
+}
+
+// embeddingAttempts — число неудачных попыток посчитать вектор текста с хешем textHash
+type embeddingAttempts struct {
+	textHash string
+	attempts int
+}
+
+// NewInMemoryEntryEmbeddingsRepository создает новый экземпляр InMemoryEntryEmbeddingsRepository
+func NewInMemoryEntryEmbeddingsRepository(entries *InMemoryEntriesRepository) *InMemoryEntryEmbeddingsRepository {
+	return &InMemoryEntryEmbeddingsRepository{
+		entries:    entries,
+		embeddings: make(map[string]map[string]EntryEmbedding),
+		failures:   make(map[string]map[string]embeddingAttempts),
+	}
+}
+
+// Upsert сохраняет вектор записи пользователя
+func (r *InMemoryEntryEmbeddingsRepository) Upsert(embedding EntryEmbedding) error {
+	entry, err := r.entries.GetByID(embedding.UserID, embedding.EntryID)
+	if err != nil {
+		return err
+	}
+	if EntryTextHash(entry.RawText) != embedding.TextHash {
+		return ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.embeddings[embedding.Model]; !ok {
+		r.embeddings[embedding.Model] = make(map[string]EntryEmbedding)
+	}
+	r.embeddings[embedding.Model][embedding.EntryID] = embedding
+	return nil
+}
+
+// GetByEntryID возвращает вектор записи пользователя
+func (r *InMemoryEntryEmbeddingsRepository) GetByEntryID(userID, entryID, model string) (EntryEmbedding, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	embedding, ok := r.embeddings[model][entryID]
+	if !ok || embedding.UserID != userID {
+		return EntryEmbedding{}, ErrNotFound
+	}
+	return embedding, nil
+}
+
+// Nearest перебирает векторы пользователя и возвращает самые близкие
+func (r *InMemoryEntryEmbeddingsRepository) Nearest(userID, model string, vector []float32, limit int, excludeEntryID string) ([]EntrySimilarity, error) {
+	r.mu.RLock()
+	var candidates []EntryEmbedding
+	for _, embedding := range r.embeddings[model] {
+		if embedding.UserID == userID && embedding.EntryID != excludeEntryID {
+			candidates = append(candidates, embedding)
+		}
+	}
+	r.mu.RUnlock()
+
+	// Удалённые записи в Postgres уходят каскадом, здесь их приходится отсеивать
+	var alive []EntryEmbedding
+	for _, c := range candidates {
+		if _, err := r.entries.GetByID(userID, c.EntryID); err == nil {
+			alive = append(alive, c)
+		}
+	}
+	return nearestByCosine(alive, vector, limit), nil
+}
+
+// RecordFailure засчитывает неудачную попытку для текущего текста записи
+func (r *InMemoryEntryEmbeddingsRepository) RecordFailure(failure EntryEmbeddingFailure) error {
+	entry, err := r.entries.GetByID(failure.UserID, failure.EntryID)
+	if err != nil {
+		return err
+	}
+	if EntryTextHash(entry.RawText) != failure.TextHash {
+		return ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.failures[failure.Model]; !ok {
+		r.failures[failure.Model] = make(map[string]embeddingAttempts)
+	}
+	prev := r.failures[failure.Model][failure.EntryID]
+	if prev.textHash != failure.TextHash {
+		prev = embeddingAttempts{textHash: failure.TextHash}
+	}
+	prev.attempts++
+	r.failures[failure.Model][failure.EntryID] = prev
+	return nil
+}
+
+// ListPending возвращает записи без актуального вектора модели
+func (r *InMemoryEntryEmbeddingsRepository) ListPending(model string, maxAttempts, limit int) ([]Entry, error) {
+	r.mu.RLock()
+	hashes := make(map[string]string, len(r.embeddings[model]))
+	for id, embedding := range r.embeddings[model] {
+		hashes[id] = embedding.TextHash
+	}
+	failures := make(map[string]embeddingAttempts, len(r.failures[model]))
+	for id, failure := range r.failures[model] {
+		failures[id] = failure
+	}
+	r.mu.RUnlock()
+
+	attempts := func(e Entry) int {
+		if f := failures[e.ID]; f.textHash == EntryTextHash(e.RawText) {
+			return f.attempts
+		}
+		return 0
+	}
+	return r.entries.listPending(limit, func(e Entry) bool {
+		return hashes[e.ID] != EntryTextHash(e.RawText) && attempts(e) < maxAttempts
+	}, attempts), nil
+}
+
+// nearestByCosine сортирует векторы по косинусной близости к vector и возвращает первые limit
+func nearestByCosine(embeddings []EntryEmbedding, vector []float32, limit int) []EntrySimilarity {
+	result := make([]EntrySimilarity, 0, len(embeddings))
+	for _, e := range embeddings {
+		if len(e.Vector) != len(vector) {
+			continue
+		}
+		result = append(result, EntrySimilarity{EntryID: e.EntryID, Score: CosineSimilarity(e.Vector, vector)})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// CosineSimilarity возвращает косинус угла между векторами одинаковой длины; для нулевого вектора — 0
+func CosineSimilarity(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "errors"
+
+// ErrNotFound возвращается, когда запрошенная запись не найдена
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists возвращается, когда запись с таким уникальным ключом уже есть
+var ErrAlreadyExists = errors.New("already exists")
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type GoalEntryLink struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"user_id"`
+	GoalID         string    `json:"goal_id"`
+	EntryID        string    `json:"entry_id"`
+	RelevanceScore float64   `json:"relevance_score"`
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// GoalEntryLinksRepository определяет интерфейс для работы со связями целей и записей.
+// Все методы ограничены владельцем: связи другого пользователя для них не существуют
+type GoalEntryLinksRepository interface {
+	// Upsert создаёт связь владельца link.UserID или обновляет relevance_score и note существующей связи (goal_id, entry_id)
+	Upsert(link GoalEntryLink) (GoalEntryLink, error)
+	Delete(userID, goalID, entryID string) error
+	ListByGoal(userID, goalID string) ([]GoalEntryLink, error)
+	DeleteByGoalID(userID, goalID string) error
+	DeleteByEntryID(userID, entryID string) error
+}
+
+// InMemoryGoalEntryLinksRepository реализует GoalEntryLinksRepository с использованием in-memory хранилища
+type InMemoryGoalEntryLinksRepository struct {
+	mu    sync.RWMutex
+	links map[string]GoalEntryLink // ключ — goalID + "/" + entryID
+}
+
+// NewInMemoryGoalEntryLinksRepository создает новый экземпляр InMemoryGoalEntryLinksRepository
+func NewInMemoryGoalEntryLinksRepository() *InMemoryGoalEntryLinksRepository {
+	return &InMemoryGoalEntryLinksRepository{
+		links: make(map[string]GoalEntryLink),
+	}
+}
+
+// Upsert создаёт или обновляет связь
+func (r *InMemoryGoalEntryLinksRepository) Upsert(link GoalEntryLink) (GoalEntryLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := link.GoalID + "/" + link.EntryID
+	if existing, ok := r.links[key]; ok {
+		// Цель и запись принадлежат одному пользователю, так что чужая связь с тем же ключом невозможна
+		if existing.UserID != link.UserID {
+			return GoalEntryLink{}, ErrNotFound
+		}
+		existing.RelevanceScore = link.RelevanceScore
+		existing.Note = link.Note
+		r.links[key] = existing
+		return existing, nil
+	}
+	r.links[key] = link
+	return link, nil
+}
+
+// Delete удаляет связь цели и записи пользователя
+func (r *InMemoryGoalEntryLinksRepository) Delete(userID, goalID, entryID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := goalID + "/" + entryID
+	if l, ok := r.links[key]; !ok || l.UserID != userID {
+		return ErrNotFound
+	}
+	delete(r.links, key)
+	return nil
+}
+
+// ListByGoal возвращает связи цели пользователя, самые релевантные первыми
+func (r *InMemoryGoalEntryLinksRepository) ListByGoal(userID, goalID string) ([]GoalEntryLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []GoalEntryLink{}
+	for _, l := range r.links {
+		if l.UserID == userID && l.GoalID == goalID {
+			result = append(result, l)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RelevanceScore != result[j].RelevanceScore {
+			return result[i].RelevanceScore > result[j].RelevanceScore
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// DeleteByGoalID удаляет все связи цели пользователя
+func (r *InMemoryGoalEntryLinksRepository) DeleteByGoalID(userID, goalID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, l := range r.links {
+		if l.UserID == userID && l.GoalID == goalID {
+			delete(r.links, key)
+		}
+	}
+	return nil
+}
+
+// DeleteByEntryID удаляет все связи записи пользователя
+func (r *InMemoryGoalEntryLinksRepository) DeleteByEntryID(userID, entryID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, l := range r.links {
+		if l.UserID == userID && l.EntryID == entryID {
+			delete(r.links, key)
+		}
+	}
+	return nil
+}
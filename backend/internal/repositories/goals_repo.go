@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type GoalStatus string
+
+const (
+	GoalStatusDraft    GoalStatus = "draft"
+	GoalStatusActive   GoalStatus = "active"
+	GoalStatusArchived GoalStatus = "archived"
+)
+
+type Goal struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      GoalStatus `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// GoalsRepository определяет интерфейс для работы с целями
+// Все методы ограничены владельцем: цель другого пользователя для них не существует (ErrNotFound)
+type GoalsRepository interface {
+	// Create сохраняет цель владельца goal.UserID
+	Create(goal Goal) error
+	GetByID(userID, id string) (Goal, error)
+	// ListByUser возвращает цели пользователя; пустой status — цели во всех статусах
+	ListByUser(userID string, status GoalStatus) ([]Goal, error)
+	Update(userID string, goal Goal) error
+	DeleteByID(userID, id string) error
+}
+
+// InMemoryGoalsRepository реализует GoalsRepository с использованием in-memory хранилища
+type InMemoryGoalsRepository struct {
+	mu    sync.RWMutex
+	goals map[string]Goal
+}
+
+// NewInMemoryGoalsRepository создает новый экземпляр InMemoryGoalsRepository
+func NewInMemoryGoalsRepository() *InMemoryGoalsRepository {
+	return &InMemoryGoalsRepository{
+		goals: make(map[string]Goal),
+	}
+}
+
+// Create добавляет новую цель
+func (r *InMemoryGoalsRepository) Create(goal Goal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.goals[goal.ID] = goal
+	return nil
+}
+
+// GetByID возвращает цель пользователя по ID
+func (r *InMemoryGoalsRepository) GetByID(userID, id string) (Goal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	goal, ok := r.goals[id]
+	if !ok || goal.UserID != userID {
+		return Goal{}, ErrNotFound
+	}
+	return goal, nil
+}
+
+// ListByUser возвращает цели пользователя в порядке создания
+func (r *InMemoryGoalsRepository) ListByUser(userID string, status GoalStatus) ([]Goal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []Goal{}
+	for _, g := range r.goals {
+		if g.UserID != userID {
+			continue
+		}
+		if status != "" && g.Status != status {
+			continue
+		}
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// Update обновляет название, описание и статус цели пользователя
+func (r *InMemoryGoalsRepository) Update(userID string, goal Goal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.goals[goal.ID]
+	if !ok || existing.UserID != userID {
+		return ErrNotFound
+	}
+	existing.Title = goal.Title
+	existing.Description = goal.Description
+	existing.Status = goal.Status
+	existing.UpdatedAt = goal.UpdatedAt
+	r.goals[goal.ID] = existing
+	return nil
+}
+
+// DeleteByID удаляет цель пользователя по ID
+func (r *InMemoryGoalsRepository) DeleteByID(userID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if goal, ok := r.goals[id]; !ok || goal.UserID != userID {
+		return ErrNotFound
+	}
+	delete(r.goals, id)
+	return nil
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+)
+
+// LocalSummaryBullet — пункт саммари дня или недели с записями, на которые сослалась модель
+type LocalSummaryBullet struct {
+	Text    string               `json:"text"`
+	Sources []PerfEvidenceSource `json:"sources"`
+}
+
+// LocalSummary представляет кэшированное саммари дня или недели.
+// EntriesHash — хеш записей периода, по которым оно получено: если записи изменились, саммари устарело
+type LocalSummary struct {
+	UserID         string               `json:"user_id"`
+	PeriodStart    string               `json:"period_start"`
+	PeriodEnd      string               `json:"period_end"`
+	EntriesHash    string               `json:"entries_hash"`
+	Bullets        []LocalSummaryBullet `json:"bullets"`
+	CandidateGoals []string             `json:"candidate_goals"`
+	CreatedAt      time.Time            `json:"created_at"`
+}
+
+// LocalSummariesRepository определяет интерфейс для кэша саммари дня или недели.
+// Для каждого периода пользователя хранится только последнее саммари
+type LocalSummariesRepository interface {
+	// Get возвращает саммари периода пользователя или ErrNotFound
+	Get(userID, periodStart, periodEnd string) (LocalSummary, error)
+	// Upsert сохраняет саммари, заменяя прежнее за тот же период
+	Upsert(summary LocalSummary) error
+}
+
+// InMemoryLocalSummariesRepository реализует LocalSummariesRepository с использованием in-memory хранилища
+type InMemoryLocalSummariesRepository struct {
+	mu        sync.RWMutex
+	summaries map[string]LocalSummary
+}
+
+// NewInMemoryLocalSummariesRepository создает новый экземпляр InMemoryLocalSummariesRepository
+func NewInMemoryLocalSummariesRepository() *InMemoryLocalSummariesRepository {
+	return &InMemoryLocalSummariesRepository{
+		summaries: make(map[string]LocalSummary),
+	}
+}
+
+// Get возвращает саммари периода пользователя
+func (r *InMemoryLocalSummariesRepository) Get(userID, periodStart, periodEnd string) (LocalSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary, ok := r.summaries[localSummaryKey(userID, periodStart, periodEnd)]
+	if !ok {
+		return LocalSummary{}, ErrNotFound
+	}
+	return summary, nil
+}
+
+// Upsert сохраняет саммари периода пользователя
+func (r *InMemoryLocalSummariesRepository) Upsert(summary LocalSummary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.summaries[localSummaryKey(summary.UserID, summary.PeriodStart, summary.PeriodEnd)] = summary
+	return nil
+}
+
+// localSummaryKey собирает ключ кэша из пользователя и границ периода
+func localSummaryKey(userID, periodStart, periodEnd string) string {
+	return userID + "|" + periodStart + "|" + periodEnd
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// PerfGoal представляет цель в перф-саммари в формате Context / Outputs / Outcomes.
+// OutputEvidence и OutcomeEvidence идут параллельно Outputs и Outcomes: i-й элемент — источники i-го пункта.
+type PerfGoal struct {
+	ID              string         `json:"id"`
+	Title           string         `json:"title"`
+	Context         string         `json:"context"`
+	Outputs         []string       `json:"outputs"`
+	Outcomes        []string       `json:"outcomes"`
+	OutputEvidence  []PerfEvidence `json:"output_evidence"`
+	OutcomeEvidence []PerfEvidence `json:"outcome_evidence"`
+}
+
+// PerfEvidence перечисляет записи, из которых получен пункт outputs/outcomes.
+// Unsourced выставляется, если модель не сослалась ни на одну из переданных ей записей.
+// UnsupportedMetrics — числа из текста пункта, которых нет в исходных записях.
+type PerfEvidence struct {
+	Sources            []PerfEvidenceSource `json:"sources"`
+	Unsourced          bool                 `json:"unsourced"`
+	UnsupportedMetrics []string             `json:"unsupported_metrics"`
+}
+
+// PerfEvidenceSource ссылается на запись, подтверждающую пункт перф-саммари
+type PerfEvidenceSource struct {
+	EntryID string `json:"entry_id"`
+	Date    string `json:"date"`
+}
+
+// PerfSummary представляет сохранённое перф-саммари за период
+type PerfSummary struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	PeriodStart string     `json:"period_start"`
+	PeriodEnd   string     `json:"period_end"`
+	Tags        []string   `json:"tags"`
+	RawGoals    []string   `json:"raw_goals"`
+	SummaryText string     `json:"summary_text"`
+	Goals       []PerfGoal `json:"goals"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// PerfSummaryRepository определяет интерфейс для работы с перф-саммари.
+// Все методы ограничены владельцем: саммари другого пользователя для них не существует (ErrNotFound)
+type PerfSummaryRepository interface {
+	// Create сохраняет саммари владельца summary.UserID
+	Create(summary PerfSummary) error
+	GetByID(userID, id string) (PerfSummary, error)
+	ListByUser(userID string) ([]PerfSummary, error)
+	// Update заменяет содержимое саммари с тем же ID одной операцией
+	Update(summary PerfSummary) error
+	DeleteByID(userID, id string) error
+}
+
+// InMemoryPerfSummaryRepository реализует PerfSummaryRepository с использованием in-memory хранилища
+type InMemoryPerfSummaryRepository struct {
+	mu        sync.RWMutex
+	summaries map[string]PerfSummary
+}
+
+// NewInMemoryPerfSummaryRepository создает новый экземпляр InMemoryPerfSummaryRepository
+func NewInMemoryPerfSummaryRepository() *InMemoryPerfSummaryRepository {
+	return &InMemoryPerfSummaryRepository{
+		summaries: make(map[string]PerfSummary),
+	}
+}
+
+// Create сохраняет перф-саммари
+func (r *InMemoryPerfSummaryRepository) Create(summary PerfSummary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.summaries[summary.ID] = summary
+	return nil
+}
+
+// GetByID возвращает перф-саммари пользователя по ID
+func (r *InMemoryPerfSummaryRepository) GetByID(userID, id string) (PerfSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary, ok := r.summaries[id]
+	if !ok || summary.UserID != userID {
+		return PerfSummary{}, ErrNotFound
+	}
+	return summary, nil
+}
+
+// ListByUser возвращает историю перф-саммари пользователя, новые первыми
+func (r *InMemoryPerfSummaryRepository) ListByUser(userID string) ([]PerfSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []PerfSummary{}
+	for _, s := range r.summaries {
+		if s.UserID == userID {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// Update заменяет перф-саммари пользователя с тем же ID
+func (r *InMemoryPerfSummaryRepository) Update(summary PerfSummary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.summaries[summary.ID]; !ok || existing.UserID != summary.UserID {
+		return ErrNotFound
+	}
+	r.summaries[summary.ID] = summary
+	return nil
+}
+
+// DeleteByID удаляет перф-саммари пользователя по ID
+func (r *InMemoryPerfSummaryRepository) DeleteByID(userID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if summary, ok := r.summaries[id]; !ok || summary.UserID != userID {
+		return ErrNotFound
+	}
+	delete(r.summaries, id)
+	return nil
+}
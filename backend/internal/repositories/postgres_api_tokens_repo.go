@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// apiTokenColumns — список колонок api_tokens в порядке scanAPIToken
+const apiTokenColumns = `id, user_id, name, token_hash, token_prefix, scopes, created_at, last_used_at, revoked_at`
+
+// PostgresAPITokensRepository реализует APITokensRepository с использованием PostgreSQL
+type PostgresAPITokensRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAPITokensRepository создает новый экземпляр PostgresAPITokensRepository
+func NewPostgresAPITokensRepository(db *sql.DB) *PostgresAPITokensRepository {
+	return &PostgresAPITokensRepository{
+		db: db,
+	}
+}
+
+// Create добавляет токен
+func (r *PostgresAPITokensRepository) Create(token APIToken) error {
+	scopes, err := json.Marshal(nonNilStrings(token.Scopes))
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO api_tokens (` + apiTokenColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err = r.db.Exec(query, token.ID, token.UserID, token.Name, token.TokenHash, token.TokenPrefix, scopes,
+		token.CreatedAt, token.LastUsedAt, token.RevokedAt)
+	return err
+}
+
+// GetByHash возвращает неотозванный токен по хешу
+func (r *PostgresAPITokensRepository) GetByHash(hash string) (APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = $1 AND revoked_at IS NULL`
+	token, err := scanAPIToken(r.db.QueryRow(query, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return APIToken{}, ErrNotFound
+	}
+	return token, err
+}
+
+// ListByUser возвращает неотозванные токены пользователя, новые первыми
+func (r *PostgresAPITokensRepository) ListByUser(userID string) ([]APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// Revoke отзывает токен пользователя
+func (r *PostgresAPITokensRepository) Revoke(userID, id string, at time.Time) error {
+	query := `UPDATE api_tokens SET revoked_at = $1 WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL`
+	res, err := r.db.Exec(query, at, id, userID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// TouchLastUsed обновляет момент последнего использования токена
+func (r *PostgresAPITokensRepository) TouchLastUsed(userID, id string, at time.Time) error {
+	query := `UPDATE api_tokens SET last_used_at = $1 WHERE id = $2 AND user_id = $3`
+	res, err := r.db.Exec(query, at, id, userID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// scanAPIToken читает одну строку api_tokens
+func scanAPIToken(row rowScanner) (APIToken, error) {
+	var token APIToken
+	var scopes []byte
+	var lastUsedAt, revokedAt sql.NullTime
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.TokenPrefix, &scopes,
+		&token.CreatedAt, &lastUsedAt, &revokedAt)
+	if err != nil {
+		return APIToken{}, err
+	}
+	if err := json.Unmarshal(scopes, &token.Scopes); err != nil {
+		return APIToken{}, err
+	}
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	return token, nil
+}
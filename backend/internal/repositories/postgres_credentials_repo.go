@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// PostgresCredentialsRepository реализует CredentialsRepository с использованием PostgreSQL
+type PostgresCredentialsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCredentialsRepository создает новый экземпляр PostgresCredentialsRepository
+func NewPostgresCredentialsRepository(db *sql.DB) *PostgresCredentialsRepository {
+	return &PostgresCredentialsRepository{
+		db: db,
+	}
+}
+
+// Create добавляет учётные данные пользователя
+func (r *PostgresCredentialsRepository) Create(credentials Credentials) error {
+	query := `
+		INSERT INTO user_credentials (user_id, email, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING`
+	res, err := r.db.Exec(query, credentials.UserID, credentials.Email, credentials.PasswordHash, credentials.CreatedAt, credentials.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	// Конфликт по email или user_id — строка не вставлена
+	if err := requireAffected(res); errors.Is(err, ErrNotFound) {
+		return ErrAlreadyExists
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByEmail возвращает учётные данные по email
+func (r *PostgresCredentialsRepository) GetByEmail(email string) (Credentials, error) {
+	query := `SELECT user_id, email, password_hash, created_at, updated_at FROM user_credentials WHERE email = $1`
+	var credentials Credentials
+	err := r.db.QueryRow(query, email).Scan(&credentials.UserID, &credentials.Email, &credentials.PasswordHash, &credentials.CreatedAt, &credentials.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Credentials{}, ErrNotFound
+	}
+	if err != nil {
+		return Credentials{}, err
+	}
+	return credentials, nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// PostgresDailyNotesSyncRepository реализует DailyNotesSyncRepository с использованием PostgreSQL
+type PostgresDailyNotesSyncRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDailyNotesSyncRepository создает новый экземпляр PostgresDailyNotesSyncRepository
+func NewPostgresDailyNotesSyncRepository(db *sql.DB) *PostgresDailyNotesSyncRepository {
+	return &PostgresDailyNotesSyncRepository{
+		db: db,
+	}
+}
+
+// ListStates возвращает состояния синхронизации пользователя
+func (r *PostgresDailyNotesSyncRepository) ListStates(userID string) ([]DailyNoteSyncState, error) {
+	query := `
+		SELECT to_char(date, 'YYYY-MM-DD'), type, file_hash, entry_hash, synced_at
+		FROM daily_note_sync_states
+		WHERE user_id = $1`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var states []DailyNoteSyncState
+	for rows.Next() {
+		state := DailyNoteSyncState{UserID: userID}
+		if err := rows.Scan(&state.Date, &state.Type, &state.FileHash, &state.EntryHash, &state.SyncedAt); err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, rows.Err()
+}
+
+// GetState возвращает состояние синхронизации для даты и типа записи
+func (r *PostgresDailyNotesSyncRepository) GetState(userID, date string, entryType EntryType) (DailyNoteSyncState, error) {
+	query := `
+		SELECT file_hash, entry_hash, synced_at
+		FROM daily_note_sync_states
+		WHERE user_id = $1 AND date = $2 AND type = $3`
+	state := DailyNoteSyncState{UserID: userID, Date: date, Type: entryType}
+	err := r.db.QueryRow(query, userID, date, entryType).Scan(&state.FileHash, &state.EntryHash, &state.SyncedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DailyNoteSyncState{}, ErrNotFound
+	}
+	if err != nil {
+		return DailyNoteSyncState{}, err
+	}
+	return state, nil
+}
+
+// SaveState сохраняет состояние синхронизации, заменяя прежнее
+func (r *PostgresDailyNotesSyncRepository) SaveState(state DailyNoteSyncState) error {
+	query := `
+		INSERT INTO daily_note_sync_states (user_id, date, type, file_hash, entry_hash, synced_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, date, type)
+		DO UPDATE SET file_hash = EXCLUDED.file_hash, entry_hash = EXCLUDED.entry_hash, synced_at = EXCLUDED.synced_at`
+	_, err := r.db.Exec(query, state.UserID, state.Date, state.Type, state.FileHash, state.EntryHash, state.SyncedAt)
+	return err
+}
+
+// DeleteState удаляет состояние синхронизации
+func (r *PostgresDailyNotesSyncRepository) DeleteState(userID, date string, entryType EntryType) error {
+	query := `DELETE FROM daily_note_sync_states WHERE user_id = $1 AND date = $2 AND type = $3`
+	_, err := r.db.Exec(query, userID, date, entryType)
+	return err
+}
+
+// AddConflict добавляет конфликт в журнал
+func (r *PostgresDailyNotesSyncRepository) AddConflict(conflict DailyNoteConflict) error {
+	query := `
+		INSERT INTO daily_note_conflicts (id, user_id, date, type, winner, file_text, entry_text, file_modified_at, entry_updated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := r.db.Exec(query, conflict.ID, conflict.UserID, conflict.Date, conflict.Type, conflict.Winner,
+		conflict.FileText, conflict.EntryText, conflict.FileModifiedAt, conflict.EntryUpdatedAt, conflict.CreatedAt)
+	return err
+}
+
+// ListConflicts возвращает конфликты пользователя, новые первыми
+func (r *PostgresDailyNotesSyncRepository) ListConflicts(userID string, limit int) ([]DailyNoteConflict, error) {
+	query := `
+		SELECT id, to_char(date, 'YYYY-MM-DD'), type, winner, file_text, entry_text, file_modified_at, entry_updated_at, created_at
+		FROM daily_note_conflicts
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+	rows, err := r.db.Query(query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := []DailyNoteConflict{}
+	for rows.Next() {
+		c := DailyNoteConflict{UserID: userID}
+		if err := rows.Scan(&c.ID, &c.Date, &c.Type, &c.Winner, &c.FileText, &c.EntryText, &c.FileModifiedAt, &c.EntryUpdatedAt, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, c)
+	}
+	return conflicts, rows.Err()
+}
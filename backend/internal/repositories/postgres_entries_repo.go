@@ -2,10 +2,15 @@ package repositories
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
 
+// entryColumns — список колонок entries в порядке scanEntry
+const entryColumns = `id, user_id, date, type, raw_text, llm_enriched, created_at, updated_at`
+
 // PostgresEntriesRepository реализует EntriesRepository с использованием PostgreSQL
 type PostgresEntriesRepository struct {
 	db *sql.DB
@@ -18,75 +23,148 @@ func NewPostgresEntriesRepository(db *sql.DB) *PostgresEntriesRepository {
 	}
 }
 
-// Create добавляет новую запись или обновляет существующую с тем же типом для той же даты
-func (r *PostgresEntriesRepository) Create(entry Entry) error {
+// Create добавляет новую запись или обновляет существующую с тем же типом для той же даты.
+// При обновлении у записи остаётся прежний ID — его и возвращает RETURNING.
+// Обогащение сохраняется, только если текст не изменился
+func (r *PostgresEntriesRepository) Create(entry Entry) (Entry, error) {
 	query := `
-		INSERT INTO entries (id, user_id, date, type, raw_text, created_at) 
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (user_id, date, type) 
-		DO UPDATE SET raw_text = EXCLUDED.raw_text, created_at = EXCLUDED.created_at`
-	_, err := r.db.Exec(query, entry.ID, entry.UserID, entry.Date, entry.Type, entry.RawText, entry.CreatedAt)
+		INSERT INTO entries (id, user_id, date, type, raw_text, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, date, type)
+		DO UPDATE SET raw_text = EXCLUDED.raw_text, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at,
+			llm_enriched = CASE WHEN entries.raw_text = EXCLUDED.raw_text THEN entries.llm_enriched END
+		RETURNING ` + entryColumns
+	saved, err := scanEntry(r.db.QueryRow(query, entry.ID, entry.UserID, entry.Date, entry.Type, entry.RawText, entry.CreatedAt, entry.UpdatedAt))
 	if err != nil {
-		// Логирование ошибки для отладки
-		fmt.Printf("Error creating entry: %v\n", err)
-		fmt.Printf("Entry data: %+v\n", entry)
-		return err
+		return Entry{}, fmt.Errorf("failed to create entry: %w", err)
+	}
+	return saved, nil
+}
+
+// GetByID возвращает запись пользователя по ID
+func (r *PostgresEntriesRepository) GetByID(userID, id string) (Entry, error) {
+	query := `SELECT ` + entryColumns + ` FROM entries WHERE id = $1 AND user_id = $2`
+	entry, err := scanEntry(r.db.QueryRow(query, id, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Entry{}, ErrNotFound
+	}
+	if err != nil {
+		return Entry{}, err
 	}
-	return nil
+	return entry, nil
 }
 
 // ListByUserAndDate возвращает записи для конкретного пользователя и даты
 func (r *PostgresEntriesRepository) ListByUserAndDate(userID, date string) ([]Entry, error) {
-	query := `SELECT id, user_id, date, type, raw_text, created_at FROM entries WHERE user_id = $1 AND date = $2`
-	rows, err := r.db.Query(query, userID, date)
+	query := `SELECT ` + entryColumns + ` FROM entries WHERE user_id = $1 AND date = $2`
+	return r.list(query, userID, date)
+}
+
+// ListByUserAndPeriod возвращает записи для пользователя за период
+func (r *PostgresEntriesRepository) ListByUserAndPeriod(userID, from, to string) ([]Entry, error) {
+	query := `SELECT ` + entryColumns + ` FROM entries WHERE user_id = $1 AND date >= $2 AND date <= $3 ORDER BY date`
+	return r.list(query, userID, from, to)
+}
+
+// ListByUserUpdatedSince возвращает записи пользователя, изменённые не раньше since
+func (r *PostgresEntriesRepository) ListByUserUpdatedSince(userID string, since time.Time) ([]Entry, error) {
+	query := `SELECT ` + entryColumns + ` FROM entries WHERE user_id = $1 AND updated_at >= $2 ORDER BY date`
+	return r.list(query, userID, since)
+}
+
+// Update обновляет запись пользователя. Изменение текста сбрасывает обогащение
+func (r *PostgresEntriesRepository) Update(userID string, entry Entry) error {
+	query := `
+		UPDATE entries
+		SET llm_enriched = CASE WHEN raw_text = $1 THEN llm_enriched END, raw_text = $1, updated_at = $4
+		WHERE id = $2 AND user_id = $3`
+	res, err := r.db.Exec(query, entry.RawText, entry.ID, userID, entry.UpdatedAt)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
+	return requireAffected(res)
+}
 
-	var entries []Entry
-	for rows.Next() {
-		var entry Entry
-		var createdAt time.Time
-		err := rows.Scan(&entry.ID, &entry.UserID, &entry.Date, &entry.Type, &entry.RawText, &createdAt)
-		if err != nil {
-			return nil, err
-		}
-		entry.CreatedAt = createdAt
-		entries = append(entries, entry)
+// DeleteByID удаляет запись пользователя по ID
+func (r *PostgresEntriesRepository) DeleteByID(userID, id string) error {
+	query := `DELETE FROM entries WHERE id = $1 AND user_id = $2`
+	res, err := r.db.Exec(query, id, userID)
+	if err != nil {
+		return err
 	}
+	return requireAffected(res)
+}
 
-	return entries, nil
+// DeleteByDate удаляет все записи пользователя за дату
+func (r *PostgresEntriesRepository) DeleteByDate(userID, date string) error {
+	query := `DELETE FROM entries WHERE user_id = $1 AND date = $2`
+	_, err := r.db.Exec(query, userID, date)
+	return err
 }
 
-// ListByUserAndPeriod возвращает записи для пользователя за период
-func (r *PostgresEntriesRepository) ListByUserAndPeriod(userID, from, to string) ([]Entry, error) {
-	query := `SELECT id, user_id, date, type, raw_text, created_at FROM entries WHERE user_id = $1 AND date >= $2 AND date <= $3 ORDER BY date`
-	rows, err := r.db.Query(query, userID, from, to)
+// SetEnrichment сохраняет обогащение записи пользователя, если её текст не изменился
+func (r *PostgresEntriesRepository) SetEnrichment(userID, id, rawText string, enrichment EntryEnrichment) error {
+	value, err := json.Marshal(enrichment)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE entries SET llm_enriched = $1 WHERE id = $2 AND user_id = $3 AND raw_text = $4`
+	res, err := r.db.Exec(query, value, id, userID, rawText)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// ListPendingEnrichment возвращает записи всех пользователей, которые нужно обогатить моделью model
+func (r *PostgresEntriesRepository) ListPendingEnrichment(model string, maxAttempts, limit int) ([]Entry, error) {
+	query := `
+		SELECT ` + entryColumns + ` FROM entries
+		WHERE llm_enriched IS NULL
+			OR llm_enriched->>'model' IS DISTINCT FROM $1
+			OR (llm_enriched->>'status' = 'failed' AND COALESCE((llm_enriched->>'attempts')::int, 0) BETWEEN 1 AND $2 - 1)
+		ORDER BY CASE WHEN llm_enriched->>'model' = $1 THEN COALESCE((llm_enriched->>'attempts')::int, 0) ELSE 0 END, created_at
+		LIMIT $3`
+	return r.list(query, model, maxAttempts, limit)
+}
+
+// searchHeadlineOptions настраивают ts_headline: до двух фрагментов, совпадения между маркерами snippetStartSel/snippetStopSel
+const searchHeadlineOptions = "StartSel=" + snippetStartSel + ", StopSel=" + snippetStopSel +
+	", MinWords=10, MaxWords=30, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// Search ищет записи пользователя по search_vector. Запрос разбирается websearch_to_tsquery,
+// поэтому поддерживаются "фразы в кавычках", OR и -исключения
+func (r *PostgresEntriesRepository) Search(userID, query string, limit int) ([]EntrySearchResult, error) {
+	sqlQuery := `
+		SELECT ` + entryColumns + `, ts_rank(search_vector, q), ts_headline('russian', raw_text, q, $3)
+		FROM entries, websearch_to_tsquery('russian', $2) AS q
+		WHERE user_id = $1 AND search_vector @@ q
+		ORDER BY ts_rank(search_vector, q) DESC, date DESC
+		LIMIT $4`
+	rows, err := r.db.Query(sqlQuery, userID, query, searchHeadlineOptions, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var entries []Entry
+	results := []EntrySearchResult{}
 	for rows.Next() {
-		var entry Entry
-		var createdAt time.Time
-		err := rows.Scan(&entry.ID, &entry.UserID, &entry.Date, &entry.Type, &entry.RawText, &createdAt)
+		var result EntrySearchResult
+		result.Entry, err = scanEntry(withExtraColumns(rows, &result.Rank, &result.Snippet))
 		if err != nil {
 			return nil, err
 		}
-		entry.CreatedAt = createdAt
-		entries = append(entries, entry)
+		result.Snippet = renderSnippet(result.Snippet)
+		results = append(results, result)
 	}
 
-	return entries, nil
+	return results, rows.Err()
 }
 
-// ListAll возвращает все записи
-func (r *PostgresEntriesRepository) ListAll() ([]Entry, error) {
-	query := `SELECT id, user_id, date, type, raw_text, created_at FROM entries ORDER BY date`
-	rows, err := r.db.Query(query)
+// list выполняет запрос, возвращающий entryColumns, и читает все строки
+func (r *PostgresEntriesRepository) list(query string, args ...any) ([]Entry, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -94,36 +172,45 @@ func (r *PostgresEntriesRepository) ListAll() ([]Entry, error) {
 
 	var entries []Entry
 	for rows.Next() {
-		var entry Entry
-		var createdAt time.Time
-		err := rows.Scan(&entry.ID, &entry.UserID, &entry.Date, &entry.Type, &entry.RawText, &createdAt)
+		entry, err := scanEntry(rows)
 		if err != nil {
 			return nil, err
 		}
-		entry.CreatedAt = createdAt
 		entries = append(entries, entry)
 	}
 
-	return entries, nil
+	return entries, rows.Err()
 }
 
-// Update обновляет запись
-func (r *PostgresEntriesRepository) Update(entry Entry) error {
-	query := `UPDATE entries SET raw_text = $1 WHERE id = $2`
-	_, err := r.db.Exec(query, entry.RawText, entry.ID)
-	return err
+// scanEntry читает одну строку entries
+func scanEntry(row rowScanner) (Entry, error) {
+	var entry Entry
+	var enriched []byte
+	err := row.Scan(&entry.ID, &entry.UserID, &entry.Date, &entry.Type, &entry.RawText, &enriched, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return Entry{}, err
+	}
+	if enriched != nil {
+		entry.LLMEnriched = &EntryEnrichment{}
+		if err := json.Unmarshal(enriched, entry.LLMEnriched); err != nil {
+			return Entry{}, err
+		}
+	}
+	return entry, nil
 }
 
-// DeleteByID удаляет запись по ID
-func (r *PostgresEntriesRepository) DeleteByID(id string) error {
-	query := `DELETE FROM entries WHERE id = $1`
-	_, err := r.db.Exec(query, id)
-	return err
+// extraColumnsScanner дочитывает колонки, выбранные после entryColumns
+type extraColumnsScanner struct {
+	row   rowScanner
+	extra []any
 }
 
-// DeleteByDate удаляет все записи по дате
-func (r *PostgresEntriesRepository) DeleteByDate(date string) error {
-	query := `DELETE FROM entries WHERE date = $1`
-	_, err := r.db.Exec(query, date)
-	return err
+// withExtraColumns позволяет scanEntry читать строку, в которой после entryColumns есть ещё колонки
+func withExtraColumns(row rowScanner, extra ...any) rowScanner {
+	return extraColumnsScanner{row: row, extra: extra}
+}
+
+// Scan читает колонки записи и дополнительные колонки одной строки
+func (s extraColumnsScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, s.extra...)...)
 }
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/lib/pq"
+)
+
+// PostgresEntryEmbeddingsRepository реализует EntryEmbeddingsRepository с использованием PostgreSQL.
+// Векторы хранятся как REAL[]; если установлено расширение pgvector, близость считает база,
+// иначе векторы пользователя читаются и сравниваются перебором в Go
+type PostgresEntryEmbeddingsRepository struct {
+	db *sql.DB
+
+	pgvectorOnce sync.Once
+	pgvector     bool
+}
+
+// NewPostgresEntryEmbeddingsRepository создает новый экземпляр PostgresEntryEmbeddingsRepository
+func NewPostgresEntryEmbeddingsRepository(db *sql.DB) *PostgresEntryEmbeddingsRepository {
+	return &PostgresEntryEmbeddingsRepository{
+		db: db,
+	}
+}
+
+// Upsert сохраняет вектор записи пользователя, если её текст не изменился
+func (r *PostgresEntryEmbeddingsRepository) Upsert(embedding EntryEmbedding) error {
+	query := `
+		INSERT INTO entry_embeddings (entry_id, user_id, model, text_hash, embedding, created_at)
+		SELECT id, user_id, $3::text, $4::text, $5::real[], $6::timestamptz
+		FROM entries
+		WHERE id = $1 AND user_id = $2 AND md5(raw_text) = $4::text
+		ON CONFLICT (entry_id, model)
+		DO UPDATE SET text_hash = EXCLUDED.text_hash, embedding = EXCLUDED.embedding, created_at = EXCLUDED.created_at`
+	res, err := r.db.Exec(query, embedding.EntryID, embedding.UserID, embedding.Model, embedding.TextHash,
+		pq.Array(embedding.Vector), embedding.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// GetByEntryID возвращает вектор записи пользователя
+func (r *PostgresEntryEmbeddingsRepository) GetByEntryID(userID, entryID, model string) (EntryEmbedding, error) {
+	query := `
+		SELECT entry_id, user_id, model, text_hash, embedding, created_at
+		FROM entry_embeddings
+		WHERE entry_id = $1 AND user_id = $2 AND model = $3`
+	var embedding EntryEmbedding
+	var vector pq.Float32Array
+	err := r.db.QueryRow(query, entryID, userID, model).Scan(&embedding.EntryID, &embedding.UserID, &embedding.Model,
+		&embedding.TextHash, &vector, &embedding.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return EntryEmbedding{}, ErrNotFound
+	}
+	if err != nil {
+		return EntryEmbedding{}, err
+	}
+	embedding.Vector = vector
+	return embedding, nil
+}
+
+// Nearest возвращает записи пользователя, ближайшие к vector
+func (r *PostgresEntryEmbeddingsRepository) Nearest(userID, model string, vector []float32, limit int, excludeEntryID string) ([]EntrySimilarity, error) {
+	if r.hasPGVector() {
+		return r.nearestPGVector(userID, model, vector, limit, excludeEntryID)
+	}
+
+	query := `SELECT entry_id, embedding FROM entry_embeddings WHERE user_id = $1 AND model = $2 AND entry_id <> $3`
+	rows, err := r.db.Query(query, userID, model, excludeEntryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var embeddings []EntryEmbedding
+	for rows.Next() {
+		var embedding EntryEmbedding
+		var stored pq.Float32Array
+		if err := rows.Scan(&embedding.EntryID, &stored); err != nil {
+			return nil, err
+		}
+		embedding.Vector = stored
+		embeddings = append(embeddings, embedding)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return nearestByCosine(embeddings, vector, limit), nil
+}
+
+// nearestPGVector считает косинусное расстояние оператором pgvector <=>.
+// Размерность зависит от модели, поэтому колонка остаётся REAL[] без индекса и приводится к vector в запросе
+func (r *PostgresEntryEmbeddingsRepository) nearestPGVector(userID, model string, vector []float32, limit int, excludeEntryID string) ([]EntrySimilarity, error) {
+	query := `
+		SELECT entry_id, 1 - (embedding::vector <=> $3::real[]::vector)
+		FROM entry_embeddings
+		WHERE user_id = $1 AND model = $2 AND entry_id <> $4
+		ORDER BY embedding::vector <=> $3::real[]::vector
+		LIMIT $5`
+	rows, err := r.db.Query(query, userID, model, pq.Array(vector), excludeEntryID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []EntrySimilarity{}
+	for rows.Next() {
+		var similarity EntrySimilarity
+		if err := rows.Scan(&similarity.EntryID, &similarity.Score); err != nil {
+			return nil, err
+		}
+		result = append(result, similarity)
+	}
+	return result, rows.Err()
+}
+
+// RecordFailure засчитывает неудачную попытку, если текст записи не изменился
+func (r *PostgresEntryEmbeddingsRepository) RecordFailure(failure EntryEmbeddingFailure) error {
+	query := `
+		INSERT INTO entry_embedding_failures (entry_id, model, text_hash, attempts, error, failed_at)
+		SELECT id, $3::text, $4::text, 1, $5::text, $6::timestamptz
+		FROM entries
+		WHERE id = $1 AND user_id = $2 AND md5(raw_text) = $4::text
+		ON CONFLICT (entry_id, model)
+		DO UPDATE SET attempts = CASE WHEN entry_embedding_failures.text_hash = EXCLUDED.text_hash
+				THEN entry_embedding_failures.attempts + 1 ELSE 1 END,
+			text_hash = EXCLUDED.text_hash, error = EXCLUDED.error, failed_at = EXCLUDED.failed_at`
+	res, err := r.db.Exec(query, failure.EntryID, failure.UserID, failure.Model, failure.TextHash, failure.Error, failure.FailedAt)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// ListPending возвращает записи всех пользователей без актуального вектора модели:
+// записи с меньшим числом неудачных попыток первыми, при равенстве — старые
+func (r *PostgresEntryEmbeddingsRepository) ListPending(model string, maxAttempts, limit int) ([]Entry, error) {
+	query := `
+		SELECT ` + entryColumns + `
+		FROM entries
+		LEFT JOIN entry_embedding_failures f
+			ON f.entry_id = entries.id AND f.model = $1 AND f.text_hash = md5(entries.raw_text)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM entry_embeddings m
+			WHERE m.entry_id = entries.id AND m.model = $1 AND m.text_hash = md5(entries.raw_text)
+		) AND COALESCE(f.attempts, 0) < $2
+		ORDER BY COALESCE(f.attempts, 0), created_at
+		LIMIT $3`
+	rows, err := r.db.Query(query, model, maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// hasPGVector проверяет один раз за время жизни процесса, установлено ли расширение pgvector
+func (r *PostgresEntryEmbeddingsRepository) hasPGVector() bool {
+	r.pgvectorOnce.Do(func() {
+		err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'vector')`).Scan(&r.pgvector)
+		if err != nil {
+			log.Printf("failed to detect pgvector, falling back to in-process cosine similarity: %v", err)
+			r.pgvector = false
+		}
+	})
+	return r.pgvector
+}
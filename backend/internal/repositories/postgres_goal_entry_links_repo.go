@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// PostgresGoalEntryLinksRepository реализует GoalEntryLinksRepository с использованием PostgreSQL.
+// Удаление цели или записи каскадно удаляет связи на уровне внешних ключей.
+type PostgresGoalEntryLinksRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresGoalEntryLinksRepository создает новый экземпляр PostgresGoalEntryLinksRepository
+func NewPostgresGoalEntryLinksRepository(db *sql.DB) *PostgresGoalEntryLinksRepository {
+	return &PostgresGoalEntryLinksRepository{
+		db: db,
+	}
+}
+
+// Upsert создаёт или обновляет связь
+func (r *PostgresGoalEntryLinksRepository) Upsert(link GoalEntryLink) (GoalEntryLink, error) {
+	query := `
+		INSERT INTO goal_entry_links (id, user_id, goal_id, entry_id, relevance_score, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (goal_id, entry_id)
+		DO UPDATE SET relevance_score = EXCLUDED.relevance_score, note = EXCLUDED.note
+		WHERE goal_entry_links.user_id = EXCLUDED.user_id
+		RETURNING id, user_id, goal_id, entry_id, relevance_score, note, created_at`
+	var saved GoalEntryLink
+	err := r.db.QueryRow(query, link.ID, link.UserID, link.GoalID, link.EntryID, link.RelevanceScore, link.Note, link.CreatedAt).
+		Scan(&saved.ID, &saved.UserID, &saved.GoalID, &saved.EntryID, &saved.RelevanceScore, &saved.Note, &saved.CreatedAt)
+	// Конфликт со связью другого пользователя — строка не обновлена и не возвращена
+	if errors.Is(err, sql.ErrNoRows) {
+		return GoalEntryLink{}, ErrNotFound
+	}
+	if err != nil {
+		return GoalEntryLink{}, err
+	}
+	return saved, nil
+}
+
+// Delete удаляет связь цели и записи пользователя
+func (r *PostgresGoalEntryLinksRepository) Delete(userID, goalID, entryID string) error {
+	query := `DELETE FROM goal_entry_links WHERE goal_id = $1 AND entry_id = $2 AND user_id = $3`
+	res, err := r.db.Exec(query, goalID, entryID, userID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// ListByGoal возвращает связи цели пользователя, самые релевантные первыми
+func (r *PostgresGoalEntryLinksRepository) ListByGoal(userID, goalID string) ([]GoalEntryLink, error) {
+	query := `
+		SELECT id, user_id, goal_id, entry_id, relevance_score, note, created_at
+		FROM goal_entry_links
+		WHERE goal_id = $1 AND user_id = $2
+		ORDER BY relevance_score DESC, created_at`
+	rows, err := r.db.Query(query, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []GoalEntryLink{}
+	for rows.Next() {
+		var link GoalEntryLink
+		if err := rows.Scan(&link.ID, &link.UserID, &link.GoalID, &link.EntryID, &link.RelevanceScore, &link.Note, &link.CreatedAt); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// DeleteByGoalID удаляет все связи цели пользователя
+func (r *PostgresGoalEntryLinksRepository) DeleteByGoalID(userID, goalID string) error {
+	_, err := r.db.Exec(`DELETE FROM goal_entry_links WHERE goal_id = $1 AND user_id = $2`, goalID, userID)
+	return err
+}
+
+// DeleteByEntryID удаляет все связи записи пользователя
+func (r *PostgresGoalEntryLinksRepository) DeleteByEntryID(userID, entryID string) error {
+	_, err := r.db.Exec(`DELETE FROM goal_entry_links WHERE entry_id = $1 AND user_id = $2`, entryID, userID)
+	return err
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// PostgresGoalsRepository реализует GoalsRepository с использованием PostgreSQL
+type PostgresGoalsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresGoalsRepository создает новый экземпляр PostgresGoalsRepository
+func NewPostgresGoalsRepository(db *sql.DB) *PostgresGoalsRepository {
+	return &PostgresGoalsRepository{
+		db: db,
+	}
+}
+
+// Create добавляет новую цель
+func (r *PostgresGoalsRepository) Create(goal Goal) error {
+	query := `
+		INSERT INTO goals (id, user_id, title, description, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.db.Exec(query, goal.ID, goal.UserID, goal.Title, goal.Description, goal.Status, goal.CreatedAt, goal.UpdatedAt)
+	return err
+}
+
+// GetByID возвращает цель пользователя по ID
+func (r *PostgresGoalsRepository) GetByID(userID, id string) (Goal, error) {
+	query := `SELECT id, user_id, title, description, status, created_at, updated_at FROM goals WHERE id = $1 AND user_id = $2`
+	var goal Goal
+	err := r.db.QueryRow(query, id, userID).Scan(&goal.ID, &goal.UserID, &goal.Title, &goal.Description, &goal.Status, &goal.CreatedAt, &goal.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Goal{}, ErrNotFound
+	}
+	if err != nil {
+		return Goal{}, err
+	}
+	return goal, nil
+}
+
+// ListByUser возвращает цели пользователя в порядке создания
+func (r *PostgresGoalsRepository) ListByUser(userID string, status GoalStatus) ([]Goal, error) {
+	query := `
+		SELECT id, user_id, title, description, status, created_at, updated_at
+		FROM goals
+		WHERE user_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at`
+	rows, err := r.db.Query(query, userID, string(status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	goals := []Goal{}
+	for rows.Next() {
+		var goal Goal
+		err := rows.Scan(&goal.ID, &goal.UserID, &goal.Title, &goal.Description, &goal.Status, &goal.CreatedAt, &goal.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, goal)
+	}
+
+	return goals, rows.Err()
+}
+
+// Update обновляет название, описание и статус цели пользователя
+func (r *PostgresGoalsRepository) Update(userID string, goal Goal) error {
+	query := `UPDATE goals SET title = $1, description = $2, status = $3, updated_at = $4 WHERE id = $5 AND user_id = $6`
+	res, err := r.db.Exec(query, goal.Title, goal.Description, goal.Status, goal.UpdatedAt, goal.ID, userID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// DeleteByID удаляет цель пользователя по ID
+func (r *PostgresGoalsRepository) DeleteByID(userID, id string) error {
+	query := `DELETE FROM goals WHERE id = $1 AND user_id = $2`
+	res, err := r.db.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// requireAffected возвращает ErrNotFound, если запрос не затронул ни одной строки
+func requireAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+)
+
+// PostgresLocalSummariesRepository реализует LocalSummariesRepository с использованием PostgreSQL
+type PostgresLocalSummariesRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLocalSummariesRepository создает новый экземпляр PostgresLocalSummariesRepository
+func NewPostgresLocalSummariesRepository(db *sql.DB) *PostgresLocalSummariesRepository {
+	return &PostgresLocalSummariesRepository{
+		db: db,
+	}
+}
+
+// Get возвращает саммари периода пользователя
+func (r *PostgresLocalSummariesRepository) Get(userID, periodStart, periodEnd string) (LocalSummary, error) {
+	query := `
+		SELECT entries_hash, bullets, candidate_goals, created_at
+		FROM local_summaries
+		WHERE user_id = $1 AND period_start = $2 AND period_end = $3`
+
+	// Границы периода берём из аргументов: DATE драйвер отдаёт как timestamp
+	summary := LocalSummary{UserID: userID, PeriodStart: periodStart, PeriodEnd: periodEnd}
+	var bullets, candidateGoals []byte
+	err := r.db.QueryRow(query, userID, periodStart, periodEnd).Scan(&summary.EntriesHash, &bullets, &candidateGoals, &summary.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return LocalSummary{}, ErrNotFound
+	}
+	if err != nil {
+		return LocalSummary{}, err
+	}
+
+	if err := json.Unmarshal(bullets, &summary.Bullets); err != nil {
+		return LocalSummary{}, err
+	}
+	if err := json.Unmarshal(candidateGoals, &summary.CandidateGoals); err != nil {
+		return LocalSummary{}, err
+	}
+	return summary, nil
+}
+
+// Upsert сохраняет саммари периода пользователя, заменяя прежнее
+func (r *PostgresLocalSummariesRepository) Upsert(summary LocalSummary) error {
+	bullets, err := json.Marshal(summary.Bullets)
+	if err != nil {
+		return err
+	}
+	candidateGoals, err := json.Marshal(nonNilStrings(summary.CandidateGoals))
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO local_summaries (user_id, period_start, period_end, entries_hash, bullets, candidate_goals, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, period_start, period_end)
+		DO UPDATE SET entries_hash = EXCLUDED.entries_hash, bullets = EXCLUDED.bullets,
+			candidate_goals = EXCLUDED.candidate_goals, created_at = EXCLUDED.created_at`
+	_, err = r.db.Exec(query, summary.UserID, summary.PeriodStart, summary.PeriodEnd, summary.EntriesHash,
+		bullets, candidateGoals, summary.CreatedAt)
+	return err
+}
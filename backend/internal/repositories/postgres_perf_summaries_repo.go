@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// PostgresPerfSummaryRepository реализует PerfSummaryRepository с использованием PostgreSQL
+type PostgresPerfSummaryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPerfSummaryRepository создает новый экземпляр PostgresPerfSummaryRepository
+func NewPostgresPerfSummaryRepository(db *sql.DB) *PostgresPerfSummaryRepository {
+	return &PostgresPerfSummaryRepository{
+		db: db,
+	}
+}
+
+// Create сохраняет перф-саммари. Цели хранятся в колонке bullets в виде JSON
+func (r *PostgresPerfSummaryRepository) Create(summary PerfSummary) error {
+	rawGoals, bullets, tags, err := marshalPerfSummary(summary)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO perf_summaries (id, user_id, period_start, period_end, raw_goals, summary_text, bullets, tags, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err = r.db.Exec(query, summary.ID, summary.UserID, summary.PeriodStart, summary.PeriodEnd,
+		rawGoals, summary.SummaryText, bullets, tags, summary.CreatedAt, summary.UpdatedAt)
+	return err
+}
+
+// GetByID возвращает перф-саммари пользователя по ID
+func (r *PostgresPerfSummaryRepository) GetByID(userID, id string) (PerfSummary, error) {
+	query := `
+		SELECT id, user_id, period_start, period_end, raw_goals, summary_text, bullets, tags, created_at, updated_at
+		FROM perf_summaries WHERE id = $1 AND user_id = $2`
+	summary, err := scanPerfSummary(r.db.QueryRow(query, id, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return PerfSummary{}, ErrNotFound
+	}
+	return summary, err
+}
+
+// ListByUser возвращает историю перф-саммари пользователя, новые первыми
+func (r *PostgresPerfSummaryRepository) ListByUser(userID string) ([]PerfSummary, error) {
+	query := `
+		SELECT id, user_id, period_start, period_end, raw_goals, summary_text, bullets, tags, created_at, updated_at
+		FROM perf_summaries WHERE user_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := []PerfSummary{}
+	for rows.Next() {
+		summary, err := scanPerfSummary(rows)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, rows.Err()
+}
+
+// Update заменяет перф-саммари пользователя одним UPDATE: саммари не пропадает, даже если запись прервётся
+func (r *PostgresPerfSummaryRepository) Update(summary PerfSummary) error {
+	rawGoals, bullets, tags, err := marshalPerfSummary(summary)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE perf_summaries
+		SET period_start = $3, period_end = $4, raw_goals = $5, summary_text = $6, bullets = $7, tags = $8, created_at = $9, updated_at = $10
+		WHERE id = $1 AND user_id = $2`
+	res, err := r.db.Exec(query, summary.ID, summary.UserID, summary.PeriodStart, summary.PeriodEnd,
+		rawGoals, summary.SummaryText, bullets, tags, summary.CreatedAt, summary.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// DeleteByID удаляет перф-саммари пользователя по ID
+func (r *PostgresPerfSummaryRepository) DeleteByID(userID, id string) error {
+	query := `DELETE FROM perf_summaries WHERE id = $1 AND user_id = $2`
+	res, err := r.db.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// marshalPerfSummary кодирует JSON-колонки перф-саммари
+func marshalPerfSummary(summary PerfSummary) (rawGoals, bullets, tags []byte, err error) {
+	if rawGoals, err = json.Marshal(nonNilStrings(summary.RawGoals)); err != nil {
+		return nil, nil, nil, err
+	}
+	if bullets, err = json.Marshal(summary.Goals); err != nil {
+		return nil, nil, nil, err
+	}
+	if tags, err = json.Marshal(nonNilStrings(summary.Tags)); err != nil {
+		return nil, nil, nil, err
+	}
+	return rawGoals, bullets, tags, nil
+}
+
+// rowScanner объединяет *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPerfSummary читает одну строку perf_summaries
+func scanPerfSummary(row rowScanner) (PerfSummary, error) {
+	var summary PerfSummary
+	var periodStart, periodEnd time.Time
+	var rawGoals, bullets, tags []byte
+	err := row.Scan(&summary.ID, &summary.UserID, &periodStart, &periodEnd,
+		&rawGoals, &summary.SummaryText, &bullets, &tags, &summary.CreatedAt, &summary.UpdatedAt)
+	if err != nil {
+		return PerfSummary{}, err
+	}
+
+	summary.PeriodStart = periodStart.Format("2006-01-02")
+	summary.PeriodEnd = periodEnd.Format("2006-01-02")
+	if err := json.Unmarshal(rawGoals, &summary.RawGoals); err != nil {
+		return PerfSummary{}, err
+	}
+	if err := json.Unmarshal(bullets, &summary.Goals); err != nil {
+		return PerfSummary{}, err
+	}
+	if err := json.Unmarshal(tags, &summary.Tags); err != nil {
+		return PerfSummary{}, err
+	}
+	return summary, nil
+}
+
+// nonNilStrings заменяет nil на пустой срез, чтобы в JSON писался [], а не null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
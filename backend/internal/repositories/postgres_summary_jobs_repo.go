@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// summaryJobColumns — список колонок summary_jobs в порядке scanSummaryJob
+const summaryJobColumns = `id, user_id, period_start, period_end, role, tags, status, progress, attempts, result_summary_id, error, created_at, updated_at, claimed_at, lease_expires_at`
+
+// PostgresSummaryJobsRepository реализует SummaryJobsRepository с использованием PostgreSQL.
+// Задачи хранятся в таблице summary_jobs и переживают перезапуск сервера.
+type PostgresSummaryJobsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSummaryJobsRepository создает новый экземпляр PostgresSummaryJobsRepository
+func NewPostgresSummaryJobsRepository(db *sql.DB) *PostgresSummaryJobsRepository {
+	return &PostgresSummaryJobsRepository{
+		db: db,
+	}
+}
+
+// Create добавляет задачу в очередь
+func (r *PostgresSummaryJobsRepository) Create(job SummaryJob) error {
+	tags, err := json.Marshal(nonNilStrings(job.Tags))
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO summary_jobs (` + summaryJobColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	_, err = r.db.Exec(query, job.ID, job.UserID, job.PeriodStart, job.PeriodEnd, job.Role, tags, job.Status,
+		job.Progress, job.Attempts, job.ResultSummaryID, job.Error, job.CreatedAt, job.UpdatedAt, job.ClaimedAt, job.LeaseExpiresAt)
+	return err
+}
+
+// GetByID возвращает задачу пользователя по ID
+func (r *PostgresSummaryJobsRepository) GetByID(userID, id string) (SummaryJob, error) {
+	query := `SELECT ` + summaryJobColumns + ` FROM summary_jobs WHERE id = $1 AND user_id = $2`
+	job, err := scanSummaryJob(r.db.QueryRow(query, id, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return SummaryJob{}, ErrNotFound
+	}
+	return job, err
+}
+
+// ClaimNext забирает самую старую задачу из очереди или с истёкшей арендой.
+// Сначала переводит в failed задачи, исчерпавшие maxAttempts; claimed_at при этом сбрасывается,
+// чтобы воркер, который, возможно, ещё выполняет такую задачу, не перезаписал её статус.
+// FOR UPDATE SKIP LOCKED позволяет нескольким воркерам (и нескольким инстансам) не мешать друг другу.
+// Время обрезается до микросекунд, как его хранит Postgres: claimed_at потом сравнивается на равенство
+func (r *PostgresSummaryJobsRepository) ClaimNext(lease time.Duration, maxAttempts int) (SummaryJob, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	failQuery := `
+		UPDATE summary_jobs
+		SET status = 'failed', error = $2, updated_at = $1, claimed_at = NULL, lease_expires_at = NULL
+		WHERE attempts >= $3
+			AND (status = 'queued' OR (status = 'running' AND (lease_expires_at IS NULL OR lease_expires_at <= $1)))`
+	if _, err := r.db.Exec(failQuery, now, summaryJobAttemptsExhausted(maxAttempts), maxAttempts); err != nil {
+		return SummaryJob{}, err
+	}
+
+	query := `
+		UPDATE summary_jobs
+		SET status = 'running', progress = 0, attempts = attempts + 1, updated_at = $1, claimed_at = $1, lease_expires_at = $2
+		WHERE id = (
+			SELECT id FROM summary_jobs
+			WHERE attempts < $3
+				AND (status = 'queued' OR (status = 'running' AND (lease_expires_at IS NULL OR lease_expires_at <= $1)))
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING ` + summaryJobColumns
+	job, err := scanSummaryJob(r.db.QueryRow(query, now, now.Add(lease), maxAttempts))
+	if errors.Is(err, sql.ErrNoRows) {
+		return SummaryJob{}, ErrNotFound
+	}
+	return job, err
+}
+
+// ExtendLease продлевает аренду задачи, если она всё ещё захвачена тем же ClaimNext
+func (r *PostgresSummaryJobsRepository) ExtendLease(job SummaryJob, lease time.Duration) error {
+	query := `
+		UPDATE summary_jobs SET lease_expires_at = $1
+		WHERE id = $2 AND status = 'running' AND claimed_at = $3`
+	res, err := r.db.Exec(query, time.Now().UTC().Add(lease), job.ID, job.ClaimedAt)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// Update сохраняет статус, прогресс, число попыток и результат задачи, если она всё ещё захвачена тем же ClaimNext
+func (r *PostgresSummaryJobsRepository) Update(job SummaryJob) error {
+	query := `
+		UPDATE summary_jobs
+		SET status = $1, progress = $2, attempts = $3, result_summary_id = $4, error = $5, updated_at = $6
+		WHERE id = $7 AND user_id = $8 AND claimed_at IS NOT DISTINCT FROM $9`
+	res, err := r.db.Exec(query, job.Status, job.Progress, job.Attempts, job.ResultSummaryID, job.Error, job.UpdatedAt, job.ID, job.UserID, job.ClaimedAt)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// scanSummaryJob читает одну строку summary_jobs
+func scanSummaryJob(row rowScanner) (SummaryJob, error) {
+	var job SummaryJob
+	var periodStart, periodEnd time.Time
+	var tags []byte
+	var claimedAt, leaseExpiresAt sql.NullTime
+	err := row.Scan(&job.ID, &job.UserID, &periodStart, &periodEnd, &job.Role, &tags, &job.Status, &job.Progress,
+		&job.Attempts, &job.ResultSummaryID, &job.Error, &job.CreatedAt, &job.UpdatedAt, &claimedAt, &leaseExpiresAt)
+	if err != nil {
+		return SummaryJob{}, err
+	}
+	if claimedAt.Valid {
+		job.ClaimedAt = &claimedAt.Time
+	}
+	if leaseExpiresAt.Valid {
+		job.LeaseExpiresAt = &leaseExpiresAt.Time
+	}
+	job.PeriodStart = periodStart.Format("2006-01-02")
+	job.PeriodEnd = periodEnd.Format("2006-01-02")
+	if err := json.Unmarshal(tags, &job.Tags); err != nil {
+		return SummaryJob{}, err
+	}
+	return job, nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresTagsRepository реализует TagsRepository с использованием PostgreSQL.
+// Теги хранятся в таблице tags, их связи с записями — в entry_tags
+type PostgresTagsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTagsRepository создает новый экземпляр PostgresTagsRepository
+func NewPostgresTagsRepository(db *sql.DB) *PostgresTagsRepository {
+	return &PostgresTagsRepository{
+		db: db,
+	}
+}
+
+// SetEntryTags заменяет набор тегов записи пользователя в одной транзакции
+func (r *PostgresTagsRepository) SetEntryTags(userID, entryID string, tags []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM entry_tags WHERE entry_id = $1 AND user_id = $2`, entryID, userID); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for i, name := range tags {
+		// DO UPDATE нужен, чтобы RETURNING вернул id уже существующего тега
+		var tagID string
+		err := tx.QueryRow(`
+			INSERT INTO tags (id, user_id, name, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id`,
+			now.Format("20060102150405.000000000")+"-"+strconv.Itoa(i), userID, name, now).Scan(&tagID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+			INSERT INTO entry_tags (entry_id, tag_id, user_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`, entryID, tagID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListByEntries возвращает теги переданных записей пользователя
+func (r *PostgresTagsRepository) ListByEntries(userID string, entryIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string, len(entryIDs))
+	if len(entryIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT et.entry_id, t.name
+		FROM entry_tags et
+		JOIN tags t ON t.id = et.tag_id
+		WHERE et.user_id = $1 AND et.entry_id = ANY($2)
+		ORDER BY t.name`
+	rows, err := r.db.Query(query, userID, pq.Array(entryIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entryID, name string
+		if err := rows.Scan(&entryID, &name); err != nil {
+			return nil, err
+		}
+		result[entryID] = append(result[entryID], name)
+	}
+
+	return result, rows.Err()
+}
+
+// ListCounts возвращает используемые теги пользователя с числом записей
+func (r *PostgresTagsRepository) ListCounts(userID string) ([]TagCount, error) {
+	query := `
+		SELECT t.name, COUNT(*)
+		FROM tags t
+		JOIN entry_tags et ON et.tag_id = t.id
+		WHERE t.user_id = $1
+		GROUP BY t.name
+		ORDER BY COUNT(*) DESC, t.name`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []TagCount{}
+	for rows.Next() {
+		var tc TagCount
+		if err := rows.Scan(&tc.Name, &tc.Count); err != nil {
+			return nil, err
+		}
+		result = append(result, tc)
+	}
+
+	return result, rows.Err()
+}
+
+// DeleteByEntryID снимает все теги с записи пользователя
+func (r *PostgresTagsRepository) DeleteByEntryID(userID, entryID string) error {
+	_, err := r.db.Exec(`DELETE FROM entry_tags WHERE entry_id = $1 AND user_id = $2`, entryID, userID)
+	return err
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// PostgresUsersRepository реализует UsersRepository с использованием PostgreSQL
+type PostgresUsersRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUsersRepository создает новый экземпляр PostgresUsersRepository
+func NewPostgresUsersRepository(db *sql.DB) *PostgresUsersRepository {
+	return &PostgresUsersRepository{
+		db: db,
+	}
+}
+
+// Create добавляет нового пользователя
+func (r *PostgresUsersRepository) Create(user User) error {
+	query := `
+		INSERT INTO users (id, name, role, perf_cycle_length_months, locale, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.db.Exec(query, user.ID, user.Name, user.Role, user.PerfCycleLengthMonths, user.Locale, user.CreatedAt, user.UpdatedAt)
+	return err
+}
+
+// GetByID возвращает пользователя по ID
+func (r *PostgresUsersRepository) GetByID(id string) (User, error) {
+	query := `SELECT id, name, role, perf_cycle_length_months, locale, created_at, updated_at FROM users WHERE id = $1`
+	var user User
+	err := r.db.QueryRow(query, id).Scan(&user.ID, &user.Name, &user.Role, &user.PerfCycleLengthMonths, &user.Locale, &user.CreatedAt, &user.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+// Update обновляет профиль пользователя
+func (r *PostgresUsersRepository) Update(user User) error {
+	query := `UPDATE users SET name = $1, role = $2, perf_cycle_length_months = $3, locale = $4, updated_at = $5 WHERE id = $6`
+	res, err := r.db.Exec(query, user.Name, user.Role, user.PerfCycleLengthMonths, user.Locale, user.UpdatedAt, user.ID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// DeleteByID удаляет пользователя по ID
+func (r *PostgresUsersRepository) DeleteByID(id string) error {
+	res, err := r.db.Exec(`DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
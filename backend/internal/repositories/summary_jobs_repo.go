@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type SummaryJobStatus string
+
+const (
+	SummaryJobStatusQueued    SummaryJobStatus = "queued"
+	SummaryJobStatusRunning   SummaryJobStatus = "running"
+	SummaryJobStatusSucceeded SummaryJobStatus = "succeeded"
+	SummaryJobStatusFailed    SummaryJobStatus = "failed"
+)
+
+type SummaryJob struct {
+	ID              string           `json:"id"`
+	UserID          string           `json:"user_id"`
+	PeriodStart     string           `json:"period_start"`
+	PeriodEnd       string           `json:"period_end"`
+	Role            string           `json:"role"`
+	Tags            []string         `json:"tags"`
+	Status          SummaryJobStatus `json:"status"`
+	Progress        int              `json:"progress"`
+	Attempts        int              `json:"attempts"`
+	ResultSummaryID string           `json:"result_summary_id"`
+	Error           string           `json:"error"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	// ClaimedAt — когда воркер забрал задачу; вместе с ID определяет, чья это аренда.
+	// LeaseExpiresAt — до какого момента воркер держит задачу. У незахваченной задачи оба пустые
+	ClaimedAt      *time.Time `json:"-"`
+	LeaseExpiresAt *time.Time `json:"-"`
+}
+
+// SummaryJobsRepository определяет интерфейс очереди задач генерации перф-саммари
+type SummaryJobsRepository interface {
+	Create(job SummaryJob) error
+	// GetByID возвращает задачу пользователя; чужая задача — ErrNotFound
+	GetByID(userID, id string) (SummaryJob, error)
+	// ClaimNext атомарно забирает самую старую задачу в статусе queued или running с истёкшей арендой
+	// (воркер, который её выполнял, упал или потерял связь с БД), переводит её в running и выдаёт аренду на lease.
+	// Задачу, которую уже забирали maxAttempts раз, не забирает, а переводит в failed: скорее всего,
+	// на ней и падает воркер. Если задач для захвата нет, возвращает ErrNotFound.
+	// ClaimNext — служебный метод воркеров и обходит задачи всех пользователей
+	ClaimNext(lease time.Duration, maxAttempts int) (SummaryJob, error)
+	// ExtendLease продлевает аренду задачи на lease от текущего момента.
+	// Если задачу уже забрал другой воркер или она завершена, возвращает ErrNotFound
+	ExtendLease(job SummaryJob, lease time.Duration) error
+	// Update сохраняет задачу владельца job.UserID, если она всё ещё захвачена тем же ClaimNext (job.ClaimedAt);
+	// иначе возвращает ErrNotFound
+	Update(job SummaryJob) error
+}
+
+// InMemorySummaryJobsRepository реализует SummaryJobsRepository с использованием in-memory хранилища
+type InMemorySummaryJobsRepository struct {
+	mu   sync.Mutex
+	jobs map[string]SummaryJob
+}
+
+// NewInMemorySummaryJobsRepository создает новый экземпляр InMemorySummaryJobsRepository
+func NewInMemorySummaryJobsRepository() *InMemorySummaryJobsRepository {
+	return &InMemorySummaryJobsRepository{
+		jobs: make(map[string]SummaryJob),
+	}
+}
+
+// Create добавляет задачу в очередь
+func (r *InMemorySummaryJobsRepository) Create(job SummaryJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.ID] = job
+	return nil
+}
+
+// GetByID возвращает задачу пользователя по ID
+func (r *InMemorySummaryJobsRepository) GetByID(userID, id string) (SummaryJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok || job.UserID != userID {
+		return SummaryJob{}, ErrNotFound
+	}
+	return job, nil
+}
+
+// ClaimNext забирает самую старую задачу из очереди или с истёкшей арендой; исчерпавшие попытки задачи переводит в failed
+func (r *InMemorySummaryJobsRepository) ClaimNext(lease time.Duration, maxAttempts int) (SummaryJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	var queued []SummaryJob
+	for _, j := range r.jobs {
+		if j.Status != SummaryJobStatusQueued && (j.Status != SummaryJobStatusRunning || (j.LeaseExpiresAt != nil && j.LeaseExpiresAt.After(now))) {
+			continue
+		}
+		if j.Attempts >= maxAttempts {
+			j.Status = SummaryJobStatusFailed
+			j.Error = summaryJobAttemptsExhausted(maxAttempts)
+			j.UpdatedAt = now
+			j.ClaimedAt = nil
+			j.LeaseExpiresAt = nil
+			r.jobs[j.ID] = j
+			continue
+		}
+		queued = append(queued, j)
+	}
+	if len(queued) == 0 {
+		return SummaryJob{}, ErrNotFound
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		return queued[i].CreatedAt.Before(queued[j].CreatedAt)
+	})
+
+	job := queued[0]
+	job.Status = SummaryJobStatusRunning
+	job.Progress = 0
+	job.Attempts++
+	job.UpdatedAt = now
+	expires := now.Add(lease)
+	job.ClaimedAt = &now
+	job.LeaseExpiresAt = &expires
+	r.jobs[job.ID] = job
+	return job, nil
+}
+
+// ExtendLease продлевает аренду задачи, если она всё ещё захвачена тем же ClaimNext
+func (r *InMemorySummaryJobsRepository) ExtendLease(job SummaryJob, lease time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.jobs[job.ID]
+	if !ok || existing.Status != SummaryJobStatusRunning || !sameClaim(existing.ClaimedAt, job.ClaimedAt) {
+		return ErrNotFound
+	}
+	expires := time.Now().UTC().Add(lease)
+	existing.LeaseExpiresAt = &expires
+	r.jobs[job.ID] = existing
+	return nil
+}
+
+// Update сохраняет статус, прогресс и результат задачи
+func (r *InMemorySummaryJobsRepository) Update(job SummaryJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.jobs[job.ID]
+	if !ok || existing.UserID != job.UserID || !sameClaim(existing.ClaimedAt, job.ClaimedAt) {
+		return ErrNotFound
+	}
+	// Срок аренды продлевает только ExtendLease
+	job.LeaseExpiresAt = existing.LeaseExpiresAt
+	r.jobs[job.ID] = job
+	return nil
+}
+
+// sameClaim сообщает, относятся ли два значения ClaimedAt к одному захвату задачи
+func sameClaim(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// summaryJobAttemptsExhausted возвращает ошибку задачи, которую забирали maxAttempts раз и ни разу не завершили
+func summaryJobAttemptsExhausted(maxAttempts int) string {
+	return fmt.Sprintf("gave up after %d attempts: the worker stopped before finishing the job", maxAttempts)
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInMemorySummaryJobsClaimNext(t *testing.T) {
+	const maxAttempts = 3
+	now := time.Now().UTC()
+	expired, active := now.Add(-time.Second), now.Add(time.Hour)
+
+	cases := []struct {
+		name         string
+		status       SummaryJobStatus
+		attempts     int
+		lease        *time.Time
+		wantClaimed  bool
+		wantStatus   SummaryJobStatus
+		wantAttempts int
+	}{
+		{name: "new job", status: SummaryJobStatusQueued, wantClaimed: true, wantStatus: SummaryJobStatusRunning, wantAttempts: 1},
+		{name: "last attempt", status: SummaryJobStatusQueued, attempts: 2, wantClaimed: true, wantStatus: SummaryJobStatusRunning, wantAttempts: 3},
+		{name: "expired lease", status: SummaryJobStatusRunning, attempts: 1, lease: &expired, wantClaimed: true, wantStatus: SummaryJobStatusRunning, wantAttempts: 2},
+		{name: "lease from before leases", status: SummaryJobStatusRunning, attempts: 1, wantClaimed: true, wantStatus: SummaryJobStatusRunning, wantAttempts: 2},
+		{name: "active lease", status: SummaryJobStatusRunning, attempts: 1, lease: &active, wantStatus: SummaryJobStatusRunning, wantAttempts: 1},
+		{name: "expired lease without attempts left", status: SummaryJobStatusRunning, attempts: 3, lease: &expired, wantStatus: SummaryJobStatusFailed, wantAttempts: 3},
+		{name: "queued without attempts left", status: SummaryJobStatusQueued, attempts: 3, wantStatus: SummaryJobStatusFailed, wantAttempts: 3},
+		{name: "active lease without attempts left", status: SummaryJobStatusRunning, attempts: 3, lease: &active, wantStatus: SummaryJobStatusRunning, wantAttempts: 3},
+		{name: "finished job", status: SummaryJobStatusSucceeded, attempts: 5, wantStatus: SummaryJobStatusSucceeded, wantAttempts: 5},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewInMemorySummaryJobsRepository()
+			job := SummaryJob{ID: "j1", UserID: "u1", Status: tc.status, Attempts: tc.attempts, LeaseExpiresAt: tc.lease, CreatedAt: now, UpdatedAt: now}
+			if tc.status == SummaryJobStatusRunning {
+				claimedAt := now.Add(-time.Minute)
+				job.ClaimedAt = &claimedAt
+			}
+			if err := repo.Create(job); err != nil {
+				t.Fatal(err)
+			}
+
+			claimed, err := repo.ClaimNext(time.Minute, maxAttempts)
+			if tc.wantClaimed != (err == nil) {
+				t.Fatalf("ClaimNext = %+v, %v; want claimed %v", claimed, err, tc.wantClaimed)
+			}
+			if !tc.wantClaimed && !errors.Is(err, ErrNotFound) {
+				t.Fatalf("ClaimNext error = %v, want ErrNotFound", err)
+			}
+
+			got, err := repo.GetByID("u1", "j1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tc.wantStatus || got.Attempts != tc.wantAttempts {
+				t.Fatalf("job = %s after %d attempts, want %s after %d", got.Status, got.Attempts, tc.wantStatus, tc.wantAttempts)
+			}
+			if tc.wantStatus == SummaryJobStatusFailed {
+				if got.Error == "" || got.ClaimedAt != nil {
+					t.Fatalf("failed job = %+v, want an error and no claim", got)
+				}
+			}
+			// Воркер, который мог ещё выполнять задачу, больше не может её записать
+			if tc.wantStatus == SummaryJobStatusFailed && job.ClaimedAt != nil {
+				job.Status = SummaryJobStatusSucceeded
+				if err := repo.Update(job); !errors.Is(err, ErrNotFound) {
+					t.Fatalf("Update by the old claim = %v, want ErrNotFound", err)
+				}
+			}
+		})
+	}
+}
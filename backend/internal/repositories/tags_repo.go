@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+)
+
+// TagCount представляет тег пользователя с числом записей, на которых он стоит
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TagsRepository определяет интерфейс для работы с тегами записей.
+// Все методы ограничены владельцем: теги другого пользователя для них не существуют
+type TagsRepository interface {
+	// SetEntryTags заменяет набор тегов записи; недостающие теги создаются
+	SetEntryTags(userID, entryID string, tags []string) error
+	// ListByEntries возвращает теги переданных записей, отсортированные по имени
+	ListByEntries(userID string, entryIDs []string) (map[string][]string, error)
+	// ListCounts возвращает используемые теги, самые частые первыми
+	ListCounts(userID string) ([]TagCount, error)
+	DeleteByEntryID(userID, entryID string) error
+}
+
+// InMemoryTagsRepository реализует TagsRepository с использованием in-memory хранилища
+type InMemoryTagsRepository struct {
+	mu sync.RWMutex
+	// entryTags хранит теги записей: user_id -> entry_id -> теги
+	entryTags map[string]map[string][]string
+}
+
+// NewInMemoryTagsRepository создает новый экземпляр InMemoryTagsRepository
+func NewInMemoryTagsRepository() *InMemoryTagsRepository {
+	return &InMemoryTagsRepository{
+		entryTags: make(map[string]map[string][]string),
+	}
+}
+
+// SetEntryTags заменяет набор тегов записи пользователя
+func (r *InMemoryTagsRepository) SetEntryTags(userID, entryID string, tags []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(tags) == 0 {
+		delete(r.entryTags[userID], entryID)
+		return nil
+	}
+	if _, ok := r.entryTags[userID]; !ok {
+		r.entryTags[userID] = make(map[string][]string)
+	}
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+	r.entryTags[userID][entryID] = sorted
+	return nil
+}
+
+// ListByEntries возвращает теги переданных записей пользователя
+func (r *InMemoryTagsRepository) ListByEntries(userID string, entryIDs []string) (map[string][]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string][]string, len(entryIDs))
+	for _, id := range entryIDs {
+		if tags, ok := r.entryTags[userID][id]; ok {
+			result[id] = append([]string(nil), tags...)
+		}
+	}
+	return result, nil
+}
+
+// ListCounts возвращает используемые теги пользователя с числом записей
+func (r *InMemoryTagsRepository) ListCounts(userID string) ([]TagCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, tags := range r.entryTags[userID] {
+		for _, t := range tags {
+			counts[t]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, TagCount{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// DeleteByEntryID снимает все теги с записи пользователя
+func (r *InMemoryTagsRepository) DeleteByEntryID(userID, entryID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entryTags[userID], entryID)
+	return nil
+}
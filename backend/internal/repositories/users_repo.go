@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+)
+
+type UserRole string
+
+const (
+	UserRoleEngineer UserRole = "engineer"
+	UserRoleLead     UserRole = "lead"
+	UserRoleManager  UserRole = "manager"
+	UserRoleMixed    UserRole = "mixed"
+)
+
+type User struct {
+	ID                    string    `json:"id"`
+	Name                  string    `json:"name"`
+	Role                  UserRole  `json:"role"`
+	PerfCycleLengthMonths int       `json:"perf_cycle_length_months"`
+	Locale                string    `json:"locale"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// UsersRepository определяет интерфейс для работы с профилями пользователей
+type UsersRepository interface {
+	Create(user User) error
+	GetByID(id string) (User, error)
+	Update(user User) error
+	DeleteByID(id string) error
+}
+
+// InMemoryUsersRepository реализует UsersRepository с использованием in-memory хранилища
+type InMemoryUsersRepository struct {
+	mu    sync.RWMutex
+	users map[string]User
+}
+
+// NewInMemoryUsersRepository создает новый экземпляр InMemoryUsersRepository
+func NewInMemoryUsersRepository() *InMemoryUsersRepository {
+	return &InMemoryUsersRepository{
+		users: make(map[string]User),
+	}
+}
+
+// Create добавляет нового пользователя
+func (r *InMemoryUsersRepository) Create(user User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[user.ID] = user
+	return nil
+}
+
+// GetByID возвращает пользователя по ID
+func (r *InMemoryUsersRepository) GetByID(id string) (User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return user, nil
+}
+
+// Update обновляет профиль пользователя
+func (r *InMemoryUsersRepository) Update(user User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.ID]; !ok {
+		return ErrNotFound
+	}
+	r.users[user.ID] = user
+	return nil
+}
+
+// DeleteByID удаляет пользователя по ID
+func (r *InMemoryUsersRepository) DeleteByID(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.users, id)
+	return nil
+}
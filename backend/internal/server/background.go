@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/jobs"
+)
+
+// Background объединяет фоновые процессы, которые живут вместе с HTTP-сервером.
+// main запускает их после старта сервера и останавливает в рамках graceful shutdown.
+type Background struct {
+	summaryWorkers *jobs.WorkerPool
+	// entryWorkers обрабатывают записи в фоне; отключённые в конфигурации сюда не попадают
+	entryWorkers []*jobs.BatchWorker
+}
+
+// Start запускает фоновые процессы
+func (b *Background) Start() {
+	b.summaryWorkers.Start()
+	for _, w := range b.entryWorkers {
+		w.Start()
+	}
+}
+
+// Shutdown останавливает фоновые процессы, дожидаясь текущих задач в пределах ctx
+func (b *Background) Shutdown(ctx context.Context) error {
+	errs := []error{b.summaryWorkers.Shutdown(ctx)}
+	for _, w := range b.entryWorkers {
+		errs = append(errs, w.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
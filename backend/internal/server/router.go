@@ -1,24 +1,37 @@
 package server
 
 import (
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
 
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
 	"github.com/inkuroshev/perf-assist-backend/internal/config"
+	"github.com/inkuroshev/perf-assist-backend/internal/dailynotes"
+	"github.com/inkuroshev/perf-assist-backend/internal/handlers/archive"
+	authhandlers "github.com/inkuroshev/perf-assist-backend/internal/handlers/auth"
 	"github.com/inkuroshev/perf-assist-backend/internal/handlers/entries"
+	"github.com/inkuroshev/perf-assist-backend/internal/handlers/goals"
 	"github.com/inkuroshev/perf-assist-backend/internal/handlers/health"
 	perfhandlers "github.com/inkuroshev/perf-assist-backend/internal/handlers/perf"
+	"github.com/inkuroshev/perf-assist-backend/internal/handlers/tokens"
+	"github.com/inkuroshev/perf-assist-backend/internal/handlers/users"
+	"github.com/inkuroshev/perf-assist-backend/internal/jobs"
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
 	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
 	"github.com/inkuroshev/perf-assist-backend/internal/usecases"
 )
 
 // NewRouter создаёт и настраивает Gin-роутер.
 // Здесь подключаются глобальные middleware и регистрируются все HTTP-ручки.
-func NewRouter(cfg *config.Config) *gin.Engine {
+// Вместе с роутером возвращаются фоновые процессы, которые main запускает и останавливает сам.
+func NewRouter(cfg *config.Config) (*gin.Engine, *Background) {
 	r := gin.New()
 
 	// базовые middleware
@@ -38,30 +51,219 @@ func NewRouter(cfg *config.Config) *gin.Engine {
 	// Создание репозиториев
 	// Используем PostgreSQL репозиторий
 	entriesRepo := repositories.NewPostgresEntriesRepository(db)
+	perfSummaryRepo := repositories.NewPostgresPerfSummaryRepository(db)
+	goalsRepo := repositories.NewPostgresGoalsRepository(db)
+	goalEntryLinksRepo := repositories.NewPostgresGoalEntryLinksRepository(db)
+	summaryJobsRepo := repositories.NewPostgresSummaryJobsRepository(db)
+	usersRepo := repositories.NewPostgresUsersRepository(db)
+	credentialsRepo := repositories.NewPostgresCredentialsRepository(db)
+	apiTokensRepo := repositories.NewPostgresAPITokensRepository(db)
+	tagsRepo := repositories.NewPostgresTagsRepository(db)
+	entryEmbeddingsRepo := repositories.NewPostgresEntryEmbeddingsRepository(db)
+	localSummariesRepo := repositories.NewPostgresLocalSummariesRepository(db)
+	dailyNotesSyncRepo := repositories.NewPostgresDailyNotesSyncRepository(db)
+
+	// Подписанные сессионные токены
+	sessionTokens := auth.NewSessionTokens(sessionSecret(cfg), time.Duration(cfg.AuthSessionTTLHours)*time.Hour)
+	if cfg.AuthDevUserID != "" {
+		log.Printf("WARNING: AUTH_DEV_USER_ID is set, requests without a token act as %q", cfg.AuthDevUserID)
+	}
+
+	// Создание LLM-провайдера (fake, openai или ollama — см. LLM_PROVIDER)
+	llmProvider, err := llm.NewProvider(cfg)
+	if err != nil {
+		log.Fatal("Failed to create LLM provider:", err)
+	}
+	if cfg.LLMProvider == llm.ProviderFake {
+		log.Println("WARNING: LLM_PROVIDER=fake, summaries are generated by a deterministic stub instead of a model; use it only for development and tests")
+	}
+
+	// Провайдер эмбеддингов для семантического поиска; nil, если он отключён
+	embedder, err := llm.NewEmbedder(cfg)
+	if err != nil {
+		log.Fatal("Failed to create embedding provider:", err)
+	}
 
 	// Создание usecases
-	createEntryUsecase := usecases.NewCreateEntryUsecase(entriesRepo)
-	listEntriesUsecase := usecases.NewListEntriesUsecase(entriesRepo)
-	updateEntryUsecase := usecases.NewUpdateEntryUsecase(entriesRepo)
-	deleteEntryUsecase := usecases.NewDeleteEntryUsecase(entriesRepo)
+	registerUserUsecase := usecases.NewRegisterUserUsecase(usersRepo, credentialsRepo, sessionTokens)
+	loginUserUsecase := usecases.NewLoginUserUsecase(usersRepo, credentialsRepo, sessionTokens)
+	authenticateAPITokenUsecase := usecases.NewAuthenticateAPITokenUsecase(apiTokensRepo)
+	createAPITokenUsecase := usecases.NewCreateAPITokenUsecase(apiTokensRepo)
+	listAPITokensUsecase := usecases.NewListAPITokensUsecase(apiTokensRepo)
+	revokeAPITokenUsecase := usecases.NewRevokeAPITokenUsecase(apiTokensRepo)
+	createEntryUsecase := usecases.NewCreateEntryUsecase(entriesRepo, tagsRepo)
+	listEntriesUsecase := usecases.NewListEntriesUsecase(entriesRepo, tagsRepo)
+	updateEntryUsecase := usecases.NewUpdateEntryUsecase(entriesRepo, tagsRepo)
+	deleteEntryUsecase := usecases.NewDeleteEntryUsecase(entriesRepo, goalEntryLinksRepo, tagsRepo)
+	listTagsUsecase := usecases.NewListTagsUsecase(tagsRepo)
+	searchEntriesUsecase := usecases.NewSearchEntriesUsecase(entriesRepo, tagsRepo)
+	semanticSearchEntriesUsecase := usecases.NewSemanticSearchEntriesUsecase(entriesRepo, entryEmbeddingsRepo, tagsRepo, embedder)
+	listRelatedEntriesUsecase := usecases.NewListRelatedEntriesUsecase(entriesRepo, entryEmbeddingsRepo, tagsRepo, embedder)
+	groupGoalCandidatesUsecase := usecases.NewGroupGoalCandidatesUsecase(entriesRepo, entryEmbeddingsRepo, tagsRepo, embedder)
+	importMarkdownEntriesUsecase := usecases.NewImportMarkdownEntriesUsecase(entriesRepo, createEntryUsecase)
+	importGitHistoryUsecase := usecases.NewImportGitHistoryUsecase(entriesRepo, createEntryUsecase, cfg.GitImportRoot, cfg.GitImportPerUser)
+	generatePerfSummaryUsecase := usecases.NewGeneratePerfSummaryUsecase(entriesRepo, perfSummaryRepo, usersRepo, tagsRepo, llmProvider, llm.NewBudget(cfg))
+	generateLocalSummaryUsecase := usecases.NewGenerateLocalSummaryUsecase(entriesRepo, localSummariesRepo, llmProvider, llm.NewBudget(cfg))
+	listPerfSummariesUsecase := usecases.NewListPerfSummariesUsecase(perfSummaryRepo)
+	getPerfSummaryUsecase := usecases.NewGetPerfSummaryUsecase(perfSummaryRepo)
+	deletePerfSummaryUsecase := usecases.NewDeletePerfSummaryUsecase(perfSummaryRepo)
+	exportPerfSummaryUsecase := usecases.NewExportPerfSummaryUsecase(perfSummaryRepo)
+	enqueueSummaryJobUsecase := usecases.NewEnqueueSummaryJobUsecase(summaryJobsRepo, usersRepo)
+	getSummaryJobUsecase := usecases.NewGetSummaryJobUsecase(summaryJobsRepo, perfSummaryRepo)
+	runSummaryJobUsecase := usecases.NewRunSummaryJobUsecase(summaryJobsRepo, generatePerfSummaryUsecase)
+	getUserProfileUsecase := usecases.NewGetUserProfileUsecase(usersRepo)
+	updateUserProfileUsecase := usecases.NewUpdateUserProfileUsecase(usersRepo)
+	createGoalUsecase := usecases.NewCreateGoalUsecase(goalsRepo)
+	listGoalsUsecase := usecases.NewListGoalsUsecase(goalsRepo)
+	getGoalUsecase := usecases.NewGetGoalUsecase(goalsRepo)
+	updateGoalUsecase := usecases.NewUpdateGoalUsecase(goalsRepo)
+	changeGoalStatusUsecase := usecases.NewChangeGoalStatusUsecase(goalsRepo)
+	deleteGoalUsecase := usecases.NewDeleteGoalUsecase(goalsRepo, goalEntryLinksRepo)
+	linkGoalEntryUsecase := usecases.NewLinkGoalEntryUsecase(goalsRepo, entriesRepo, goalEntryLinksRepo)
+	unlinkGoalEntryUsecase := usecases.NewUnlinkGoalEntryUsecase(goalsRepo, goalEntryLinksRepo)
+	listGoalEntriesUsecase := usecases.NewListGoalEntriesUsecase(goalsRepo, entriesRepo, goalEntryLinksRepo, tagsRepo)
+	exportAccountUsecase := usecases.NewExportAccountUsecase(entriesRepo, tagsRepo, goalsRepo, goalEntryLinksRepo, perfSummaryRepo, usersRepo)
+	importAccountUsecase := usecases.NewImportAccountUsecase(entriesRepo, tagsRepo, goalsRepo, goalEntryLinksRepo, perfSummaryRepo, usersRepo)
+	listDailyNoteConflictsUsecase := usecases.NewListDailyNoteConflictsUsecase(dailyNotesSyncRepo)
 
 	api := r.Group("/api")
 
 	// регистрация health-ручки
 	health.RegisterRoutes(api, health.Deps{})
 
+	// регистрация публичных ручек регистрации и входа
+	authhandlers.RegisterRoutes(api, authhandlers.Deps{
+		RegisterUserUsecase: registerUserUsecase,
+		LoginUserUsecase:    loginUserUsecase,
+	})
+
+	// все остальные ручки доступны только аутентифицированному пользователю:
+	// по сессионному токену или по персональному API-токену с нужной областью доступа
+	protected := api.Group("", auth.Middleware(sessionTokens, authenticateAPITokenUsecase, cfg.AuthDevUserID))
+
+	// регистрация ручек управления API-токенами (только из веб-сессии)
+	tokens.RegisterRoutes(protected.Group("", auth.RequireSession()), tokens.Deps{
+		CreateAPITokenUsecase: createAPITokenUsecase,
+		ListAPITokensUsecase:  listAPITokensUsecase,
+		RevokeAPITokenUsecase: revokeAPITokenUsecase,
+	})
+
+	// регистрация ручек профиля пользователя
+	users.RegisterRoutes(protected.Group("", auth.RequireResourceScope("profile")), users.Deps{
+		GetUserProfileUsecase:    getUserProfileUsecase,
+		UpdateUserProfileUsecase: updateUserProfileUsecase,
+	})
+
 	// регистрация ручек для entries
-	entries.RegisterRoutes(api, entries.Deps{
-		CreateEntryUsecase: createEntryUsecase,
-		ListEntriesUsecase: listEntriesUsecase,
-		UpdateEntryUsecase: updateEntryUsecase,
-		DeleteEntryUsecase: deleteEntryUsecase,
+	entries.RegisterRoutes(protected.Group("", auth.RequireResourceScope("entries")), entries.Deps{
+		CreateEntryUsecase:            createEntryUsecase,
+		ListEntriesUsecase:            listEntriesUsecase,
+		UpdateEntryUsecase:            updateEntryUsecase,
+		DeleteEntryUsecase:            deleteEntryUsecase,
+		ListTagsUsecase:               listTagsUsecase,
+		SearchEntriesUsecase:          searchEntriesUsecase,
+		SemanticSearchEntriesUsecase:  semanticSearchEntriesUsecase,
+		ListRelatedEntriesUsecase:     listRelatedEntriesUsecase,
+		GroupGoalCandidatesUsecase:    groupGoalCandidatesUsecase,
+		ImportMarkdownEntriesUsecase:  importMarkdownEntriesUsecase,
+		ImportGitHistoryUsecase:       importGitHistoryUsecase,
+		ListDailyNoteConflictsUsecase: listDailyNoteConflictsUsecase,
+	})
+
+	// регистрация ручек для goals
+	goals.RegisterRoutes(protected.Group("", auth.RequireResourceScope("goals")), goals.Deps{
+		CreateGoalUsecase:       createGoalUsecase,
+		ListGoalsUsecase:        listGoalsUsecase,
+		GetGoalUsecase:          getGoalUsecase,
+		UpdateGoalUsecase:       updateGoalUsecase,
+		ChangeGoalStatusUsecase: changeGoalStatusUsecase,
+		DeleteGoalUsecase:       deleteGoalUsecase,
+		LinkGoalEntryUsecase:    linkGoalEntryUsecase,
+		UnlinkGoalEntryUsecase:  unlinkGoalEntryUsecase,
+		ListGoalEntriesUsecase:  listGoalEntriesUsecase,
 	})
 
-	// регистрация ручек для perf summary (mock)
-	perfhandlers.RegisterRoutes(api, perfhandlers.Deps{})
+	// регистрация ручек для perf summary
+	perfhandlers.RegisterRoutes(protected.Group("", auth.RequireResourceScope("perf")), perfhandlers.Deps{
+		GeneratePerfSummaryUsecase:  generatePerfSummaryUsecase,
+		ListPerfSummariesUsecase:    listPerfSummariesUsecase,
+		GetPerfSummaryUsecase:       getPerfSummaryUsecase,
+		DeletePerfSummaryUsecase:    deletePerfSummaryUsecase,
+		EnqueueSummaryJobUsecase:    enqueueSummaryJobUsecase,
+		GetSummaryJobUsecase:        getSummaryJobUsecase,
+		GenerateLocalSummaryUsecase: generateLocalSummaryUsecase,
+		ExportPerfSummaryUsecase:    exportPerfSummaryUsecase,
+	})
 
-	return r
+	// регистрация ручек резервного копирования: архив затрагивает все ресурсы,
+	// поэтому API-токену нужен доступ на чтение (экспорт) или запись (импорт) к каждому из них
+	archive.RegisterRoutes(protected.Group("",
+		auth.RequireResourceScope("entries"),
+		auth.RequireResourceScope("goals"),
+		auth.RequireResourceScope("perf"),
+		auth.RequireResourceScope("profile"),
+	), archive.Deps{
+		ExportAccountUsecase: exportAccountUsecase,
+		ImportAccountUsecase: importAccountUsecase,
+	})
+
+	// фоновые воркеры очереди перф-саммари, обогащения и индексации записей, синхронизации с заметками
+	background := &Background{
+		summaryWorkers: jobs.NewWorkerPool(runSummaryJobUsecase, cfg.SummaryWorkers),
+	}
+	// Фейк не обогащает записи: его ответы попали бы в llm_enriched как результат модели
+	switch {
+	case !cfg.EntryEnrichmentEnabled:
+	case cfg.LLMProvider == llm.ProviderFake:
+		log.Println("Entry enrichment is disabled: LLM_PROVIDER=fake")
+	default:
+		enrichEntriesUsecase := usecases.NewEnrichEntriesUsecase(entriesRepo, llmProvider, llm.ProviderModel(cfg), llm.NewBudget(cfg))
+		background.entryWorkers = append(background.entryWorkers, jobs.NewBatchWorker("entry enrichment", enrichEntriesUsecase))
+	}
+	if embedder != nil {
+		embedEntriesUsecase := usecases.NewEmbedEntriesUsecase(entryEmbeddingsRepo, embedder)
+		background.entryWorkers = append(background.entryWorkers, jobs.NewBatchWorker("entry embeddings", embedEntriesUsecase))
+	}
+	if cfg.DailyNotesDir != "" {
+		syncDailyNotesUsecase := newSyncDailyNotesUsecase(cfg, entriesRepo, dailyNotesSyncRepo, createEntryUsecase, deleteEntryUsecase)
+		background.entryWorkers = append(background.entryWorkers, jobs.NewBatchWorker("daily notes sync", syncDailyNotesUsecase))
+	}
+
+	return r, background
+}
+
+// newSyncDailyNotesUsecase проверяет настройки синхронизации с каталогом ежедневных заметок.
+// Заметки принадлежат одному пользователю: DAILY_NOTES_USER_ID или пользователю локальной разработки
+func newSyncDailyNotesUsecase(cfg *config.Config, entriesRepo repositories.EntriesRepository, syncRepo repositories.DailyNotesSyncRepository, createEntry *usecases.CreateEntryUsecase, deleteEntry *usecases.DeleteEntryUsecase) *usecases.SyncDailyNotesUsecase {
+	userID := cfg.DailyNotesUserID
+	if userID == "" {
+		userID = cfg.AuthDevUserID
+	}
+	if userID == "" {
+		log.Fatal("DAILY_NOTES_DIR is set, but DAILY_NOTES_USER_ID is not: daily notes need an owner")
+	}
+	if info, err := os.Stat(cfg.DailyNotesDir); err != nil || !info.IsDir() {
+		log.Fatalf("DAILY_NOTES_DIR %q is not a directory", cfg.DailyNotesDir)
+	}
+	log.Printf("Syncing daily notes in %s with entries of user %q", cfg.DailyNotesDir, userID)
+
+	vault := dailynotes.NewVault(cfg.DailyNotesDir, cfg.DailyNotesPlanHeading, cfg.DailyNotesFactHeading)
+	return usecases.NewSyncDailyNotesUsecase(vault, userID, entriesRepo, syncRepo, createEntry, deleteEntry)
+}
+
+// sessionSecret возвращает ключ подписи сессионных токенов.
+// Без AUTH_SECRET генерируется случайный ключ — сессии не переживут перезапуск
+func sessionSecret(cfg *config.Config) []byte {
+	if cfg.AuthSecret != "" {
+		return []byte(cfg.AuthSecret)
+	}
+
+	log.Println("WARNING: AUTH_SECRET is not set, using a random key; sessions will be invalidated on restart")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatal("Failed to generate session secret:", err)
+	}
+	return secret
 }
 
 // getDBConnectionString формирует строку подключения к базе данных
@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Формат архива аккаунта. Версия повышается при несовместимых изменениях структуры;
+// импорт принимает архивы текущей и более ранних версий
+const (
+	AccountArchiveFormat  = "perf-assist-archive"
+	AccountArchiveVersion = 1
+)
+
+var (
+	// ErrInvalidArchive возвращается, если архив не в формате AccountArchiveFormat, его версия не поддерживается
+	// или какой-то объект в нём не проходит проверку. Ошибка оборачивается с указанием объекта
+	ErrInvalidArchive = errors.New("invalid archive")
+	// ErrInvalidImportStrategy возвращается для неизвестной стратегии разрешения конфликтов
+	ErrInvalidImportStrategy = errors.New("strategy must be one of: skip, overwrite, keep_both")
+)
+
+// AccountArchive представляет переносимую резервную копию данных пользователя.
+// В архив не попадают ID пользователя, API-токены, очередь саммари, кэш саммари дня или недели
+// и эмбеддинги — всё это либо привязано к инстансу, либо пересчитывается. Теги хранятся в записях
+type AccountArchive struct {
+	Format         string                 `json:"format"`
+	Version        int                    `json:"version"`
+	ExportedAt     time.Time              `json:"exported_at"`
+	Settings       *ArchiveSettings       `json:"settings"`
+	Entries        []ArchiveEntry         `json:"entries"`
+	Goals          []ArchiveGoal          `json:"goals"`
+	GoalEntryLinks []ArchiveGoalEntryLink `json:"goal_entry_links"`
+	PerfSummaries  []ArchivePerfSummary   `json:"perf_summaries"`
+}
+
+// ArchiveSettings представляет настройки профиля в архиве
+type ArchiveSettings struct {
+	Name                  string                `json:"name"`
+	Role                  repositories.UserRole `json:"role"`
+	PerfCycleLengthMonths int                   `json:"perf_cycle_length_months"`
+	Locale                string                `json:"locale"`
+}
+
+// ArchiveEntry представляет запись дневника в архиве. ID нужен только для ссылок из GoalEntryLinks:
+// при импорте запись сопоставляется с существующей по дате и типу
+type ArchiveEntry struct {
+	ID          string                        `json:"id"`
+	Date        string                        `json:"date"`
+	Type        repositories.EntryType        `json:"type"`
+	RawText     string                        `json:"raw_text"`
+	Tags        []string                      `json:"tags"`
+	LLMEnriched *repositories.EntryEnrichment `json:"llm_enriched"`
+	CreatedAt   time.Time                     `json:"created_at"`
+}
+
+// ArchiveGoal представляет цель в архиве. При импорте цель сопоставляется с существующей по названию
+type ArchiveGoal struct {
+	ID          string                  `json:"id"`
+	Title       string                  `json:"title"`
+	Description string                  `json:"description"`
+	Status      repositories.GoalStatus `json:"status"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+// ArchiveGoalEntryLink представляет связь цели и записи; GoalID и EntryID — ID из этого же архива
+type ArchiveGoalEntryLink struct {
+	GoalID         string    `json:"goal_id"`
+	EntryID        string    `json:"entry_id"`
+	RelevanceScore float64   `json:"relevance_score"`
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ArchivePerfSummary представляет сохранённое перф-саммари в архиве.
+// При импорте саммари сопоставляется с существующим по периоду и времени создания
+type ArchivePerfSummary struct {
+	ID          string                  `json:"id"`
+	PeriodStart string                  `json:"period_start"`
+	PeriodEnd   string                  `json:"period_end"`
+	Tags        []string                `json:"tags"`
+	RawGoals    []string                `json:"raw_goals"`
+	SummaryText string                  `json:"summary_text"`
+	Goals       []repositories.PerfGoal `json:"goals"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+// ImportStrategy определяет, что делать с объектом архива, который совпал с существующим, но отличается от него
+type ImportStrategy string
+
+const (
+	// ImportStrategySkip оставляет существующий объект без изменений
+	ImportStrategySkip ImportStrategy = "skip"
+	// ImportStrategyOverwrite заменяет существующий объект объектом из архива
+	ImportStrategyOverwrite ImportStrategy = "overwrite"
+	// ImportStrategyKeepBoth сохраняет оба варианта: цель и саммари импортируются копией,
+	// текст записи дописывается к существующему. Профиль и связи, которые не могут существовать
+	// в двух вариантах, при этой стратегии не меняются
+	ImportStrategyKeepBoth ImportStrategy = "keep_both"
+)
+
+// ImportCounts считает результат импорта объектов одного вида.
+// Unchanged — объекты, которые уже есть в аккаунте в том же виде: повторный импорт того же архива ничего не меняет
+type ImportCounts struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Skipped   int `json:"skipped"`
+	Unchanged int `json:"unchanged"`
+}
+
+// ImportReport представляет итог импорта архива
+type ImportReport struct {
+	Strategy       ImportStrategy `json:"strategy"`
+	Settings       ImportCounts   `json:"settings"`
+	Entries        ImportCounts   `json:"entries"`
+	Goals          ImportCounts   `json:"goals"`
+	GoalEntryLinks ImportCounts   `json:"goal_entry_links"`
+	PerfSummaries  ImportCounts   `json:"perf_summaries"`
+}
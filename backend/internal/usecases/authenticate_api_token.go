@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// apiTokenTouchInterval — не чаще какого интервала обновляется last_used_at,
+// чтобы скрипт, шлющий запросы подряд, не порождал запись в БД на каждый запрос
+const apiTokenTouchInterval = time.Minute
+
+// AuthenticateAPITokenUsecase отвечает за проверку персонального API-токена
+type AuthenticateAPITokenUsecase struct {
+	repo repositories.APITokensRepository
+}
+
+// NewAuthenticateAPITokenUsecase создает новый экземпляр AuthenticateAPITokenUsecase
+func NewAuthenticateAPITokenUsecase(repo repositories.APITokensRepository) *AuthenticateAPITokenUsecase {
+	return &AuthenticateAPITokenUsecase{
+		repo: repo,
+	}
+}
+
+// Execute находит токен по хешу, отмечает его использование и возвращает субъекта запроса
+func (u *AuthenticateAPITokenUsecase) Execute(token string) (auth.Principal, error) {
+	apiToken, err := u.repo.GetByHash(auth.HashAPIToken(token))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return auth.Principal{}, auth.ErrInvalidAPIToken
+	}
+	if err != nil {
+		return auth.Principal{}, err
+	}
+
+	now := time.Now().UTC()
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) >= apiTokenTouchInterval {
+		// Отметка использования не должна ронять сам запрос
+		if err := u.repo.TouchLastUsed(apiToken.UserID, apiToken.ID, now); err != nil {
+			log.Printf("failed to update last_used_at for api token %s: %v", apiToken.ID, err)
+		}
+	}
+
+	principal := auth.Principal{UserID: apiToken.UserID, APITokenID: apiToken.ID}
+	// Пустой список областей — полный доступ
+	if len(apiToken.Scopes) > 0 {
+		principal.Scopes = apiToken.Scopes
+	}
+	return principal, nil
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+var (
+	// ErrInvalidGoalStatus возвращается для неизвестного статуса цели
+	ErrInvalidGoalStatus = errors.New("invalid goal status")
+	// ErrInvalidGoalStatusTransition возвращается, если переход между статусами запрещён
+	ErrInvalidGoalStatusTransition = errors.New("invalid goal status transition")
+)
+
+// goalStatusTransitions описывает допустимые переходы жизненного цикла цели:
+// draft -> active | archived, active -> archived, archived -> active.
+// Вернуть цель в черновик нельзя — после активации она уже учитывается в саммари.
+var goalStatusTransitions = map[repositories.GoalStatus][]repositories.GoalStatus{
+	repositories.GoalStatusDraft:    {repositories.GoalStatusActive, repositories.GoalStatusArchived},
+	repositories.GoalStatusActive:   {repositories.GoalStatusArchived},
+	repositories.GoalStatusArchived: {repositories.GoalStatusActive},
+}
+
+// ChangeGoalStatusCommand представляет команду для смены статуса цели
+type ChangeGoalStatusCommand struct {
+	UserID string
+	ID     string
+	Status repositories.GoalStatus
+}
+
+// ChangeGoalStatusUsecase отвечает за смену статуса цели
+type ChangeGoalStatusUsecase struct {
+	repo repositories.GoalsRepository
+}
+
+// NewChangeGoalStatusUsecase создает новый экземпляр ChangeGoalStatusUsecase
+func NewChangeGoalStatusUsecase(repo repositories.GoalsRepository) *ChangeGoalStatusUsecase {
+	return &ChangeGoalStatusUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет смену статуса цели с проверкой допустимости перехода
+func (u *ChangeGoalStatusUsecase) Execute(cmd ChangeGoalStatusCommand) (repositories.Goal, error) {
+	if !isKnownGoalStatus(cmd.Status) {
+		return repositories.Goal{}, ErrInvalidGoalStatus
+	}
+
+	goal, err := u.repo.GetByID(cmd.UserID, cmd.ID)
+	if err != nil {
+		return repositories.Goal{}, err
+	}
+
+	// Повторная установка того же статуса — no-op
+	if goal.Status == cmd.Status {
+		return goal, nil
+	}
+	if !canTransitionGoalStatus(goal.Status, cmd.Status) {
+		return repositories.Goal{}, ErrInvalidGoalStatusTransition
+	}
+
+	goal.Status = cmd.Status
+	goal.UpdatedAt = time.Now().UTC()
+
+	if err := u.repo.Update(cmd.UserID, goal); err != nil {
+		return repositories.Goal{}, err
+	}
+
+	return goal, nil
+}
+
+// isKnownGoalStatus проверяет, что статус входит в жизненный цикл цели
+func isKnownGoalStatus(status repositories.GoalStatus) bool {
+	_, ok := goalStatusTransitions[status]
+	return ok
+}
+
+// canTransitionGoalStatus проверяет допустимость перехода from -> to
+func canTransitionGoalStatus(from, to repositories.GoalStatus) bool {
+	for _, allowed := range goalStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
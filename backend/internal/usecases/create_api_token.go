@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// maxAPITokenNameLength — ограничение на длину названия токена
+const maxAPITokenNameLength = 100
+
+var (
+	ErrAPITokenNameRequired = errors.New("token name is required")
+	ErrAPITokenNameTooLong  = errors.New("token name must be at most 100 characters")
+	ErrInvalidAPITokenScope = errors.New("unknown token scope")
+)
+
+// CreateAPITokenCommand представляет команду для выпуска API-токена
+type CreateAPITokenCommand struct {
+	UserID string
+	Name   string
+	// Scopes — пустой список означает полный доступ
+	Scopes []string
+}
+
+// CreatedAPIToken — выпущенный токен. Secret возвращается только один раз и нигде не хранится
+type CreatedAPIToken struct {
+	Token  repositories.APIToken
+	Secret string
+}
+
+// CreateAPITokenUsecase отвечает за выпуск персональных API-токенов
+type CreateAPITokenUsecase struct {
+	repo repositories.APITokensRepository
+}
+
+// NewCreateAPITokenUsecase создает новый экземпляр CreateAPITokenUsecase
+func NewCreateAPITokenUsecase(repo repositories.APITokensRepository) *CreateAPITokenUsecase {
+	return &CreateAPITokenUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет выпуск токена с указанными областями доступа
+func (u *CreateAPITokenUsecase) Execute(cmd CreateAPITokenCommand) (CreatedAPIToken, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return CreatedAPIToken{}, ErrAPITokenNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxAPITokenNameLength {
+		return CreatedAPIToken{}, ErrAPITokenNameTooLong
+	}
+
+	scopes, err := normalizeAPITokenScopes(cmd.Scopes)
+	if err != nil {
+		return CreatedAPIToken{}, err
+	}
+
+	secret, hash, prefix, err := auth.NewAPIToken()
+	if err != nil {
+		return CreatedAPIToken{}, err
+	}
+
+	now := time.Now().UTC()
+	token := repositories.APIToken{
+		ID:          now.Format("20060102150405.000000000"),
+		UserID:      cmd.UserID,
+		Name:        name,
+		TokenHash:   hash,
+		TokenPrefix: prefix,
+		Scopes:      scopes,
+		CreatedAt:   now,
+	}
+	if err := u.repo.Create(token); err != nil {
+		return CreatedAPIToken{}, err
+	}
+
+	return CreatedAPIToken{Token: token, Secret: secret}, nil
+}
+
+// normalizeAPITokenScopes проверяет области доступа, убирает дубликаты и сортирует их
+func normalizeAPITokenScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	result := []string{}
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !auth.IsKnownScope(scope) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAPITokenScope, scope)
+		}
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		result = append(result, scope)
+	}
+	sort.Strings(result)
+	return result, nil
+}
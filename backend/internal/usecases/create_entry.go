@@ -6,41 +6,56 @@ import (
 	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
 )
 
-// CreateEntryCommand представляет команду для создания записи
+// CreateEntryCommand представляет команду для создания записи.
+// Tags == nil оставляет теги перезаписываемой записи того же типа без изменений
 type CreateEntryCommand struct {
 	UserID  string
 	Date    string
 	Type    repositories.EntryType
 	RawText string
+	Tags    []string
 }
 
 // CreateEntryUsecase отвечает за создание записей
 type CreateEntryUsecase struct {
-	repo repositories.EntriesRepository
+	repo     repositories.EntriesRepository
+	tagsRepo repositories.TagsRepository
 }
 
 // NewCreateEntryUsecase создает новый экземпляр CreateEntryUsecase
-func NewCreateEntryUsecase(repo repositories.EntriesRepository) *CreateEntryUsecase {
+func NewCreateEntryUsecase(repo repositories.EntriesRepository, tagsRepo repositories.TagsRepository) *CreateEntryUsecase {
 	return &CreateEntryUsecase{
-		repo: repo,
+		repo:     repo,
+		tagsRepo: tagsRepo,
 	}
 }
 
 // Execute выполняет создание записи
 func (u *CreateEntryUsecase) Execute(cmd CreateEntryCommand) (repositories.Entry, error) {
+	tags, err := prepareEntryTags(cmd.Tags)
+	if err != nil {
+		return repositories.Entry{}, err
+	}
+
+	now := time.Now().UTC()
 	entry := repositories.Entry{
-		ID:        time.Now().UTC().Format("20060102150405.000000000"),
+		ID:        now.Format("20060102150405.000000000"),
 		UserID:    cmd.UserID,
 		Date:      cmd.Date,
 		Type:      cmd.Type,
 		RawText:   cmd.RawText,
-		CreatedAt: time.Now().UTC(),
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
-	err := u.repo.Create(entry)
+	entry, err = u.repo.Create(entry)
 	if err != nil {
 		return repositories.Entry{}, err
 	}
 
+	if err := saveEntryTags(u.tagsRepo, &entry, tags); err != nil {
+		return repositories.Entry{}, err
+	}
+
 	return entry, nil
 }
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// maxGoalTitleLength совпадает с размером колонки goals.title
+const maxGoalTitleLength = 255
+
+var (
+	// ErrGoalTitleRequired возвращается, если у цели пустое название
+	ErrGoalTitleRequired = errors.New("goal title is required")
+	// ErrGoalTitleTooLong возвращается, если название цели длиннее maxGoalTitleLength
+	ErrGoalTitleTooLong = errors.New("goal title is too long")
+)
+
+// CreateGoalCommand представляет команду для создания цели
+type CreateGoalCommand struct {
+	UserID      string
+	Title       string
+	Description string
+	Status      repositories.GoalStatus
+}
+
+// CreateGoalUsecase отвечает за создание целей
+type CreateGoalUsecase struct {
+	repo repositories.GoalsRepository
+}
+
+// NewCreateGoalUsecase создает новый экземпляр CreateGoalUsecase
+func NewCreateGoalUsecase(repo repositories.GoalsRepository) *CreateGoalUsecase {
+	return &CreateGoalUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет создание цели. Новая цель по умолчанию создаётся в статусе draft
+func (u *CreateGoalUsecase) Execute(cmd CreateGoalCommand) (repositories.Goal, error) {
+	title, err := validateGoalTitle(cmd.Title)
+	if err != nil {
+		return repositories.Goal{}, err
+	}
+
+	status := cmd.Status
+	if status == "" {
+		status = repositories.GoalStatusDraft
+	}
+	// Создать сразу архивную цель бессмысленно — допускаем только draft и active
+	if status != repositories.GoalStatusDraft && status != repositories.GoalStatusActive {
+		return repositories.Goal{}, ErrInvalidGoalStatus
+	}
+
+	now := time.Now().UTC()
+	goal := repositories.Goal{
+		ID:          now.Format("20060102150405.000000000"),
+		UserID:      cmd.UserID,
+		Title:       title,
+		Description: strings.TrimSpace(cmd.Description),
+		Status:      status,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := u.repo.Create(goal); err != nil {
+		return repositories.Goal{}, err
+	}
+
+	return goal, nil
+}
+
+// validateGoalTitle проверяет и нормализует название цели
+func validateGoalTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", ErrGoalTitleRequired
+	}
+	if len([]rune(title)) > maxGoalTitleLength {
+		return "", ErrGoalTitleTooLong
+	}
+	return title, nil
+}
@@ -6,29 +6,55 @@ import (
 
 // DeleteEntryCommand представляет команду для удаления записи
 type DeleteEntryCommand struct {
+	UserID   string
 	IDOrDate string
 }
 
 // DeleteEntryUsecase отвечает за удаление записи
 type DeleteEntryUsecase struct {
-	repo repositories.EntriesRepository
+	repo      repositories.EntriesRepository
+	linksRepo repositories.GoalEntryLinksRepository
+	tagsRepo  repositories.TagsRepository
 }
 
 // NewDeleteEntryUsecase создает новый экземпляр DeleteEntryUsecase
-func NewDeleteEntryUsecase(repo repositories.EntriesRepository) *DeleteEntryUsecase {
+func NewDeleteEntryUsecase(repo repositories.EntriesRepository, linksRepo repositories.GoalEntryLinksRepository, tagsRepo repositories.TagsRepository) *DeleteEntryUsecase {
 	return &DeleteEntryUsecase{
-		repo: repo,
+		repo:      repo,
+		linksRepo: linksRepo,
+		tagsRepo:  tagsRepo,
 	}
 }
 
-// Execute выполняет удаление записи
+// Execute выполняет удаление записи вместе с её связями с целями и тегами
 func (u *DeleteEntryUsecase) Execute(cmd DeleteEntryCommand) error {
 	// Проверяем, является ли cmd.IDOrDate датой (формат YYYY-MM-DD)
 	if len(cmd.IDOrDate) == 10 {
-		// Удаление по дате
-		return u.repo.DeleteByDate(cmd.IDOrDate)
+		// Удаление по дате: сначала снимаем связи и теги всех записей пользователя за эту дату
+		entries, err := u.repo.ListByUserAndDate(cmd.UserID, cmd.IDOrDate)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := u.linksRepo.DeleteByEntryID(cmd.UserID, e.ID); err != nil {
+				return err
+			}
+			if err := u.tagsRepo.DeleteByEntryID(cmd.UserID, e.ID); err != nil {
+				return err
+			}
+		}
+		return u.repo.DeleteByDate(cmd.UserID, cmd.IDOrDate)
 	} else {
-		// Удаление по ID
-		return u.repo.DeleteByID(cmd.IDOrDate)
+		// Удаление по ID вместе со связями и тегами записи
+		if _, err := u.repo.GetByID(cmd.UserID, cmd.IDOrDate); err != nil {
+			return err
+		}
+		if err := u.linksRepo.DeleteByEntryID(cmd.UserID, cmd.IDOrDate); err != nil {
+			return err
+		}
+		if err := u.tagsRepo.DeleteByEntryID(cmd.UserID, cmd.IDOrDate); err != nil {
+			return err
+		}
+		return u.repo.DeleteByID(cmd.UserID, cmd.IDOrDate)
 	}
 }
@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// DeleteGoalCommand представляет команду для удаления цели
+type DeleteGoalCommand struct {
+	UserID string
+	ID     string
+}
+
+// DeleteGoalUsecase отвечает за удаление цели
+type DeleteGoalUsecase struct {
+	repo      repositories.GoalsRepository
+	linksRepo repositories.GoalEntryLinksRepository
+}
+
+// NewDeleteGoalUsecase создает новый экземпляр DeleteGoalUsecase
+func NewDeleteGoalUsecase(repo repositories.GoalsRepository, linksRepo repositories.GoalEntryLinksRepository) *DeleteGoalUsecase {
+	return &DeleteGoalUsecase{
+		repo:      repo,
+		linksRepo: linksRepo,
+	}
+}
+
+// Execute выполняет удаление цели вместе с её связями с записями
+func (u *DeleteGoalUsecase) Execute(cmd DeleteGoalCommand) error {
+	if _, err := u.repo.GetByID(cmd.UserID, cmd.ID); err != nil {
+		return err
+	}
+	if err := u.linksRepo.DeleteByGoalID(cmd.UserID, cmd.ID); err != nil {
+		return err
+	}
+	return u.repo.DeleteByID(cmd.UserID, cmd.ID)
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// DeletePerfSummaryCommand представляет команду для удаления перф-саммари
+type DeletePerfSummaryCommand struct {
+	UserID string
+	ID     string
+}
+
+// DeletePerfSummaryUsecase отвечает за удаление перф-саммари из истории
+type DeletePerfSummaryUsecase struct {
+	repo repositories.PerfSummaryRepository
+}
+
+// NewDeletePerfSummaryUsecase создает новый экземпляр DeletePerfSummaryUsecase
+func NewDeletePerfSummaryUsecase(repo repositories.PerfSummaryRepository) *DeletePerfSummaryUsecase {
+	return &DeletePerfSummaryUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет удаление перф-саммари
+func (u *DeletePerfSummaryUsecase) Execute(cmd DeletePerfSummaryCommand) error {
+	return u.repo.DeleteByID(cmd.UserID, cmd.ID)
+}
@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+const (
+	// embeddingBatchSize — сколько записей отправляется провайдеру эмбеддингов одним запросом
+	embeddingBatchSize = 32
+	// embeddingMaxAttempts — после стольких ошибок провайдера запись пропускается до изменения текста
+	embeddingMaxAttempts = 3
+)
+
+// EmbedEntriesUsecase отвечает за фоновую индексацию записей для семантического поиска
+type EmbedEntriesUsecase struct {
+	embeddings repositories.EntryEmbeddingsRepository
+	embedder   llm.Embedder
+}
+
+// NewEmbedEntriesUsecase создает новый экземпляр EmbedEntriesUsecase
+func NewEmbedEntriesUsecase(embeddings repositories.EntryEmbeddingsRepository, embedder llm.Embedder) *EmbedEntriesUsecase {
+	return &EmbedEntriesUsecase{
+		embeddings: embeddings,
+		embedder:   embedder,
+	}
+}
+
+// Execute считает векторы очередной пачки записей без актуального эмбеддинга.
+// Возвращает false, если индексировать нечего.
+// Если провайдер отклонил пачку, записи считаются по одной, чтобы одна неподходящая запись
+// не останавливала индексацию остальных; ошибка на отдельной записи засчитывается ей как попытка
+func (u *EmbedEntriesUsecase) Execute(ctx context.Context) (bool, error) {
+	entries, err := u.embeddings.ListPending(u.embedder.Model(), embeddingMaxAttempts, embeddingBatchSize)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	vectors, err := u.embed(ctx, entries)
+	switch {
+	case ctx.Err() != nil:
+		return true, ctx.Err()
+	case err != nil && len(entries) == 1:
+		return true, u.failed(entries, err)
+	case err != nil:
+		return true, u.embedEach(ctx, entries)
+	}
+	return true, u.save(entries, vectors)
+}
+
+// embedEach считает векторы записей по одной. Ошибки засчитываются записям, только если хотя бы
+// одна запись пачки прошла: когда не проходит ни одна, скорее всего недоступен сам провайдер
+func (u *EmbedEntriesUsecase) embedEach(ctx context.Context, entries []repositories.Entry) error {
+	var rejected []repositories.Entry
+	var lastErr error
+	for _, e := range entries {
+		vectors, err := u.embed(ctx, []repositories.Entry{e})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			rejected = append(rejected, e)
+			lastErr = err
+			continue
+		}
+		if err := u.save([]repositories.Entry{e}, vectors); err != nil {
+			return err
+		}
+	}
+
+	if len(rejected) == 0 {
+		return nil
+	}
+	if len(rejected) == len(entries) {
+		return fmt.Errorf("failed to embed entries: %w", lastErr)
+	}
+	return u.failed(rejected, lastErr)
+}
+
+// embed запрашивает векторы текстов записей
+func (u *EmbedEntriesUsecase) embed(ctx context.Context, entries []repositories.Entry) ([][]float32, error) {
+	texts := make([]string, len(entries))
+	for i, e := range entries {
+		texts[i] = e.RawText
+	}
+	vectors, err := u.embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(entries) {
+		return nil, fmt.Errorf("embedder returned %d vectors for %d entries", len(vectors), len(entries))
+	}
+	return vectors, nil
+}
+
+// save сохраняет векторы записей
+func (u *EmbedEntriesUsecase) save(entries []repositories.Entry, vectors [][]float32) error {
+	for i, e := range entries {
+		err := u.embeddings.Upsert(newEntryEmbedding(e, u.embedder.Model(), vectors[i]))
+		// Запись удалили или изменили, пока считался вектор: изменённую возьмём снова с новым текстом
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// failed засчитывает записям неудачную попытку и возвращает ошибку провайдера
+func (u *EmbedEntriesUsecase) failed(entries []repositories.Entry, cause error) error {
+	for _, e := range entries {
+		err := u.embeddings.RecordFailure(repositories.EntryEmbeddingFailure{
+			EntryID:  e.ID,
+			UserID:   e.UserID,
+			Model:    u.embedder.Model(),
+			TextHash: repositories.EntryTextHash(e.RawText),
+			Error:    cause.Error(),
+			FailedAt: time.Now().UTC(),
+		})
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to embed %d entries: %w", len(entries), cause)
+}
+
+// newEntryEmbedding собирает EntryEmbedding для текущего текста записи
+func newEntryEmbedding(entry repositories.Entry, model string, vector []float32) repositories.EntryEmbedding {
+	return repositories.EntryEmbedding{
+		EntryID:   entry.ID,
+		UserID:    entry.UserID,
+		Model:     model,
+		TextHash:  repositories.EntryTextHash(entry.RawText),
+		Vector:    vector,
+		CreatedAt: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// EnqueueSummaryJobUsecase отвечает за постановку генерации перф-саммари в очередь
+type EnqueueSummaryJobUsecase struct {
+	repo      repositories.SummaryJobsRepository
+	usersRepo repositories.UsersRepository
+}
+
+// NewEnqueueSummaryJobUsecase создает новый экземпляр EnqueueSummaryJobUsecase
+func NewEnqueueSummaryJobUsecase(repo repositories.SummaryJobsRepository, usersRepo repositories.UsersRepository) *EnqueueSummaryJobUsecase {
+	return &EnqueueSummaryJobUsecase{
+		repo:      repo,
+		usersRepo: usersRepo,
+	}
+}
+
+// Execute выполняет постановку задачи в очередь.
+// Период и роль из профиля фиксируются сразу, чтобы задача после рестарта считалась с теми же параметрами.
+func (u *EnqueueSummaryJobUsecase) Execute(cmd GeneratePerfSummaryCommand) (repositories.SummaryJob, error) {
+	profile, err := loadUserProfile(u.usersRepo, cmd.UserID)
+	if err != nil {
+		return repositories.SummaryJob{}, err
+	}
+	cmd, err = withPerfSummaryDefaults(cmd, profile)
+	if err != nil {
+		return repositories.SummaryJob{}, err
+	}
+	tags, err := normalizeTags(cmd.Tags)
+	if err != nil {
+		return repositories.SummaryJob{}, err
+	}
+
+	now := time.Now().UTC()
+	job := repositories.SummaryJob{
+		ID:          now.Format("20060102150405.000000000"),
+		UserID:      cmd.UserID,
+		PeriodStart: cmd.PeriodStart,
+		PeriodEnd:   cmd.PeriodEnd,
+		Role:        cmd.Role,
+		Tags:        tags,
+		Status:      repositories.SummaryJobStatusQueued,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := u.repo.Create(job); err != nil {
+		return repositories.SummaryJob{}, err
+	}
+
+	return job, nil
+}
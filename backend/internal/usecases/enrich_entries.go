@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/prompts"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Ограничения обогащения: сколько записей берётся за раз и сколько пунктов каждого вида сохраняется
+const (
+	enrichmentBatchSize   = 10
+	enrichmentMaxItems    = 5
+	enrichmentMaxRunes    = 300
+	enrichmentTemperature = 0.2
+	// enrichmentMaxAttempts — после стольких ошибок провайдера подряд запись остаётся со статусом failed
+	enrichmentMaxAttempts = 3
+)
+
+// EnrichEntriesUsecase отвечает за фоновое обогащение записей моделью:
+// проекты, кандидаты в outputs/outcomes и предлагаемые теги сохраняются в llm_enriched.
+// Обогащение помечается моделью model, и после смены модели записи обогащаются заново
+type EnrichEntriesUsecase struct {
+	repo     repositories.EntriesRepository
+	provider llm.Provider
+	model    string
+	budget   llm.Budget
+}
+
+// NewEnrichEntriesUsecase создает новый экземпляр EnrichEntriesUsecase
+func NewEnrichEntriesUsecase(repo repositories.EntriesRepository, provider llm.Provider, model string, budget llm.Budget) *EnrichEntriesUsecase {
+	return &EnrichEntriesUsecase{
+		repo:     repo,
+		provider: provider,
+		model:    model,
+		budget:   budget,
+	}
+}
+
+// llmEnrichmentAnswer описывает JSON-ответ модели из entry_enrichment_prompt.md
+type llmEnrichmentAnswer struct {
+	Projects []string `json:"projects"`
+	Outputs  []string `json:"outputs"`
+	Outcomes []string `json:"outcomes"`
+	Tags     []string `json:"tags"`
+}
+
+// Execute обогащает очередную пачку записей без актуального llm_enriched.
+// Возвращает false, если обогащать нечего.
+// Ошибка провайдера засчитывается записи как попытка и прерывает пачку: запись уходит в конец очереди,
+// а после enrichmentMaxAttempts попыток остаётся со статусом failed, чтобы не блокировать остальные.
+// Ответ, который не удалось разобрать, и запись, которая не помещается в контекст модели,
+// сразу сохраняются со статусом failed, чтобы не запрашивать их повторно
+func (u *EnrichEntriesUsecase) Execute(ctx context.Context) (bool, error) {
+	entries, err := u.repo.ListPendingEnrichment(u.model, enrichmentMaxAttempts, enrichmentBatchSize)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	for _, entry := range entries {
+		enrichment, err := u.enrich(ctx, entry)
+		if err != nil {
+			if ctx.Err() != nil {
+				return true, ctx.Err()
+			}
+			failed := u.failedEnrichment("llm completion failed: " + err.Error())
+			if entry.LLMEnriched != nil && entry.LLMEnriched.Model == u.model {
+				failed.Attempts = entry.LLMEnriched.Attempts
+			}
+			failed.Attempts++
+			if err := u.save(entry, failed); err != nil {
+				return true, err
+			}
+			return true, fmt.Errorf("failed to enrich entry %s (attempt %d): %w", entry.ID, failed.Attempts, err)
+		}
+		if err := u.save(entry, enrichment); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// save сохраняет обогащение. Запись удалили или изменили, пока модель отвечала: изменённую возьмём снова с новым текстом
+func (u *EnrichEntriesUsecase) save(entry repositories.Entry, enrichment repositories.EntryEnrichment) error {
+	err := u.repo.SetEnrichment(entry.UserID, entry.ID, entry.RawText, enrichment)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// failedEnrichment возвращает обогащение со статусом failed и причиной ошибки
+func (u *EnrichEntriesUsecase) failedEnrichment(reason string) repositories.EntryEnrichment {
+	return repositories.EntryEnrichment{
+		Status:     repositories.EntryEnrichmentStatusFailed,
+		Projects:   []string{},
+		Outputs:    []string{},
+		Outcomes:   []string{},
+		Tags:       []string{},
+		Error:      reason,
+		Model:      u.model,
+		EnrichedAt: time.Now().UTC(),
+	}
+}
+
+// enrich запрашивает у модели обогащение одной записи
+func (u *EnrichEntriesUsecase) enrich(ctx context.Context, entry repositories.Entry) (repositories.EntryEnrichment, error) {
+	// DATE из Postgres приходит как "2025-03-14T00:00:00Z" — модели нужна только дата
+	date := entry.Date
+	if len(date) > len("2006-01-02") {
+		date = date[:len("2006-01-02")]
+	}
+	text := strings.Join(strings.Fields(entry.RawText), " ")
+	input := fmt.Sprintf("- %s (%s): %s\n", date, entry.Type, text)
+
+	messages := llm.SystemUserMessages(prompts.EntryEnrichmentPrompt, input)
+	if !u.budget.Fits(messages) {
+		return u.failedEnrichment("entry is too long for the model context"), nil
+	}
+	resp, err := u.provider.Complete(ctx, llm.CompletionRequest{
+		Messages:    messages,
+		Temperature: enrichmentTemperature,
+		MaxTokens:   u.budget.OutputTokens,
+		JSONMode:    true,
+	})
+	if err != nil {
+		return repositories.EntryEnrichment{}, err
+	}
+
+	var answer llmEnrichmentAnswer
+	if err := json.Unmarshal([]byte(llm.ExtractJSON(resp.Content)), &answer); err != nil {
+		return u.failedEnrichment("failed to parse llm answer: " + err.Error()), nil
+	}
+
+	enrichment := repositories.EntryEnrichment{
+		Status:     repositories.EntryEnrichmentStatusDone,
+		Projects:   cleanEnrichmentItems(answer.Projects),
+		Outputs:    cleanEnrichmentItems(answer.Outputs),
+		Outcomes:   []string{},
+		Tags:       []string{},
+		Model:      u.model,
+		EnrichedAt: time.Now().UTC(),
+	}
+	// У плана не может быть результатов — даже если модель их придумала
+	if entry.Type == repositories.EntryTypeFact {
+		enrichment.Outcomes = cleanEnrichmentItems(answer.Outcomes)
+	}
+	for _, raw := range answer.Tags {
+		// Тег, который не проходит ограничения тегов записи, просто не предлагаем
+		tags, err := normalizeTags([]string{raw})
+		if err != nil || len(tags) != 1 || slices.Contains(enrichment.Tags, tags[0]) {
+			continue
+		}
+		if len(enrichment.Tags) < enrichmentMaxItems {
+			enrichment.Tags = append(enrichment.Tags, tags[0])
+		}
+	}
+	return enrichment, nil
+}
+
+// cleanEnrichmentItems убирает пустые пункты и дубликаты, обрезает длинные и ограничивает их число
+func cleanEnrichmentItems(items []string) []string {
+	result := []string{}
+	for _, item := range items {
+		item = strings.Join(strings.Fields(item), " ")
+		if item == "" || slices.Contains(result, item) {
+			continue
+		}
+		if runes := []rune(item); len(runes) > enrichmentMaxRunes {
+			item = string(runes[:enrichmentMaxRunes-1]) + "…"
+		}
+		result = append(result, item)
+		if len(result) == enrichmentMaxItems {
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Ограничения выдачи семантического поиска и похожих записей
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+)
+
+// ErrSemanticSearchDisabled возвращается, если провайдер эмбеддингов отключён (EMBEDDING_PROVIDER=none)
+var ErrSemanticSearchDisabled = errors.New("semantic search is disabled")
+
+// SimilarEntry представляет запись с косинусной близостью к запросу или другой записи
+type SimilarEntry struct {
+	Entry repositories.Entry `json:"entry"`
+	Score float64            `json:"score"`
+}
+
+// similarLimit приводит запрошенный лимит к допустимому диапазону
+func similarLimit(limit int) int {
+	if limit <= 0 {
+		return defaultSimilarLimit
+	}
+	return min(limit, maxSimilarLimit)
+}
+
+// entryVector возвращает актуальный вектор записи. Если фоновая индексация ещё не дошла
+// до записи или её текст изменился, вектор считается сразу и сохраняется
+func entryVector(ctx context.Context, embeddings repositories.EntryEmbeddingsRepository, embedder llm.Embedder, entry repositories.Entry) ([]float32, error) {
+	stored, err := embeddings.GetByEntryID(entry.UserID, entry.ID, embedder.Model())
+	if err == nil && stored.TextHash == repositories.EntryTextHash(entry.RawText) {
+		return stored.Vector, nil
+	}
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	vectors, err := embedder.Embed(ctx, []string{entry.RawText})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed entry: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for 1 entry", len(vectors))
+	}
+	if err := embeddings.Upsert(newEntryEmbedding(entry, embedder.Model(), vectors[0])); err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+// loadSimilarEntries загружает записи пользователя по результатам Nearest и заполняет их теги.
+// Записи, удалённые между поиском и загрузкой, и записи с неположительной близостью пропускаются
+func loadSimilarEntries(entries repositories.EntriesRepository, tagsRepo repositories.TagsRepository, userID string, similar []repositories.EntrySimilarity) ([]SimilarEntry, error) {
+	result := []SimilarEntry{}
+	for _, s := range similar {
+		if s.Score <= 0 {
+			continue
+		}
+		entry, err := entries.GetByID(userID, s.EntryID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, SimilarEntry{Entry: entry, Score: s.Score})
+	}
+
+	loaded := make([]repositories.Entry, len(result))
+	for i, r := range result {
+		loaded[i] = r.Entry
+	}
+	if err := withEntryTags(tagsRepo, userID, loaded); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Entry = loaded[i]
+	}
+	return result, nil
+}
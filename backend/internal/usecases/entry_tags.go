@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Ограничения на теги записи
+const (
+	maxTagLength    = 50
+	maxTagsPerEntry = 20
+)
+
+var (
+	// ErrTagTooLong возвращается, если тег длиннее maxTagLength символов
+	ErrTagTooLong = errors.New("tag is too long")
+	// ErrTooManyTags возвращается, если у записи больше maxTagsPerEntry тегов
+	ErrTooManyTags = errors.New("too many tags")
+)
+
+// normalizeTags приводит теги к каноническому виду: без '#', в нижнем регистре, пробелы заменены на '-'.
+// Значение может содержать несколько тегов через запятую, поэтому одинаково принимаются
+// ["impact", "team"] и ["impact,team"]. Дубликаты и пустые теги отбрасываются, результат отсортирован
+func normalizeTags(values []string) ([]string, error) {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, raw := range strings.Split(value, ",") {
+			tag := strings.TrimPrefix(strings.TrimSpace(raw), "#")
+			tag = strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+			if tag == "" || seen[tag] {
+				continue
+			}
+			if utf8.RuneCountInString(tag) > maxTagLength {
+				return nil, fmt.Errorf("%w: %q", ErrTagTooLong, tag)
+			}
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// prepareEntryTags проверяет теги записи до сохранения самой записи.
+// nil означает «теги не переданы» и возвращается как есть
+func prepareEntryTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) > maxTagsPerEntry {
+		return nil, ErrTooManyTags
+	}
+	return normalized, nil
+}
+
+// saveEntryTags сохраняет подготовленные prepareEntryTags теги записи и выставляет entry.Tags.
+// При tags == nil текущий набор не меняется, а только загружается
+func saveEntryTags(tagsRepo repositories.TagsRepository, entry *repositories.Entry, tags []string) error {
+	if tags == nil {
+		entries := []repositories.Entry{*entry}
+		if err := withEntryTags(tagsRepo, entry.UserID, entries); err != nil {
+			return err
+		}
+		entry.Tags = entries[0].Tags
+		return nil
+	}
+
+	if err := tagsRepo.SetEntryTags(entry.UserID, entry.ID, tags); err != nil {
+		return err
+	}
+	entry.Tags = tags
+	return nil
+}
+
+// withEntryTags заполняет Tags у записей пользователя одним запросом.
+// У записи без тегов Tags — пустой срез, чтобы в JSON был [], а не null
+func withEntryTags(tagsRepo repositories.TagsRepository, userID string, entries []repositories.Entry) error {
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	byEntry, err := tagsRepo.ListByEntries(userID, ids)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		tags := byEntry[entries[i].ID]
+		if tags == nil {
+			tags = []string{}
+		}
+		entries[i].Tags = tags
+	}
+	return nil
+}
+
+// filterEntriesByTags оставляет записи, у которых есть хотя бы один из тегов.
+// Ожидает, что Tags у записей уже заполнены
+func filterEntriesByTags(entries []repositories.Entry, tags []string) []repositories.Entry {
+	wanted := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		wanted[t] = true
+	}
+
+	var result []repositories.Entry
+	for _, e := range entries {
+		for _, t := range e.Tags {
+			if wanted[t] {
+				result = append(result, e)
+				break
+			}
+		}
+	}
+	return result
+}
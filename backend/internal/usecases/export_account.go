@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Границы дат, между которыми лежат все записи пользователя
+const (
+	archiveMinDate = "0001-01-01"
+	archiveMaxDate = "9999-12-31"
+)
+
+// ExportAccountUsecase отвечает за выгрузку всех данных пользователя в AccountArchive
+type ExportAccountUsecase struct {
+	entriesRepo     repositories.EntriesRepository
+	tagsRepo        repositories.TagsRepository
+	goalsRepo       repositories.GoalsRepository
+	linksRepo       repositories.GoalEntryLinksRepository
+	perfSummaryRepo repositories.PerfSummaryRepository
+	usersRepo       repositories.UsersRepository
+}
+
+// NewExportAccountUsecase создает новый экземпляр ExportAccountUsecase
+func NewExportAccountUsecase(entriesRepo repositories.EntriesRepository, tagsRepo repositories.TagsRepository, goalsRepo repositories.GoalsRepository, linksRepo repositories.GoalEntryLinksRepository, perfSummaryRepo repositories.PerfSummaryRepository, usersRepo repositories.UsersRepository) *ExportAccountUsecase {
+	return &ExportAccountUsecase{
+		entriesRepo:     entriesRepo,
+		tagsRepo:        tagsRepo,
+		goalsRepo:       goalsRepo,
+		linksRepo:       linksRepo,
+		perfSummaryRepo: perfSummaryRepo,
+		usersRepo:       usersRepo,
+	}
+}
+
+// Execute собирает архив данных пользователя. Объекты упорядочены стабильно,
+// чтобы архивы одного и того же аккаунта можно было сравнивать
+func (u *ExportAccountUsecase) Execute(userID string) (AccountArchive, error) {
+	archive := AccountArchive{
+		Format:         AccountArchiveFormat,
+		Version:        AccountArchiveVersion,
+		ExportedAt:     time.Now().UTC(),
+		Entries:        []ArchiveEntry{},
+		Goals:          []ArchiveGoal{},
+		GoalEntryLinks: []ArchiveGoalEntryLink{},
+		PerfSummaries:  []ArchivePerfSummary{},
+	}
+
+	user, err := u.usersRepo.GetByID(userID)
+	if err == nil {
+		settings := toArchiveSettings(user)
+		archive.Settings = &settings
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return AccountArchive{}, err
+	}
+
+	entries, err := u.entriesRepo.ListByUserAndPeriod(userID, archiveMinDate, archiveMaxDate)
+	if err != nil {
+		return AccountArchive{}, err
+	}
+	if err := withEntryTags(u.tagsRepo, userID, entries); err != nil {
+		return AccountArchive{}, err
+	}
+	for _, e := range sortEntriesByDay(entries) {
+		archive.Entries = append(archive.Entries, toArchiveEntry(e))
+	}
+
+	goals, err := u.goalsRepo.ListByUser(userID, "")
+	if err != nil {
+		return AccountArchive{}, err
+	}
+	for _, g := range goals {
+		archive.Goals = append(archive.Goals, toArchiveGoal(g))
+
+		links, err := u.linksRepo.ListByGoal(userID, g.ID)
+		if err != nil {
+			return AccountArchive{}, err
+		}
+		for _, l := range links {
+			archive.GoalEntryLinks = append(archive.GoalEntryLinks, ArchiveGoalEntryLink{
+				GoalID:         l.GoalID,
+				EntryID:        l.EntryID,
+				RelevanceScore: l.RelevanceScore,
+				Note:           l.Note,
+				CreatedAt:      l.CreatedAt,
+			})
+		}
+	}
+
+	summaries, err := u.perfSummaryRepo.ListByUser(userID)
+	if err != nil {
+		return AccountArchive{}, err
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].CreatedAt.Before(summaries[j].CreatedAt)
+	})
+	for _, s := range summaries {
+		archive.PerfSummaries = append(archive.PerfSummaries, toArchivePerfSummary(s))
+	}
+
+	return archive, nil
+}
+
+// toArchiveSettings переводит профиль в настройки архива
+func toArchiveSettings(user repositories.User) ArchiveSettings {
+	return ArchiveSettings{
+		Name:                  user.Name,
+		Role:                  user.Role,
+		PerfCycleLengthMonths: user.PerfCycleLengthMonths,
+		Locale:                user.Locale,
+	}
+}
+
+// toArchiveEntry переводит запись в запись архива
+func toArchiveEntry(e repositories.Entry) ArchiveEntry {
+	return ArchiveEntry{
+		ID:          e.ID,
+		Date:        entryDay(e),
+		Type:        e.Type,
+		RawText:     e.RawText,
+		Tags:        nonNilTags(e.Tags),
+		LLMEnriched: e.LLMEnriched,
+		CreatedAt:   e.CreatedAt,
+	}
+}
+
+// toArchiveGoal переводит цель в цель архива
+func toArchiveGoal(g repositories.Goal) ArchiveGoal {
+	return ArchiveGoal{
+		ID:          g.ID,
+		Title:       g.Title,
+		Description: g.Description,
+		Status:      g.Status,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+// toArchivePerfSummary переводит перф-саммари в саммари архива
+func toArchivePerfSummary(s repositories.PerfSummary) ArchivePerfSummary {
+	goals := s.Goals
+	if goals == nil {
+		goals = []repositories.PerfGoal{}
+	}
+	return ArchivePerfSummary{
+		ID:          s.ID,
+		PeriodStart: s.PeriodStart,
+		PeriodEnd:   s.PeriodEnd,
+		Tags:        nonNilTags(s.Tags),
+		RawGoals:    nonNilTags(s.RawGoals),
+		SummaryText: s.SummaryText,
+		Goals:       goals,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// nonNilTags возвращает пустой срез вместо nil, чтобы в JSON был [], а не null
+func nonNilTags(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/export"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ExportPerfSummaryQuery представляет запрос на экспорт перф-саммари.
+// Пустой Format означает Markdown
+type ExportPerfSummaryQuery struct {
+	UserID string
+	ID     string
+	Format export.Format
+}
+
+// ExportPerfSummaryUsecase отвечает за выгрузку сохранённого перф-саммари в файл
+type ExportPerfSummaryUsecase struct {
+	repo repositories.PerfSummaryRepository
+}
+
+// NewExportPerfSummaryUsecase создает новый экземпляр ExportPerfSummaryUsecase
+func NewExportPerfSummaryUsecase(repo repositories.PerfSummaryRepository) *ExportPerfSummaryUsecase {
+	return &ExportPerfSummaryUsecase{
+		repo: repo,
+	}
+}
+
+// Execute загружает перф-саммари по ID и рендерит его в запрошенном формате
+func (u *ExportPerfSummaryUsecase) Execute(query ExportPerfSummaryQuery) (export.File, error) {
+	if query.Format == "" {
+		query.Format = export.FormatMarkdown
+	}
+
+	summary, err := u.repo.GetByID(query.UserID, query.ID)
+	if err != nil {
+		return export.File{}, err
+	}
+	return export.PerfSummary(summary, query.Format)
+}
@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Ограничения саммари дня или недели
+const (
+	maxLocalSummaryDays    = 7
+	maxLocalSummaryBullets = 7
+)
+
+var (
+	// ErrInvalidLocalSummaryPeriod возвращается, если даты не в формате YYYY-MM-DD, перепутаны местами или период длиннее недели
+	ErrInvalidLocalSummaryPeriod = errors.New("period dates must be YYYY-MM-DD, start_date must not be after end_date and the period must be at most 7 days")
+	// ErrLocalSummaryTooLarge возвращается, если записи периода не помещаются в контекст модели
+	ErrLocalSummaryTooLarge = errors.New("entries for the period do not fit into the model context, choose a shorter period")
+)
+
+// GenerateLocalSummaryCommand представляет команду для саммари дня или недели.
+// Без PeriodEnd берётся сегодняшний день, без PeriodStart — саммари одного дня PeriodEnd
+type GenerateLocalSummaryCommand struct {
+	UserID      string
+	PeriodStart string
+	PeriodEnd   string
+}
+
+// GenerateLocalSummaryUsecase отвечает за краткое саммари дня или недели: 3–7 пунктов и кандидаты в цели.
+// Результат кэшируется по хешу записей периода и пересчитывается, как только любая из них меняется
+type GenerateLocalSummaryUsecase struct {
+	repo        repositories.EntriesRepository
+	summaryRepo repositories.LocalSummariesRepository
+	provider    llm.Provider
+	budget      llm.Budget
+}
+
+// NewGenerateLocalSummaryUsecase создает новый экземпляр GenerateLocalSummaryUsecase
+func NewGenerateLocalSummaryUsecase(repo repositories.EntriesRepository, summaryRepo repositories.LocalSummariesRepository, provider llm.Provider, budget llm.Budget) *GenerateLocalSummaryUsecase {
+	return &GenerateLocalSummaryUsecase{
+		repo:        repo,
+		summaryRepo: summaryRepo,
+		provider:    provider,
+		budget:      budget,
+	}
+}
+
+// Execute возвращает саммари периода и признак того, что оно взято из кэша
+func (u *GenerateLocalSummaryUsecase) Execute(ctx context.Context, cmd GenerateLocalSummaryCommand) (repositories.LocalSummary, bool, error) {
+	cmd, err := withLocalSummaryDefaults(cmd)
+	if err != nil {
+		return repositories.LocalSummary{}, false, err
+	}
+
+	entries, err := u.repo.ListByUserAndPeriod(cmd.UserID, cmd.PeriodStart, cmd.PeriodEnd)
+	if err != nil {
+		return repositories.LocalSummary{}, false, err
+	}
+	hash := localSummaryEntriesHash(entries)
+
+	cached, err := u.summaryRepo.Get(cmd.UserID, cmd.PeriodStart, cmd.PeriodEnd)
+	if err == nil && cached.EntriesHash == hash {
+		return cached, true, nil
+	}
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return repositories.LocalSummary{}, false, err
+	}
+
+	summary := repositories.LocalSummary{
+		UserID:         cmd.UserID,
+		PeriodStart:    cmd.PeriodStart,
+		PeriodEnd:      cmd.PeriodEnd,
+		EntriesHash:    hash,
+		Bullets:        []repositories.LocalSummaryBullet{},
+		CandidateGoals: []string{},
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	// Без записей модели нечего суммировать — не тратим вызов LLM и не кэшируем пустоту
+	if len(entries) == 0 {
+		return summary, false, nil
+	}
+
+	lines := entryEvidenceLines(sortEntriesByDay(entries))
+	if messages, _ := localSummaryMessages(cmd.PeriodStart, cmd.PeriodEnd, lines); !u.budget.Fits(messages) {
+		return repositories.LocalSummary{}, false, ErrLocalSummaryTooLarge
+	}
+
+	answer, err := requestLocalSummary(ctx, u.provider, u.budget, cmd.PeriodStart, cmd.PeriodEnd, lines)
+	if err != nil {
+		return repositories.LocalSummary{}, false, err
+	}
+	for _, b := range answer.Bullets {
+		if len(summary.Bullets) == maxLocalSummaryBullets {
+			break
+		}
+		summary.Bullets = append(summary.Bullets, repositories.LocalSummaryBullet{Text: b.Text, Sources: b.Sources})
+	}
+	summary.CandidateGoals = mergeCandidateGoals([]weeklySummary{{CandidateGoals: answer.CandidateGoals}})
+	if summary.CandidateGoals == nil {
+		summary.CandidateGoals = []string{}
+	}
+
+	if err := u.summaryRepo.Upsert(summary); err != nil {
+		return repositories.LocalSummary{}, false, err
+	}
+	return summary, false, nil
+}
+
+// withLocalSummaryDefaults подставляет границы периода по умолчанию и проверяет их
+func withLocalSummaryDefaults(cmd GenerateLocalSummaryCommand) (GenerateLocalSummaryCommand, error) {
+	if cmd.PeriodEnd == "" {
+		cmd.PeriodEnd = time.Now().UTC().Format("2006-01-02")
+	}
+	if cmd.PeriodStart == "" {
+		cmd.PeriodStart = cmd.PeriodEnd
+	}
+
+	start, err := time.Parse("2006-01-02", cmd.PeriodStart)
+	if err != nil {
+		return cmd, ErrInvalidLocalSummaryPeriod
+	}
+	end, err := time.Parse("2006-01-02", cmd.PeriodEnd)
+	if err != nil || start.After(end) || end.Sub(start) >= maxLocalSummaryDays*24*time.Hour {
+		return cmd, ErrInvalidLocalSummaryPeriod
+	}
+	return cmd, nil
+}
+
+// localSummaryEntriesHash считает хеш записей периода независимо от порядка, в котором их вернул репозиторий
+func localSummaryEntriesHash(entries []repositories.Entry) string {
+	sorted := make([]repositories.Entry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	h := sha256.New()
+	for _, e := range sorted {
+		for _, field := range []string{e.ID, entryDay(e), string(e.Type), e.RawText} {
+			h.Write([]byte(field))
+			h.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
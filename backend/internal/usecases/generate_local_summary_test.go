@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// localSummaryAnswer — ответ модели на промпт саммари дня или недели
+const localSummaryAnswer = `{"summary":[{"text":"Shipped login","sources":["R1"]}],"candidate_goals":["Auth"]}`
+
+// newLocalSummaryUsecase возвращает usecase на in-memory хранилищах; каждый вызов модели получает localSummaryAnswer
+func newLocalSummaryUsecase(calls int) (*GenerateLocalSummaryUsecase, *repositories.InMemoryEntriesRepository, *repositories.InMemoryLocalSummariesRepository, *llm.ScriptedProvider) {
+	steps := make([]llm.ScriptedStep, calls)
+	for i := range steps {
+		steps[i] = answer(localSummaryAnswer)
+	}
+	entries := repositories.NewInMemoryEntriesRepository()
+	summaries := repositories.NewInMemoryLocalSummariesRepository()
+	provider := llm.NewScriptedProvider(steps...)
+	return NewGenerateLocalSummaryUsecase(entries, summaries, provider, perfSummaryTestBudget), entries, summaries, provider
+}
+
+func TestGenerateLocalSummaryCache(t *testing.T) {
+	u, entries, _, provider := newLocalSummaryUsecase(4)
+	create := func(id, date, text string) {
+		t.Helper()
+		if _, err := entries.Create(repositories.Entry{ID: id, UserID: "u1", Date: date, Type: repositories.EntryTypeFact, RawText: text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	create("e1", "2026-01-05", "Shipped login")
+	create("e2", "2026-01-06", "Fixed logout")
+	create("e9", "2026-01-20", "Outside the week")
+
+	// Шаги идут по порядку на одном кэше: wantCached — ответ взят из кэша без вызова модели
+	steps := []struct {
+		name       string
+		change     func()
+		wantCached bool
+	}{
+		{name: "first request", change: func() {}},
+		{name: "unchanged period", change: func() {}, wantCached: true},
+		{
+			name: "entry edited",
+			change: func() {
+				e, _ := entries.GetByID("u1", "e2")
+				e.RawText = "Fixed logout on mobile"
+				if err := entries.Update("u1", e); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{name: "cache refreshed after the edit", change: func() {}, wantCached: true},
+		{
+			name: "entry outside the period edited",
+			change: func() {
+				e, _ := entries.GetByID("u1", "e9")
+				e.RawText = "Still outside the week"
+				if err := entries.Update("u1", e); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantCached: true,
+		},
+		{name: "entry added", change: func() { create("e3", "2026-01-07", "Reviewed the release") }},
+		{
+			name: "entry deleted",
+			change: func() {
+				if err := entries.DeleteByID("u1", "e1"); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+
+	calls := 0
+	for _, step := range steps {
+		step.change()
+		summary, cached, err := u.Execute(context.Background(), GenerateLocalSummaryCommand{UserID: "u1", PeriodStart: "2026-01-05", PeriodEnd: "2026-01-11"})
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if !step.wantCached {
+			calls++
+		}
+		if cached != step.wantCached || len(provider.Requests()) != calls {
+			t.Fatalf("%s: cached = %v after %d model calls, want %v after %d", step.name, cached, len(provider.Requests()), step.wantCached, calls)
+		}
+		if len(summary.Bullets) != 1 || summary.Bullets[0].Text != "Shipped login" || len(summary.CandidateGoals) != 1 {
+			t.Fatalf("%s: summary = %+v", step.name, summary)
+		}
+	}
+}
+
+func TestGenerateLocalSummaryPeriod(t *testing.T) {
+	cases := []struct {
+		name      string
+		start     string
+		end       string
+		wantStart string
+		wantErr   bool
+	}{
+		{name: "single day", start: "2026-01-05", end: "2026-01-05", wantStart: "2026-01-05"},
+		{name: "day of the end date", end: "2026-01-05", wantStart: "2026-01-05"},
+		{name: "seven days", start: "2026-01-05", end: "2026-01-11", wantStart: "2026-01-05"},
+		{name: "eight days", start: "2026-01-04", end: "2026-01-11", wantErr: true},
+		{name: "start after end", start: "2026-01-06", end: "2026-01-05", wantErr: true},
+		{name: "bad date", start: "2026-1-5", end: "2026-01-05", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, entries, _, provider := newLocalSummaryUsecase(1)
+			if _, err := entries.Create(repositories.Entry{ID: "e1", UserID: "u1", Date: "2026-01-05", Type: repositories.EntryTypeFact, RawText: "Shipped login"}); err != nil {
+				t.Fatal(err)
+			}
+
+			summary, _, err := u.Execute(context.Background(), GenerateLocalSummaryCommand{UserID: "u1", PeriodStart: tc.start, PeriodEnd: tc.end})
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidLocalSummaryPeriod) {
+					t.Fatalf("Execute = %v, want ErrInvalidLocalSummaryPeriod", err)
+				}
+				if len(provider.Requests()) != 0 {
+					t.Fatal("invalid period reached the model")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary.PeriodStart != tc.wantStart || summary.PeriodEnd != tc.end {
+				t.Fatalf("period = %s — %s", summary.PeriodStart, summary.PeriodEnd)
+			}
+		})
+	}
+}
+
+func TestGenerateLocalSummaryEmptyPeriod(t *testing.T) {
+	u, entries, summaries, provider := newLocalSummaryUsecase(0)
+	if _, err := entries.Create(repositories.Entry{ID: "e1", UserID: "u1", Date: "2026-01-12", Type: repositories.EntryTypeFact, RawText: "Next week"}); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, cached, err := u.Execute(context.Background(), GenerateLocalSummaryCommand{UserID: "u1", PeriodStart: "2026-01-05", PeriodEnd: "2026-01-11"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(provider.Requests()) != 0 {
+		t.Fatal("empty period called the model")
+	}
+	if cached || len(summary.Bullets) != 0 || len(summary.CandidateGoals) != 0 {
+		t.Fatalf("summary = %+v, cached = %v", summary, cached)
+	}
+	// Пустое саммари не кэшируется: первая запись периода сразу попадёт в новое
+	if _, err := summaries.Get("u1", "2026-01-05", "2026-01-11"); !errors.Is(err, repositories.ErrNotFound) {
+		t.Fatalf("empty summary is cached: %v", err)
+	}
+}
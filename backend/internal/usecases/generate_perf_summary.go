@@ -0,0 +1,328 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/prompts"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ErrInvalidSummaryPeriod возвращается, если даты периода не в формате YYYY-MM-DD или перепутаны местами
+var ErrInvalidSummaryPeriod = errors.New("period dates must be YYYY-MM-DD and start_date must not be after end_date")
+
+// perfSummaryTemperature держим низкой, чтобы модель меньше фантазировала
+const perfSummaryTemperature = 0.2
+
+// GeneratePerfSummaryCommand представляет команду для генерации перф-саммари.
+// Если Tags не пуст, в саммари попадают только записи хотя бы с одним из тегов
+type GeneratePerfSummaryCommand struct {
+	UserID      string
+	PeriodStart string
+	PeriodEnd   string
+	Role        string
+	Tags        []string
+}
+
+// GeneratePerfSummaryUsecase отвечает за генерацию перф-саммари через LLM
+type GeneratePerfSummaryUsecase struct {
+	repo        repositories.EntriesRepository
+	summaryRepo repositories.PerfSummaryRepository
+	usersRepo   repositories.UsersRepository
+	tagsRepo    repositories.TagsRepository
+	provider    llm.Provider
+	budget      llm.Budget
+}
+
+// NewGeneratePerfSummaryUsecase создает новый экземпляр GeneratePerfSummaryUsecase
+func NewGeneratePerfSummaryUsecase(repo repositories.EntriesRepository, summaryRepo repositories.PerfSummaryRepository, usersRepo repositories.UsersRepository, tagsRepo repositories.TagsRepository, provider llm.Provider, budget llm.Budget) *GeneratePerfSummaryUsecase {
+	return &GeneratePerfSummaryUsecase{
+		repo:        repo,
+		summaryRepo: summaryRepo,
+		usersRepo:   usersRepo,
+		tagsRepo:    tagsRepo,
+		provider:    provider,
+		budget:      budget,
+	}
+}
+
+// llmPerfGoal описывает цель из ответа модели (формат perf_summary_prompt.md) после валидации
+type llmPerfGoal struct {
+	Title    string
+	Context  string
+	Outputs  []llmPerfBullet
+	Outcomes []llmPerfBullet
+}
+
+// llmPerfBullet — пункт outputs/outcomes с метками строк входа, на которые сослалась модель
+type llmPerfBullet struct {
+	Text    string
+	Sources []string
+}
+
+// Execute выполняет генерацию перф-саммари и сохраняет результат в историю
+func (u *GeneratePerfSummaryUsecase) Execute(ctx context.Context, cmd GeneratePerfSummaryCommand) (repositories.PerfSummary, error) {
+	return u.ExecuteWithProgress(ctx, cmd, func(PerfSummaryEvent) {})
+}
+
+// ExecuteWithProgress выполняет генерацию перф-саммари, сообщая о каждом этапе через onEvent.
+// Для длинных периодов onEvent получает chunk_summarized после каждой недели и после сборки целей.
+// Ответ модели запрашивается в режиме стриминга: onEvent получает токены и цели по мере готовности.
+func (u *GeneratePerfSummaryUsecase) ExecuteWithProgress(ctx context.Context, cmd GeneratePerfSummaryCommand, onEvent PerfSummaryProgressFunc) (repositories.PerfSummary, error) {
+	profile, err := loadUserProfile(u.usersRepo, cmd.UserID)
+	if err != nil {
+		return repositories.PerfSummary{}, err
+	}
+	cmd, err = withPerfSummaryDefaults(cmd, profile)
+	if err != nil {
+		return repositories.PerfSummary{}, err
+	}
+	cmd.Tags, err = normalizeTags(cmd.Tags)
+	if err != nil {
+		return repositories.PerfSummary{}, err
+	}
+
+	entries, err := u.loadEntries(cmd)
+	if err != nil {
+		return repositories.PerfSummary{}, err
+	}
+	onEvent(PerfSummaryEvent{Type: PerfSummaryEventEntriesLoaded, EntriesCount: len(entries)})
+
+	now := time.Now().UTC()
+	summary := repositories.PerfSummary{
+		ID:          now.Format("20060102150405.000000000"),
+		UserID:      cmd.UserID,
+		PeriodStart: cmd.PeriodStart,
+		PeriodEnd:   cmd.PeriodEnd,
+		Tags:        cmd.Tags,
+		RawGoals:    []string{},
+		Goals:       []repositories.PerfGoal{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// Без записей модели нечего анализировать — не тратим вызов LLM
+	if len(entries) == 0 {
+		summary.SummaryText = "За выбранный период нет записей."
+		return u.save(summary)
+	}
+
+	// Короткий период отправляем модели целиком. Если записи не помещаются в бюджет провайдера,
+	// сначала суммаризуем каждую неделю отдельно (map), а затем собираем цели из недельных саммари (reduce).
+	input, index := renderPerfSummaryInput(cmd, entryEvidenceLines(sortEntriesByDay(entries)))
+	messages := llm.SystemUserMessages(prompts.PerfSummaryPrompt, input)
+	totalChunks := 1
+	if !u.budget.Fits(messages) {
+		chunks := u.splitIntoWeeklyChunks(cmd, entries)
+		totalChunks = len(chunks) + 1
+
+		weeks := make([]weeklySummary, 0, len(chunks))
+		for i, chunk := range chunks {
+			week, err := u.summarizeChunk(ctx, chunk)
+			if err != nil {
+				return repositories.PerfSummary{}, fmt.Errorf("failed to summarize week %s: %w", chunk.Start, err)
+			}
+			weeks = append(weeks, week)
+			onEvent(PerfSummaryEvent{Type: PerfSummaryEventChunkSummarized, Chunk: i + 1, TotalChunks: totalChunks})
+		}
+
+		weeks, err = u.compressWeeklySummaries(ctx, cmd, weeks)
+		if err != nil {
+			return repositories.PerfSummary{}, err
+		}
+		input, index = renderPerfSummaryReduceInput(cmd, weeks)
+		messages = llm.SystemUserMessages(prompts.PerfSummaryPrompt, input)
+	}
+
+	// Числа в пунктах сверяются с исходными записями — и в черновиках, и в итоговых целях
+	goals, err := u.draftGoals(ctx, messages, index, func(event PerfSummaryEvent) {
+		if event.Goal != nil {
+			checkPerfGoalMetrics(event.Goal, entries)
+		}
+		onEvent(event)
+	})
+	if err != nil {
+		return repositories.PerfSummary{}, err
+	}
+	for i := range goals {
+		checkPerfGoalMetrics(&goals[i], entries)
+	}
+	onEvent(PerfSummaryEvent{Type: PerfSummaryEventChunkSummarized, Chunk: totalChunks, TotalChunks: totalChunks})
+	summary.Goals = goals
+
+	summary.SummaryText = fmt.Sprintf("Черновик перф-саммари за период %s — %s (целей: %d, записей: %d).",
+		cmd.PeriodStart, cmd.PeriodEnd, len(summary.Goals), len(entries))
+	if len(cmd.Tags) > 0 {
+		summary.SummaryText += fmt.Sprintf(" Учтены только записи с тегами: %s.", strings.Join(cmd.Tags, ", "))
+	}
+
+	return u.save(summary)
+}
+
+// loadEntries загружает записи за период, оставляя только записи с тегами из cmd.Tags, если они заданы
+func (u *GeneratePerfSummaryUsecase) loadEntries(cmd GeneratePerfSummaryCommand) ([]repositories.Entry, error) {
+	entries, err := u.repo.ListByUserAndPeriod(cmd.UserID, cmd.PeriodStart, cmd.PeriodEnd)
+	if err != nil || len(cmd.Tags) == 0 {
+		return entries, err
+	}
+	if err := withEntryTags(u.tagsRepo, cmd.UserID, entries); err != nil {
+		return nil, err
+	}
+	return filterEntriesByTags(entries, cmd.Tags), nil
+}
+
+// draftGoals запрашивает у модели цели в режиме стриминга, сообщая о токенах и готовых целях через onEvent.
+// Если ответ не проходит валидацию, модель получает список нарушений и отвечает повторно (без стриминга).
+// Ссылки пунктов на строки входа разрешаются через index в записи-источники.
+func (u *GeneratePerfSummaryUsecase) draftGoals(ctx context.Context, messages []llm.Message, index *evidenceIndex, onEvent PerfSummaryProgressFunc) ([]repositories.PerfGoal, error) {
+	req := llm.CompletionRequest{
+		Messages:    messages,
+		Temperature: perfSummaryTemperature,
+		MaxTokens:   u.budget.OutputTokens,
+		JSONMode:    true,
+	}
+
+	var scanner goalStreamScanner
+	scanned := 0
+	resp, err := llm.Stream(ctx, u.provider, req, func(token string) error {
+		onEvent(PerfSummaryEvent{Type: PerfSummaryEventToken, Token: token})
+		for _, raw := range scanner.Feed(token) {
+			scanned++
+			// Черновик цели показываем, только если он уже сейчас проходит валидацию
+			g, issues := parsePerfGoal(fmt.Sprintf("goals[%d]", scanned-1), raw)
+			if len(issues) > 0 {
+				continue
+			}
+			goal := toPerfGoal(scanned, g, index)
+			onEvent(PerfSummaryEvent{Type: PerfSummaryEventGoalDrafted, Goal: &goal})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm completion failed: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		answer, issues := parsePerfSummaryAnswer(resp.Content)
+		if len(issues) == 0 {
+			goals := make([]repositories.PerfGoal, 0, len(answer))
+			for i, g := range answer {
+				goals = append(goals, toPerfGoal(i+1, g, index))
+			}
+			return goals, nil
+		}
+		if attempt > perfSummaryRepairAttempts {
+			return nil, &PerfSummaryValidationError{Attempts: attempt, Issues: issues}
+		}
+
+		req.Messages = append(req.Messages,
+			llm.Message{Role: llm.RoleAssistant, Content: resp.Content},
+			llm.Message{Role: llm.RoleUser, Content: renderRepairPrompt(issues)},
+		)
+		resp, err = u.provider.Complete(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("llm completion failed: %w", err)
+		}
+	}
+}
+
+// withPerfSummaryDefaults подставляет период и роль из профиля пользователя и проверяет период.
+// Если период не задан — берём последний перф-цикл пользователя (по умолчанию полгода).
+// Неверные даты или перепутанные границы возвращают ErrInvalidSummaryPeriod
+func withPerfSummaryDefaults(cmd GeneratePerfSummaryCommand, profile repositories.User) (GeneratePerfSummaryCommand, error) {
+	if cmd.PeriodEnd == "" {
+		cmd.PeriodEnd = time.Now().UTC().Format("2006-01-02")
+	}
+	end, err := time.Parse("2006-01-02", cmd.PeriodEnd)
+	if err != nil {
+		return cmd, ErrInvalidSummaryPeriod
+	}
+	if cmd.PeriodStart == "" {
+		months := profile.PerfCycleLengthMonths
+		if months <= 0 {
+			months = defaultPerfCycleLengthMonths
+		}
+		cmd.PeriodStart = end.AddDate(0, -months, 0).Format("2006-01-02")
+	}
+	start, err := time.Parse("2006-01-02", cmd.PeriodStart)
+	if err != nil || start.After(end) {
+		return cmd, ErrInvalidSummaryPeriod
+	}
+	if cmd.Role == "" {
+		cmd.Role = string(profile.Role)
+	}
+	if cmd.Role == "" {
+		cmd.Role = string(repositories.UserRoleEngineer)
+	}
+	return cmd, nil
+}
+
+// save сохраняет перф-саммари в историю
+func (u *GeneratePerfSummaryUsecase) save(summary repositories.PerfSummary) (repositories.PerfSummary, error) {
+	if err := u.summaryRepo.Create(summary); err != nil {
+		return repositories.PerfSummary{}, err
+	}
+	return summary, nil
+}
+
+// toPerfGoal маппит цель из ответа модели в доменную модель, разрешая источники каждого пункта
+func toPerfGoal(n int, g llmPerfGoal, index *evidenceIndex) repositories.PerfGoal {
+	goal := repositories.PerfGoal{
+		ID:              fmt.Sprintf("goal-%d", n),
+		Title:           g.Title,
+		Context:         g.Context,
+		Outputs:         make([]string, 0, len(g.Outputs)),
+		Outcomes:        make([]string, 0, len(g.Outcomes)),
+		OutputEvidence:  make([]repositories.PerfEvidence, 0, len(g.Outputs)),
+		OutcomeEvidence: make([]repositories.PerfEvidence, 0, len(g.Outcomes)),
+	}
+	for _, b := range g.Outputs {
+		goal.Outputs = append(goal.Outputs, b.Text)
+		goal.OutputEvidence = append(goal.OutputEvidence, index.evidence(b.Sources))
+	}
+	for _, b := range g.Outcomes {
+		goal.Outcomes = append(goal.Outcomes, b.Text)
+		goal.OutcomeEvidence = append(goal.OutcomeEvidence, index.evidence(b.Sources))
+	}
+	return goal
+}
+
+// renderPerfSummaryInput формирует пользовательское сообщение в формате,
+// описанном в разделе "Input Format" промпта perf_summary_prompt.md, и индекс меток строк
+func renderPerfSummaryInput(cmd GeneratePerfSummaryCommand, lines []evidenceLine) (string, *evidenceIndex) {
+	index := newEvidenceIndex()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Role: %s\n", cmd.Role)
+	fmt.Fprintf(&b, "Period: %s — %s\n\n", cmd.PeriodStart, cmd.PeriodEnd)
+	b.WriteString("Entries:\n")
+	writeEvidenceLines(&b, lines, index)
+	return b.String(), index
+}
+
+// sortEntriesByDay возвращает копию записей, отсортированную по дате; план дня идёт перед фактом
+func sortEntriesByDay(entries []repositories.Entry) []repositories.Entry {
+	sorted := make([]repositories.Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if entryDay(sorted[i]) != entryDay(sorted[j]) {
+			return entryDay(sorted[i]) < entryDay(sorted[j])
+		}
+		return sorted[i].Type == repositories.EntryTypePlan && sorted[j].Type == repositories.EntryTypeFact
+	})
+	return sorted
+}
+
+// entryDay возвращает дату записи в формате YYYY-MM-DD.
+// Postgres-драйвер отдаёт колонку DATE как timestamp в RFC3339, поэтому обрезаем время.
+func entryDay(e repositories.Entry) string {
+	if len(e.Date) > 10 {
+		return e.Date[:10]
+	}
+	return e.Date
+}
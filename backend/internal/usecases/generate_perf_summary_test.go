@@ -0,0 +1,207 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/prompts"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+const perfSummaryTestUser = "u1"
+
+// perfSummaryTestBudget вмещает любой период из тестов одним запросом
+var perfSummaryTestBudget = llm.Budget{ContextTokens: 100000, OutputTokens: 512}
+
+// perfSummaryFixture — генерация перф-саммари на in-memory хранилищах с заготовленными ответами модели
+type perfSummaryFixture struct {
+	t         *testing.T
+	entries   *repositories.InMemoryEntriesRepository
+	users     *repositories.InMemoryUsersRepository
+	summaries *repositories.InMemoryPerfSummaryRepository
+	provider  *llm.ScriptedProvider
+	usecase   *GeneratePerfSummaryUsecase
+}
+
+func newPerfSummaryFixture(t *testing.T, budget llm.Budget, steps ...llm.ScriptedStep) *perfSummaryFixture {
+	f := &perfSummaryFixture{
+		t:         t,
+		entries:   repositories.NewInMemoryEntriesRepository(),
+		users:     repositories.NewInMemoryUsersRepository(),
+		summaries: repositories.NewInMemoryPerfSummaryRepository(),
+		provider:  llm.NewScriptedProvider(steps...),
+	}
+	f.usecase = NewGeneratePerfSummaryUsecase(f.entries, f.summaries, f.users, repositories.NewInMemoryTagsRepository(), f.provider, budget)
+	return f
+}
+
+// entry сохраняет запись пользователя с заданным ID
+func (f *perfSummaryFixture) entry(id, date string, entryType repositories.EntryType, text string) {
+	f.t.Helper()
+	if _, err := f.entries.Create(repositories.Entry{ID: id, UserID: perfSummaryTestUser, Date: date, Type: entryType, RawText: text}); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// generate генерирует саммари за январь 2026 года
+func (f *perfSummaryFixture) generate() (repositories.PerfSummary, error) {
+	return f.usecase.Execute(context.Background(), GeneratePerfSummaryCommand{UserID: perfSummaryTestUser, PeriodStart: "2026-01-01", PeriodEnd: "2026-01-31"})
+}
+
+// answer возвращает заготовленный ответ модели
+func answer(content string) llm.ScriptedStep {
+	return llm.ScriptedStep{Content: content}
+}
+
+func TestGeneratePerfSummary(t *testing.T) {
+	f := newPerfSummaryFixture(t, perfSummaryTestBudget, answer(`{"goals":[{
+		"title": " Auth ",
+		"context": "Login and logout",
+		"outputs": [{"text": "Shipped login", "sources": ["R2"]}, "Fixed logout"],
+		"outcomes": []
+	}]}`))
+	f.entry("e3", "2026-01-06", repositories.EntryTypeFact, "Fixed logout")
+	f.entry("e2", "2026-01-05", repositories.EntryTypeFact, "Shipped  login\nfor web")
+	f.entry("e1", "2026-01-05", repositories.EntryTypePlan, "Plan login")
+	f.entry("e0", "2025-12-31", repositories.EntryTypeFact, "Outside the period")
+
+	summary, err := f.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := f.provider.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	req := requests[0]
+	if !req.JSONMode || req.Temperature != perfSummaryTemperature || req.MaxTokens != perfSummaryTestBudget.OutputTokens {
+		t.Fatalf("request options = %+v", req)
+	}
+	if len(req.Messages) != 2 || req.Messages[0].Role != llm.RoleSystem || req.Messages[0].Content != prompts.PerfSummaryPrompt {
+		t.Fatalf("request must start with the perf summary prompt, got %d messages", len(req.Messages))
+	}
+	wantInput := "Role: engineer\nPeriod: 2026-01-01 — 2026-01-31\n\nEntries:\n" +
+		"- 2026-01-05 (plan) [R1]: Plan login\n" +
+		"- 2026-01-05 (fact) [R2]: Shipped login for web\n" +
+		"- 2026-01-06 (fact) [R3]: Fixed logout\n"
+	if got := req.Messages[1].Content; got != wantInput {
+		t.Fatalf("input =\n%s\nwant:\n%s", got, wantInput)
+	}
+
+	if len(summary.Goals) != 1 {
+		t.Fatalf("goals = %+v", summary.Goals)
+	}
+	goal := summary.Goals[0]
+	if goal.ID != "goal-1" || goal.Title != "Auth" || goal.Context != "Login and logout" {
+		t.Fatalf("goal = %+v", goal)
+	}
+	if strings.Join(goal.Outputs, "|") != "Shipped login|Fixed logout" || len(goal.Outcomes) != 0 {
+		t.Fatalf("outputs = %q, outcomes = %q", goal.Outputs, goal.Outcomes)
+	}
+	if !strings.Contains(summary.SummaryText, "целей: 1, записей: 3") {
+		t.Fatalf("summary text = %q", summary.SummaryText)
+	}
+	if _, err := f.summaries.GetByID(perfSummaryTestUser, summary.ID); err != nil {
+		t.Fatalf("summary is not saved: %v", err)
+	}
+}
+
+func TestGeneratePerfSummaryProviderError(t *testing.T) {
+	errUnavailable := errors.New("model is unavailable")
+	f := newPerfSummaryFixture(t, perfSummaryTestBudget, llm.ScriptedStep{Err: errUnavailable})
+	f.entry("e1", "2026-01-05", repositories.EntryTypeFact, "Shipped login")
+
+	if _, err := f.generate(); !errors.Is(err, errUnavailable) {
+		t.Fatalf("Execute = %v, want the provider error", err)
+	}
+	if saved, _ := f.summaries.ListByUser(perfSummaryTestUser); len(saved) != 0 {
+		t.Fatalf("failed generation saved %d summaries", len(saved))
+	}
+}
+
+func TestGeneratePerfSummaryPeriod(t *testing.T) {
+	cases := []struct {
+		name      string
+		start     string
+		end       string
+		profile   *repositories.User
+		wantStart string
+		wantEnd   string
+		wantRole  string
+		wantErr   bool
+	}{
+		{name: "explicit period", start: "2026-01-01", end: "2026-01-31", wantStart: "2026-01-01", wantEnd: "2026-01-31", wantRole: "engineer"},
+		{name: "single day", start: "2026-01-05", end: "2026-01-05", wantStart: "2026-01-05", wantEnd: "2026-01-05", wantRole: "engineer"},
+		{name: "default cycle is half a year", end: "2026-01-31", wantStart: "2025-07-31", wantEnd: "2026-01-31", wantRole: "engineer"},
+		{
+			name:      "cycle and role from the profile",
+			end:       "2026-01-31",
+			profile:   &repositories.User{ID: perfSummaryTestUser, Role: repositories.UserRoleManager, PerfCycleLengthMonths: 3},
+			wantStart: "2025-10-31",
+			wantEnd:   "2026-01-31",
+			wantRole:  "manager",
+		},
+		{name: "start after end", start: "2026-02-01", end: "2026-01-31", wantErr: true},
+		{name: "bad start", start: "2026-1-1", end: "2026-01-31", wantErr: true},
+		{name: "bad end", start: "2026-01-01", end: "31.01.2026", wantErr: true},
+		{name: "impossible date", start: "2026-02-30", end: "2026-03-01", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newPerfSummaryFixture(t, perfSummaryTestBudget, answer(`{"goals":[]}`))
+			if tc.profile != nil {
+				if err := f.users.Create(*tc.profile); err != nil {
+					t.Fatal(err)
+				}
+			}
+			f.entry("e1", "2026-01-05", repositories.EntryTypeFact, "Shipped login")
+
+			summary, err := f.usecase.Execute(context.Background(), GeneratePerfSummaryCommand{UserID: perfSummaryTestUser, PeriodStart: tc.start, PeriodEnd: tc.end})
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidSummaryPeriod) {
+					t.Fatalf("Execute = %v, want ErrInvalidSummaryPeriod", err)
+				}
+				if len(f.provider.Requests()) != 0 {
+					t.Fatal("invalid period reached the model")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary.PeriodStart != tc.wantStart || summary.PeriodEnd != tc.wantEnd {
+				t.Fatalf("period = %s — %s, want %s — %s", summary.PeriodStart, summary.PeriodEnd, tc.wantStart, tc.wantEnd)
+			}
+			// Модель видит тот же период и роль, что сохранены в саммари
+			requests := f.provider.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("got %d requests, want 1", len(requests))
+			}
+			input := requests[0].Messages[1].Content
+			if !strings.HasPrefix(input, "Role: "+tc.wantRole+"\nPeriod: "+tc.wantStart+" — "+tc.wantEnd+"\n") {
+				t.Fatalf("input = %q", input)
+			}
+		})
+	}
+}
+
+func TestGeneratePerfSummaryEmptyPeriod(t *testing.T) {
+	f := newPerfSummaryFixture(t, perfSummaryTestBudget)
+	f.entry("e1", "2025-12-31", repositories.EntryTypeFact, "Outside the period")
+
+	summary, err := f.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.provider.Requests()) != 0 {
+		t.Fatal("empty period called the model")
+	}
+	if len(summary.Goals) != 0 || summary.SummaryText != "За выбранный период нет записей." {
+		t.Fatalf("summary = %+v", summary)
+	}
+}
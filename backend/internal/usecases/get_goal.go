@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// GetGoalQuery представляет запрос для получения цели
+type GetGoalQuery struct {
+	UserID string
+	ID     string
+}
+
+// GetGoalUsecase отвечает за получение цели
+type GetGoalUsecase struct {
+	repo repositories.GoalsRepository
+}
+
+// NewGetGoalUsecase создает новый экземпляр GetGoalUsecase
+func NewGetGoalUsecase(repo repositories.GoalsRepository) *GetGoalUsecase {
+	return &GetGoalUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет получение цели по ID
+func (u *GetGoalUsecase) Execute(query GetGoalQuery) (repositories.Goal, error) {
+	return u.repo.GetByID(query.UserID, query.ID)
+}
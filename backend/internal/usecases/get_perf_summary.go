@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// GetPerfSummaryQuery представляет запрос для получения перф-саммари
+type GetPerfSummaryQuery struct {
+	UserID string
+	ID     string
+}
+
+// GetPerfSummaryUsecase отвечает за получение сохранённого перф-саммари
+type GetPerfSummaryUsecase struct {
+	repo repositories.PerfSummaryRepository
+}
+
+// NewGetPerfSummaryUsecase создает новый экземпляр GetPerfSummaryUsecase
+func NewGetPerfSummaryUsecase(repo repositories.PerfSummaryRepository) *GetPerfSummaryUsecase {
+	return &GetPerfSummaryUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет получение перф-саммари по ID
+func (u *GetPerfSummaryUsecase) Execute(query GetPerfSummaryQuery) (repositories.PerfSummary, error) {
+	return u.repo.GetByID(query.UserID, query.ID)
+}
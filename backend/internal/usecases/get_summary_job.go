@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// GetSummaryJobQuery представляет запрос для получения задачи генерации перф-саммари
+type GetSummaryJobQuery struct {
+	UserID string
+	ID     string
+}
+
+// SummaryJobResult объединяет задачу и готовое перф-саммари (если задача завершилась успешно)
+type SummaryJobResult struct {
+	Job     repositories.SummaryJob
+	Summary *repositories.PerfSummary
+}
+
+// GetSummaryJobUsecase отвечает за получение статуса и результата задачи
+type GetSummaryJobUsecase struct {
+	repo        repositories.SummaryJobsRepository
+	summaryRepo repositories.PerfSummaryRepository
+}
+
+// NewGetSummaryJobUsecase создает новый экземпляр GetSummaryJobUsecase
+func NewGetSummaryJobUsecase(repo repositories.SummaryJobsRepository, summaryRepo repositories.PerfSummaryRepository) *GetSummaryJobUsecase {
+	return &GetSummaryJobUsecase{
+		repo:        repo,
+		summaryRepo: summaryRepo,
+	}
+}
+
+// Execute выполняет получение задачи по ID
+func (u *GetSummaryJobUsecase) Execute(query GetSummaryJobQuery) (SummaryJobResult, error) {
+	job, err := u.repo.GetByID(query.UserID, query.ID)
+	if err != nil {
+		return SummaryJobResult{}, err
+	}
+
+	result := SummaryJobResult{Job: job}
+	if job.Status != repositories.SummaryJobStatusSucceeded || job.ResultSummaryID == "" {
+		return result, nil
+	}
+
+	summary, err := u.summaryRepo.GetByID(job.UserID, job.ResultSummaryID)
+	if err != nil {
+		return SummaryJobResult{}, err
+	}
+	result.Summary = &summary
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Значения профиля по умолчанию: инженер с полугодовым перф-циклом
+const (
+	defaultPerfCycleLengthMonths = 6
+	defaultUserLocale            = "ru"
+)
+
+// GetUserProfileQuery представляет запрос для получения профиля пользователя
+type GetUserProfileQuery struct {
+	UserID string
+}
+
+// GetUserProfileUsecase отвечает за получение профиля текущего пользователя
+type GetUserProfileUsecase struct {
+	repo repositories.UsersRepository
+}
+
+// NewGetUserProfileUsecase создает новый экземпляр GetUserProfileUsecase
+func NewGetUserProfileUsecase(repo repositories.UsersRepository) *GetUserProfileUsecase {
+	return &GetUserProfileUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет получение профиля. При первом обращении профиль создаётся со значениями по умолчанию
+func (u *GetUserProfileUsecase) Execute(query GetUserProfileQuery) (repositories.User, error) {
+	user, err := u.repo.GetByID(query.UserID)
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return user, err
+	}
+
+	user = newDefaultUser(query.UserID)
+	if err := u.repo.Create(user); err != nil {
+		// Профиль мог создать параллельный запрос — тогда он уже есть в хранилище
+		if existing, getErr := u.repo.GetByID(query.UserID); getErr == nil {
+			return existing, nil
+		}
+		return repositories.User{}, err
+	}
+
+	return user, nil
+}
+
+// loadUserProfile возвращает сохранённый профиль или профиль по умолчанию, не создавая его
+func loadUserProfile(repo repositories.UsersRepository, userID string) (repositories.User, error) {
+	user, err := repo.GetByID(userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return newDefaultUser(userID), nil
+	}
+	return user, err
+}
+
+// newDefaultUser создает профиль со значениями по умолчанию
+func newDefaultUser(id string) repositories.User {
+	now := time.Now().UTC()
+	return repositories.User{
+		ID:                    id,
+		Role:                  repositories.UserRoleEngineer,
+		PerfCycleLengthMonths: defaultPerfCycleLengthMonths,
+		Locale:                defaultUserLocale,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+}
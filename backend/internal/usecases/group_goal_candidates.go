@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Ограничения группировки записей в кандидатов в цели
+const (
+	// goalCandidateMinSimilarity — минимальная косинусная близость записи к центру группы.
+	// У несвязанных записей близость обычно ниже 0.3, у записей об одной работе — от 0.5
+	goalCandidateMinSimilarity = 0.45
+	// goalCandidateMinEntries — группа из меньшего числа записей кандидатом не считается
+	goalCandidateMinEntries = 2
+	maxGoalCandidates       = 20
+)
+
+// GroupGoalCandidatesQuery представляет запрос кандидатов в цели за период; пустые From и To — без ограничения
+type GroupGoalCandidatesQuery struct {
+	UserID string
+	From   string
+	To     string
+}
+
+// GoalCandidate представляет группу близких по смыслу записей — кандидата в цель перф-саммари.
+// Label — самый частый проект из обогащения или тег записей группы; пустой, если их нет
+type GoalCandidate struct {
+	Label   string               `json:"label"`
+	Entries []repositories.Entry `json:"entries"`
+}
+
+// GoalCandidatesResult представляет кандидатов в цели, самые крупные группы первыми.
+// Unindexed — записи периода без актуального вектора: фоновая индексация до них ещё не дошла
+type GoalCandidatesResult struct {
+	Candidates []GoalCandidate `json:"candidates"`
+	Unindexed  int             `json:"unindexed"`
+}
+
+// GroupGoalCandidatesUsecase отвечает за группировку записей периода в кандидатов в цели по эмбеддингам.
+// Используются только сохранённые векторы, поэтому ни записи, ни их тексты в чат-модель не отправляются
+type GroupGoalCandidatesUsecase struct {
+	entries    repositories.EntriesRepository
+	embeddings repositories.EntryEmbeddingsRepository
+	tagsRepo   repositories.TagsRepository
+	embedder   llm.Embedder
+}
+
+// NewGroupGoalCandidatesUsecase создает новый экземпляр GroupGoalCandidatesUsecase.
+// embedder может быть nil — тогда Execute возвращает ErrSemanticSearchDisabled
+func NewGroupGoalCandidatesUsecase(entries repositories.EntriesRepository, embeddings repositories.EntryEmbeddingsRepository, tagsRepo repositories.TagsRepository, embedder llm.Embedder) *GroupGoalCandidatesUsecase {
+	return &GroupGoalCandidatesUsecase{
+		entries:    entries,
+		embeddings: embeddings,
+		tagsRepo:   tagsRepo,
+		embedder:   embedder,
+	}
+}
+
+// goalCluster — группа записей и сумма их нормированных векторов, по которой считается близость к группе
+type goalCluster struct {
+	entries  []repositories.Entry
+	centroid []float32
+}
+
+// Execute группирует записи пользователя за период. Записи обходятся по дате, и каждая попадает
+// в самую близкую группу, если близость к её центру не меньше goalCandidateMinSimilarity, иначе открывает новую
+func (u *GroupGoalCandidatesUsecase) Execute(query GroupGoalCandidatesQuery) (GoalCandidatesResult, error) {
+	if u.embedder == nil {
+		return GoalCandidatesResult{}, ErrSemanticSearchDisabled
+	}
+	from, to := query.From, query.To
+	if from == "" {
+		from = minEntryDate
+	}
+	if to == "" {
+		to = maxEntryDate
+	}
+
+	entries, err := u.entries.ListByUserAndPeriod(query.UserID, from, to)
+	if err != nil {
+		return GoalCandidatesResult{}, err
+	}
+	if err := withEntryTags(u.tagsRepo, query.UserID, entries); err != nil {
+		return GoalCandidatesResult{}, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entryDay(entries[i]) < entryDay(entries[j])
+	})
+
+	result := GoalCandidatesResult{Candidates: []GoalCandidate{}}
+	var clusters []*goalCluster
+	for _, e := range entries {
+		stored, err := u.embeddings.GetByEntryID(query.UserID, e.ID, u.embedder.Model())
+		if errors.Is(err, repositories.ErrNotFound) || (err == nil && stored.TextHash != repositories.EntryTextHash(e.RawText)) {
+			result.Unindexed++
+			continue
+		}
+		if err != nil {
+			return GoalCandidatesResult{}, err
+		}
+		vector := normalizedVector(stored.Vector)
+		if vector == nil {
+			continue
+		}
+
+		var best *goalCluster
+		bestScore := goalCandidateMinSimilarity
+		for _, c := range clusters {
+			if len(c.centroid) != len(vector) {
+				continue
+			}
+			if score := repositories.CosineSimilarity(vector, c.centroid); score >= bestScore {
+				best, bestScore = c, score
+			}
+		}
+		if best == nil {
+			best = &goalCluster{centroid: make([]float32, len(vector))}
+			clusters = append(clusters, best)
+		}
+		best.entries = append(best.entries, e)
+		for i, v := range vector {
+			best.centroid[i] += v
+		}
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].entries) > len(clusters[j].entries)
+	})
+	for _, c := range clusters {
+		if len(c.entries) < goalCandidateMinEntries || len(result.Candidates) == maxGoalCandidates {
+			break
+		}
+		result.Candidates = append(result.Candidates, GoalCandidate{Label: goalCandidateLabel(c.entries), Entries: c.entries})
+	}
+	return result, nil
+}
+
+// normalizedVector возвращает вектор единичной длины; для нулевого вектора — nil
+func normalizedVector(vector []float32) []float32 {
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	result := make([]float32, len(vector))
+	for i, v := range vector {
+		result[i] = float32(float64(v) / norm)
+	}
+	return result
+}
+
+// goalCandidateLabel возвращает самый частый проект из обогащения записей, а если проектов нет — самый частый тег.
+// При равенстве побеждает встретившийся раньше
+func goalCandidateLabel(entries []repositories.Entry) string {
+	var projects, tags []string
+	for _, e := range entries {
+		if e.LLMEnriched != nil && e.LLMEnriched.Status == repositories.EntryEnrichmentStatusDone {
+			projects = append(projects, e.LLMEnriched.Projects...)
+		}
+		tags = append(tags, e.Tags...)
+	}
+	if label := mostFrequent(projects); label != "" {
+		return label
+	}
+	return mostFrequent(tags)
+}
+
+// mostFrequent возвращает самую частую строку; при равенстве — встретившуюся раньше
+func mostFrequent(values []string) string {
+	counts := make(map[string]int, len(values))
+	for _, v := range values {
+		counts[v]++
+	}
+	best := ""
+	for _, v := range values {
+		if counts[v] > counts[best] {
+			best = v
+		}
+	}
+	return best
+}
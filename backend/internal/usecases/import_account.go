@@ -0,0 +1,598 @@
+package usecases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ImportAccountCommand представляет команду восстановления данных из архива.
+// Пустая Strategy означает ImportStrategySkip
+type ImportAccountCommand struct {
+	UserID   string
+	Strategy ImportStrategy
+	Archive  AccountArchive
+}
+
+// ImportAccountUsecase отвечает за восстановление данных пользователя из AccountArchive.
+// Объекты архива сопоставляются с существующими по естественным ключам, а не по ID, поэтому архив
+// переносится между инстансами: записи — по дате и типу, цели — по названию, перф-саммари — по периоду
+// и времени создания. Новые объекты получают ID этого инстанса. Импорт идемпотентен: объекты,
+// которые уже есть в том же виде, не меняются, так что прерванный импорт можно просто повторить
+type ImportAccountUsecase struct {
+	entriesRepo     repositories.EntriesRepository
+	tagsRepo        repositories.TagsRepository
+	goalsRepo       repositories.GoalsRepository
+	linksRepo       repositories.GoalEntryLinksRepository
+	perfSummaryRepo repositories.PerfSummaryRepository
+	usersRepo       repositories.UsersRepository
+}
+
+// NewImportAccountUsecase создает новый экземпляр ImportAccountUsecase
+func NewImportAccountUsecase(entriesRepo repositories.EntriesRepository, tagsRepo repositories.TagsRepository, goalsRepo repositories.GoalsRepository, linksRepo repositories.GoalEntryLinksRepository, perfSummaryRepo repositories.PerfSummaryRepository, usersRepo repositories.UsersRepository) *ImportAccountUsecase {
+	return &ImportAccountUsecase{
+		entriesRepo:     entriesRepo,
+		tagsRepo:        tagsRepo,
+		goalsRepo:       goalsRepo,
+		linksRepo:       linksRepo,
+		perfSummaryRepo: perfSummaryRepo,
+		usersRepo:       usersRepo,
+	}
+}
+
+// Execute проверяет архив целиком и только потом импортирует его
+func (u *ImportAccountUsecase) Execute(cmd ImportAccountCommand) (ImportReport, error) {
+	if cmd.Strategy == "" {
+		cmd.Strategy = ImportStrategySkip
+	}
+	switch cmd.Strategy {
+	case ImportStrategySkip, ImportStrategyOverwrite, ImportStrategyKeepBoth:
+	default:
+		return ImportReport{}, ErrInvalidImportStrategy
+	}
+
+	archive := cmd.Archive
+	if err := validateArchive(&archive); err != nil {
+		return ImportReport{}, err
+	}
+
+	imp := &accountImport{
+		ImportAccountUsecase: u,
+		userID:               cmd.UserID,
+		strategy:             cmd.Strategy,
+		newID:                newImportIDs(),
+		entryIDs:             make(map[string]string, len(archive.Entries)),
+		goalIDs:              make(map[string]string, len(archive.Goals)),
+		report:               ImportReport{Strategy: cmd.Strategy},
+	}
+	if err := imp.settings(archive.Settings); err != nil {
+		return ImportReport{}, err
+	}
+	if err := imp.entries(archive.Entries); err != nil {
+		return ImportReport{}, err
+	}
+	if err := imp.goals(archive.Goals); err != nil {
+		return ImportReport{}, err
+	}
+	if err := imp.links(archive.GoalEntryLinks); err != nil {
+		return ImportReport{}, err
+	}
+	if err := imp.perfSummaries(archive.PerfSummaries); err != nil {
+		return ImportReport{}, err
+	}
+	return imp.report, nil
+}
+
+// accountImport хранит состояние одного импорта: соответствие ID архива и ID этого инстанса и счётчики
+type accountImport struct {
+	*ImportAccountUsecase
+	userID   string
+	strategy ImportStrategy
+	newID    func() string
+	entryIDs map[string]string
+	goalIDs  map[string]string
+	report   ImportReport
+}
+
+// newImportIDs возвращает генератор ID новых объектов импорта в обычном формате ID.
+// Время одно на весь импорт, а счётчик добавляет наносекунды, чтобы ID в одном импорте не совпадали
+func newImportIDs() func() string {
+	base := time.Now().UTC()
+	n := 0
+	return func() string {
+		n++
+		return base.Add(time.Duration(n)).Format("20060102150405.000000000")
+	}
+}
+
+// settings импортирует настройки профиля
+func (imp *accountImport) settings(settings *ArchiveSettings) error {
+	if settings == nil {
+		return nil
+	}
+
+	user, err := imp.usersRepo.GetByID(imp.userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		user = applyArchiveSettings(newDefaultUser(imp.userID), *settings)
+		if err := imp.usersRepo.Create(user); err != nil {
+			return err
+		}
+		imp.report.Settings.Created++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case toArchiveSettings(user) == *settings:
+		imp.report.Settings.Unchanged++
+	case imp.strategy == ImportStrategyOverwrite:
+		user = applyArchiveSettings(user, *settings)
+		user.UpdatedAt = time.Now().UTC()
+		if err := imp.usersRepo.Update(user); err != nil {
+			return err
+		}
+		imp.report.Settings.Updated++
+	default:
+		imp.report.Settings.Skipped++
+	}
+	return nil
+}
+
+// applyArchiveSettings переносит настройки архива в профиль
+func applyArchiveSettings(user repositories.User, settings ArchiveSettings) repositories.User {
+	user.Name = settings.Name
+	user.Role = settings.Role
+	user.PerfCycleLengthMonths = settings.PerfCycleLengthMonths
+	user.Locale = settings.Locale
+	return user
+}
+
+// entries импортирует записи. Запись за ту же дату того же типа может быть только одна,
+// поэтому keep_both дописывает текст из архива к существующей записи и объединяет теги
+func (imp *accountImport) entries(archived []ArchiveEntry) error {
+	for _, ae := range archived {
+		existing, found, err := imp.findEntry(ae.Date, ae.Type)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			entry, err := imp.entriesRepo.Create(repositories.Entry{
+				ID:        imp.newID(),
+				UserID:    imp.userID,
+				Date:      ae.Date,
+				Type:      ae.Type,
+				RawText:   ae.RawText,
+				CreatedAt: ae.CreatedAt,
+				UpdatedAt: time.Now().UTC(),
+			})
+			if err != nil {
+				return err
+			}
+			if err := imp.tagsRepo.SetEntryTags(imp.userID, entry.ID, ae.Tags); err != nil {
+				return err
+			}
+			if err := imp.restoreEnrichment(entry.ID, ae); err != nil {
+				return err
+			}
+			imp.entryIDs[ae.ID] = entry.ID
+			imp.report.Entries.Created++
+			continue
+		}
+
+		imp.entryIDs[ae.ID] = existing.ID
+		text, tags := ae.RawText, ae.Tags
+		switch {
+		case existing.RawText == ae.RawText && slices.Equal(existing.Tags, ae.Tags):
+			if existing.LLMEnriched == nil {
+				if err := imp.restoreEnrichment(existing.ID, ae); err != nil {
+					return err
+				}
+			}
+			imp.report.Entries.Unchanged++
+			continue
+		case imp.strategy == ImportStrategySkip:
+			imp.report.Entries.Skipped++
+			continue
+		case imp.strategy == ImportStrategyKeepBoth:
+			text, tags = mergeArchivedEntry(existing, ae)
+			if text == existing.RawText && slices.Equal(existing.Tags, tags) {
+				imp.report.Entries.Unchanged++
+				continue
+			}
+		}
+
+		if err := imp.entriesRepo.Update(imp.userID, repositories.Entry{ID: existing.ID, RawText: text, UpdatedAt: time.Now().UTC()}); err != nil {
+			return err
+		}
+		if err := imp.tagsRepo.SetEntryTags(imp.userID, existing.ID, tags); err != nil {
+			return err
+		}
+		if text == ae.RawText {
+			if err := imp.restoreEnrichment(existing.ID, ae); err != nil {
+				return err
+			}
+		}
+		imp.report.Entries.Updated++
+	}
+	return nil
+}
+
+// findEntry ищет запись пользователя за дату заданного типа и загружает её теги
+func (imp *accountImport) findEntry(date string, entryType repositories.EntryType) (repositories.Entry, bool, error) {
+	entries, err := imp.entriesRepo.ListByUserAndDate(imp.userID, date)
+	if err != nil {
+		return repositories.Entry{}, false, err
+	}
+	for _, e := range entries {
+		if e.Type != entryType {
+			continue
+		}
+		found := []repositories.Entry{e}
+		if err := withEntryTags(imp.tagsRepo, imp.userID, found); err != nil {
+			return repositories.Entry{}, false, err
+		}
+		return found[0], true, nil
+	}
+	return repositories.Entry{}, false, nil
+}
+
+// restoreEnrichment переносит обогащение из архива, чтобы не тратить на запись повторный вызов модели.
+// Если текст записи уже не совпадает с текстом из архива, обогащение не сохраняется
+func (imp *accountImport) restoreEnrichment(entryID string, ae ArchiveEntry) error {
+	if ae.LLMEnriched == nil {
+		return nil
+	}
+	err := imp.entriesRepo.SetEnrichment(imp.userID, entryID, ae.RawText, *ae.LLMEnriched)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// mergeArchivedEntry дописывает текст записи из архива к существующему, если его там ещё нет,
+// и добавляет теги из архива, пока их не больше maxTagsPerEntry
+func mergeArchivedEntry(existing repositories.Entry, ae ArchiveEntry) (string, []string) {
+	text := existing.RawText
+	if !strings.Contains(text, ae.RawText) {
+		text = strings.TrimRight(text, "\n") + "\n\n" + ae.RawText
+	}
+
+	tags := append([]string{}, existing.Tags...)
+	for _, t := range ae.Tags {
+		if len(tags) == maxTagsPerEntry {
+			break
+		}
+		if !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+	sort.Strings(tags)
+	return text, tags
+}
+
+// goals импортирует цели. keep_both создаёт копию цели с тем же названием
+func (imp *accountImport) goals(archived []ArchiveGoal) error {
+	existing, err := imp.goalsRepo.ListByUser(imp.userID, "")
+	if err != nil {
+		return err
+	}
+
+	for _, ag := range archived {
+		var candidates []repositories.Goal
+		for _, g := range existing {
+			if strings.EqualFold(g.Title, ag.Title) {
+				candidates = append(candidates, g)
+			}
+		}
+
+		if i := slices.IndexFunc(candidates, func(g repositories.Goal) bool {
+			return g.Title == ag.Title && g.Description == ag.Description && g.Status == ag.Status
+		}); i >= 0 {
+			imp.goalIDs[ag.ID] = candidates[i].ID
+			imp.report.Goals.Unchanged++
+			continue
+		}
+
+		switch {
+		case len(candidates) > 0 && imp.strategy == ImportStrategySkip:
+			imp.goalIDs[ag.ID] = candidates[0].ID
+			imp.report.Goals.Skipped++
+		case len(candidates) > 0 && imp.strategy == ImportStrategyOverwrite:
+			goal := candidates[0]
+			goal.Title = ag.Title
+			goal.Description = ag.Description
+			goal.Status = ag.Status
+			goal.UpdatedAt = time.Now().UTC()
+			if err := imp.goalsRepo.Update(imp.userID, goal); err != nil {
+				return err
+			}
+			imp.goalIDs[ag.ID] = goal.ID
+			imp.report.Goals.Updated++
+		default:
+			goal := repositories.Goal{
+				ID:          imp.newID(),
+				UserID:      imp.userID,
+				Title:       ag.Title,
+				Description: ag.Description,
+				Status:      ag.Status,
+				CreatedAt:   ag.CreatedAt,
+				UpdatedAt:   ag.UpdatedAt,
+			}
+			if err := imp.goalsRepo.Create(goal); err != nil {
+				return err
+			}
+			existing = append(existing, goal)
+			imp.goalIDs[ag.ID] = goal.ID
+			imp.report.Goals.Created++
+		}
+	}
+	return nil
+}
+
+// links импортирует связи целей и записей. Связь пары цель–запись может быть только одна,
+// поэтому при keep_both существующая связь остаётся как есть
+func (imp *accountImport) links(archived []ArchiveGoalEntryLink) error {
+	byGoal := make(map[string][]repositories.GoalEntryLink)
+	for _, al := range archived {
+		goalID, entryID := imp.goalIDs[al.GoalID], imp.entryIDs[al.EntryID]
+
+		links, ok := byGoal[goalID]
+		if !ok {
+			var err error
+			if links, err = imp.linksRepo.ListByGoal(imp.userID, goalID); err != nil {
+				return err
+			}
+			byGoal[goalID] = links
+		}
+
+		link := repositories.GoalEntryLink{
+			ID:             imp.newID(),
+			UserID:         imp.userID,
+			GoalID:         goalID,
+			EntryID:        entryID,
+			RelevanceScore: al.RelevanceScore,
+			Note:           al.Note,
+			CreatedAt:      al.CreatedAt,
+		}
+		if i := slices.IndexFunc(links, func(l repositories.GoalEntryLink) bool { return l.EntryID == entryID }); i >= 0 {
+			existing := links[i]
+			if existing.RelevanceScore == al.RelevanceScore && existing.Note == al.Note {
+				imp.report.GoalEntryLinks.Unchanged++
+				continue
+			}
+			if imp.strategy != ImportStrategyOverwrite {
+				imp.report.GoalEntryLinks.Skipped++
+				continue
+			}
+			if _, err := imp.linksRepo.Upsert(link); err != nil {
+				return err
+			}
+			links[i].RelevanceScore, links[i].Note = al.RelevanceScore, al.Note
+			imp.report.GoalEntryLinks.Updated++
+			continue
+		}
+
+		saved, err := imp.linksRepo.Upsert(link)
+		if err != nil {
+			return err
+		}
+		byGoal[goalID] = append(links, saved)
+		imp.report.GoalEntryLinks.Created++
+	}
+	return nil
+}
+
+// perfSummaries импортирует сохранённые перф-саммари. keep_both сохраняет копию саммари
+func (imp *accountImport) perfSummaries(archived []ArchivePerfSummary) error {
+	existing, err := imp.perfSummaryRepo.ListByUser(imp.userID)
+	if err != nil {
+		return err
+	}
+
+	for _, as := range archived {
+		content, err := perfSummaryContent(as)
+		if err != nil {
+			return err
+		}
+
+		var candidates []repositories.PerfSummary
+		unchanged := false
+		for _, s := range existing {
+			if s.PeriodStart != as.PeriodStart || s.PeriodEnd != as.PeriodEnd || !sameInstant(s.CreatedAt, as.CreatedAt) {
+				continue
+			}
+			sc, err := perfSummaryContent(toArchivePerfSummary(s))
+			if err != nil {
+				return err
+			}
+			if sc == content {
+				unchanged = true
+				break
+			}
+			candidates = append(candidates, s)
+		}
+
+		summary := repositories.PerfSummary{
+			ID:          imp.newID(),
+			UserID:      imp.userID,
+			PeriodStart: as.PeriodStart,
+			PeriodEnd:   as.PeriodEnd,
+			Tags:        as.Tags,
+			RawGoals:    as.RawGoals,
+			SummaryText: as.SummaryText,
+			Goals:       as.Goals,
+			CreatedAt:   as.CreatedAt,
+			UpdatedAt:   as.UpdatedAt,
+		}
+		switch {
+		case unchanged:
+			imp.report.PerfSummaries.Unchanged++
+		case len(candidates) > 0 && imp.strategy == ImportStrategySkip:
+			imp.report.PerfSummaries.Skipped++
+		case len(candidates) > 0 && imp.strategy == ImportStrategyOverwrite:
+			// У саммари нет частичного обновления: заменяем его целиком под тем же ID
+			summary.ID = candidates[0].ID
+			if err := imp.perfSummaryRepo.Update(summary); err != nil {
+				return err
+			}
+			imp.report.PerfSummaries.Updated++
+		default:
+			if err := imp.perfSummaryRepo.Create(summary); err != nil {
+				return err
+			}
+			existing = append(existing, summary)
+			imp.report.PerfSummaries.Created++
+		}
+	}
+	return nil
+}
+
+// perfSummaryContent сериализует содержимое саммари без ID и времени для сравнения
+func perfSummaryContent(s ArchivePerfSummary) (string, error) {
+	s.ID = ""
+	s.CreatedAt, s.UpdatedAt = time.Time{}, time.Time{}
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+// sameInstant сравнивает время с точностью до миллисекунды: Postgres хранит микросекунды, а архив
+// из in-memory хранилища — наносекунды, и после переноса время не должно считаться другим
+func sameInstant(a, b time.Time) bool {
+	return a.Sub(b).Abs() < time.Millisecond
+}
+
+// validateArchive проверяет архив и нормализует его объекты так же, как это делают обычные ручки
+func validateArchive(a *AccountArchive) error {
+	if a.Format != AccountArchiveFormat {
+		return fmt.Errorf("%w: format must be %q", ErrInvalidArchive, AccountArchiveFormat)
+	}
+	if a.Version < 1 || a.Version > AccountArchiveVersion {
+		return fmt.Errorf("%w: unsupported version %d, this instance supports up to %d", ErrInvalidArchive, a.Version, AccountArchiveVersion)
+	}
+
+	if a.Settings != nil {
+		if err := validateArchiveSettings(*a.Settings); err != nil {
+			return fmt.Errorf("%w: settings: %v", ErrInvalidArchive, err)
+		}
+	}
+
+	entryIDs := make(map[string]bool, len(a.Entries))
+	entryKeys := make(map[string]bool, len(a.Entries))
+	for i := range a.Entries {
+		e := &a.Entries[i]
+		if err := validateArchiveEntry(e); err != nil {
+			return fmt.Errorf("%w: entries[%d]: %v", ErrInvalidArchive, i, err)
+		}
+		key := e.Date + "/" + string(e.Type)
+		if entryKeys[key] {
+			return fmt.Errorf("%w: entries[%d]: duplicate %s entry for %s", ErrInvalidArchive, i, e.Type, e.Date)
+		}
+		entryKeys[key] = true
+		entryIDs[e.ID] = true
+	}
+
+	goalIDs := make(map[string]bool, len(a.Goals))
+	for i := range a.Goals {
+		g := &a.Goals[i]
+		title, err := validateGoalTitle(g.Title)
+		if err != nil {
+			return fmt.Errorf("%w: goals[%d]: %v", ErrInvalidArchive, i, err)
+		}
+		g.Title = title
+		g.Description = strings.TrimSpace(g.Description)
+		if g.Status == "" {
+			g.Status = repositories.GoalStatusDraft
+		}
+		if !isKnownGoalStatus(g.Status) {
+			return fmt.Errorf("%w: goals[%d]: %v", ErrInvalidArchive, i, ErrInvalidGoalStatus)
+		}
+		g.CreatedAt, g.UpdatedAt = orNow(g.CreatedAt), orNow(g.UpdatedAt)
+		goalIDs[g.ID] = true
+	}
+
+	for i := range a.GoalEntryLinks {
+		l := &a.GoalEntryLinks[i]
+		if !goalIDs[l.GoalID] || !entryIDs[l.EntryID] {
+			return fmt.Errorf("%w: goal_entry_links[%d]: goal_id and entry_id must reference goals and entries of the archive", ErrInvalidArchive, i)
+		}
+		if l.RelevanceScore < 0 || l.RelevanceScore > 1 {
+			return fmt.Errorf("%w: goal_entry_links[%d]: %v", ErrInvalidArchive, i, ErrInvalidRelevanceScore)
+		}
+		l.Note = strings.TrimSpace(l.Note)
+		l.CreatedAt = orNow(l.CreatedAt)
+	}
+
+	for i := range a.PerfSummaries {
+		s := &a.PerfSummaries[i]
+		if !isArchiveDate(s.PeriodStart) || !isArchiveDate(s.PeriodEnd) || s.PeriodStart > s.PeriodEnd {
+			return fmt.Errorf("%w: perf_summaries[%d]: period dates must be YYYY-MM-DD and period_start must not be after period_end", ErrInvalidArchive, i)
+		}
+		s.Tags, s.RawGoals = nonNilTags(s.Tags), nonNilTags(s.RawGoals)
+		if s.Goals == nil {
+			s.Goals = []repositories.PerfGoal{}
+		}
+		s.CreatedAt, s.UpdatedAt = orNow(s.CreatedAt), orNow(s.UpdatedAt)
+	}
+	return nil
+}
+
+// validateArchiveSettings проверяет настройки профиля по тем же правилам, что и UpdateUserProfileUsecase
+func validateArchiveSettings(s ArchiveSettings) error {
+	if utf8.RuneCountInString(s.Name) > maxUserNameLength {
+		return ErrUserNameTooLong
+	}
+	if !isKnownUserRole(s.Role) {
+		return ErrInvalidUserRole
+	}
+	if s.PerfCycleLengthMonths < minPerfCycleLengthMonths || s.PerfCycleLengthMonths > maxPerfCycleLengthMonths {
+		return ErrInvalidPerfCycleLength
+	}
+	if !userLocales[s.Locale] {
+		return ErrInvalidUserLocale
+	}
+	return nil
+}
+
+// validateArchiveEntry проверяет запись архива и нормализует её теги
+func validateArchiveEntry(e *ArchiveEntry) error {
+	if !isArchiveDate(e.Date) {
+		return errors.New("date must be YYYY-MM-DD")
+	}
+	if e.Type != repositories.EntryTypePlan && e.Type != repositories.EntryTypeFact {
+		return errors.New("type must be plan or fact")
+	}
+	if strings.TrimSpace(e.RawText) == "" {
+		return errors.New("raw_text is required")
+	}
+	tags, err := prepareEntryTags(nonNilTags(e.Tags))
+	if err != nil {
+		return err
+	}
+	e.Tags = tags
+	e.CreatedAt = orNow(e.CreatedAt)
+	return nil
+}
+
+// isArchiveDate проверяет формат даты YYYY-MM-DD
+func isArchiveDate(date string) bool {
+	_, err := time.Parse("2006-01-02", date)
+	return err == nil
+}
+
+// orNow подставляет текущее время вместо отсутствующего в архиве
+func orNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now().UTC()
+	}
+	return t
+}
@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+func TestImportAccountPerfSummaryConflict(t *testing.T) {
+	createdAt := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+	existing := repositories.PerfSummary{
+		ID:          "s1",
+		UserID:      "u1",
+		PeriodStart: "2026-01-01",
+		PeriodEnd:   "2026-01-31",
+		Tags:        []string{},
+		RawGoals:    []string{},
+		SummaryText: "Old draft",
+		Goals:       []repositories.PerfGoal{},
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+	// То же саммари, отредактированное на другом инстансе
+	archive := AccountArchive{
+		Format:  AccountArchiveFormat,
+		Version: AccountArchiveVersion,
+		PerfSummaries: []ArchivePerfSummary{{
+			ID:          "other-instance-id",
+			PeriodStart: "2026-01-01",
+			PeriodEnd:   "2026-01-31",
+			SummaryText: "Edited draft",
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt.Add(time.Hour),
+		}},
+	}
+
+	cases := []struct {
+		name     string
+		strategy ImportStrategy
+		want     ImportCounts
+		// wantTexts — тексты саммари пользователя после импорта; первым — саммари с ID s1
+		wantTexts []string
+	}{
+		{name: "skip", strategy: ImportStrategySkip, want: ImportCounts{Skipped: 1}, wantTexts: []string{"Old draft"}},
+		{name: "overwrite in place", strategy: ImportStrategyOverwrite, want: ImportCounts{Updated: 1}, wantTexts: []string{"Edited draft"}},
+		{name: "keep both", strategy: ImportStrategyKeepBoth, want: ImportCounts{Created: 1}, wantTexts: []string{"Old draft", "Edited draft"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			summaries := repositories.NewInMemoryPerfSummaryRepository()
+			if err := summaries.Create(existing); err != nil {
+				t.Fatal(err)
+			}
+			u := NewImportAccountUsecase(repositories.NewInMemoryEntriesRepository(), repositories.NewInMemoryTagsRepository(), repositories.NewInMemoryGoalsRepository(), repositories.NewInMemoryGoalEntryLinksRepository(), summaries, repositories.NewInMemoryUsersRepository())
+
+			report, err := u.Execute(ImportAccountCommand{UserID: "u1", Strategy: tc.strategy, Archive: archive})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.PerfSummaries != tc.want {
+				t.Fatalf("perf summaries = %+v, want %+v", report.PerfSummaries, tc.want)
+			}
+
+			list, err := summaries.ListByUser("u1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(list) != len(tc.wantTexts) {
+				t.Fatalf("got %d summaries, want %d", len(list), len(tc.wantTexts))
+			}
+			s1, err := summaries.GetByID("u1", "s1")
+			if err != nil {
+				t.Fatalf("summary s1 is gone: %v", err)
+			}
+			if s1.SummaryText != tc.wantTexts[0] {
+				t.Fatalf("summary s1 text = %q, want %q", s1.SummaryText, tc.wantTexts[0])
+			}
+		})
+	}
+}
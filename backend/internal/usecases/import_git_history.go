@@ -0,0 +1,319 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/gitlog"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// gitShortHashLength — длина сокращённого хеша коммита в тексте записи, как по умолчанию в git log --oneline
+const gitShortHashLength = 7
+
+var (
+	// ErrGitImportDisabled возвращается, если не задан каталог с репозиториями GIT_IMPORT_ROOT
+	ErrGitImportDisabled = errors.New("git import is disabled: GIT_IMPORT_ROOT is not set")
+	// ErrInvalidGitRepo возвращается, если repo_path не ведёт к git-репозиторию внутри GIT_IMPORT_ROOT.
+	// Ошибка оборачивается с указанием причины
+	ErrInvalidGitRepo = errors.New("repo_path must point to a git repository inside GIT_IMPORT_ROOT")
+	// ErrGitRefNotFound возвращается, если в репозитории нет указанной ветки, тега или коммита
+	ErrGitRefNotFound = errors.New("ref not found in the repository")
+	// ErrGitAuthorEmailRequired возвращается, если не указан email автора коммитов
+	ErrGitAuthorEmailRequired = errors.New("author_email is required")
+	// ErrInvalidGitImportPeriod возвращается для неверных границ периода
+	ErrInvalidGitImportPeriod = errors.New("from and to must be YYYY-MM-DD and from must not be after to")
+)
+
+// gitCommitRefPattern находит хеш коммита в скобках, которым заканчиваются импортированные строки
+var gitCommitRefPattern = regexp.MustCompile(`\(([0-9a-f]{7,40})\)`)
+
+// gitCommitLinePattern распознаёт строку импортированного коммита: "- subject (abc1234)"
+var gitCommitLinePattern = regexp.MustCompile(`^- .*\([0-9a-f]{7,40}\)$`)
+
+// ImportGitHistoryCommand представляет команду импорта коммитов в факты.
+// RepoPath — путь относительно GIT_IMPORT_ROOT или абсолютный путь внутри него; пустой Ref означает HEAD.
+// From и To необязательны и ограничивают дни коммитов. При Preview ничего не сохраняется
+type ImportGitHistoryCommand struct {
+	UserID      string
+	RepoPath    string
+	AuthorEmail string
+	Ref         string
+	From        string
+	To          string
+	Preview     bool
+}
+
+// GitImportCommit представляет коммит, найденный для импорта. Imported — коммит уже есть в факте дня
+type GitImportCommit struct {
+	Hash       string    `json:"hash"`
+	Subject    string    `json:"subject"`
+	AuthoredAt time.Time `json:"authored_at"`
+	Imported   bool      `json:"imported"`
+}
+
+// GitImportDay представляет день с коммитами автора и судьбу факта за этот день.
+// Статусы те же, что у импорта дневника: created, updated, unchanged
+type GitImportDay struct {
+	Date    string            `json:"date"`
+	Commits []GitImportCommit `json:"commits"`
+	Status  string            `json:"status"`
+}
+
+// GitImportResult представляет результат импорта истории коммитов
+type GitImportResult struct {
+	Preview   bool           `json:"preview"`
+	Days      []GitImportDay `json:"days"`
+	Created   int            `json:"created"`
+	Updated   int            `json:"updated"`
+	Unchanged int            `json:"unchanged"`
+}
+
+// ImportGitHistoryUsecase отвечает за создание фактов из истории локального git-репозитория.
+// Коммиты автора группируются по дням в его часовом поясе; каждый коммит становится строкой
+// "- subject (abc1234)" в факте дня. Коммиты, хеш которых уже есть в факте, пропускаются,
+// поэтому повторный импорт дописывает только новые коммиты, а уже импортированные дни не меняются.
+// Репозиторий читается прямо с диска; доступны только репозитории внутри root.
+// При perUser каждый пользователь видит только подкаталог root с именем своего ID,
+// иначе все пользователи видят все репозитории root
+type ImportGitHistoryUsecase struct {
+	repo        repositories.EntriesRepository
+	createEntry *CreateEntryUsecase
+	root        string
+	perUser     bool
+}
+
+// NewImportGitHistoryUsecase создает новый экземпляр ImportGitHistoryUsecase; пустой root отключает импорт
+func NewImportGitHistoryUsecase(repo repositories.EntriesRepository, createEntry *CreateEntryUsecase, root string, perUser bool) *ImportGitHistoryUsecase {
+	return &ImportGitHistoryUsecase{
+		repo:        repo,
+		createEntry: createEntry,
+		root:        root,
+		perUser:     perUser,
+	}
+}
+
+// Execute читает историю репозитория и сохраняет факты, если это не предпросмотр
+func (u *ImportGitHistoryUsecase) Execute(cmd ImportGitHistoryCommand) (GitImportResult, error) {
+	if u.root == "" {
+		return GitImportResult{}, ErrGitImportDisabled
+	}
+	email := strings.TrimSpace(cmd.AuthorEmail)
+	if email == "" {
+		return GitImportResult{}, ErrGitAuthorEmailRequired
+	}
+	if !validGitImportPeriod(cmd.From, cmd.To) {
+		return GitImportResult{}, ErrInvalidGitImportPeriod
+	}
+
+	commitsByDay, err := u.readCommits(cmd, email)
+	if err != nil {
+		return GitImportResult{}, err
+	}
+	result := GitImportResult{Preview: cmd.Preview, Days: []GitImportDay{}}
+	if len(commitsByDay) == 0 {
+		return result, nil
+	}
+
+	days := make([]string, 0, len(commitsByDay))
+	for day := range commitsByDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	existing, err := u.repo.ListByUserAndPeriod(cmd.UserID, days[0], days[len(days)-1])
+	if err != nil {
+		return GitImportResult{}, err
+	}
+	facts := make(map[string]string)
+	for _, e := range existing {
+		if e.Type == repositories.EntryTypeFact {
+			facts[entryDay(e)] = e.RawText
+		}
+	}
+
+	for _, day := range days {
+		text, found := facts[day]
+		imported := gitCommitRefs(text)
+
+		var lines []string
+		dayResult := GitImportDay{Date: day}
+		for _, c := range commitsByDay[day] {
+			hash := c.Hash.String()
+			commit := GitImportCommit{Hash: hash, Subject: c.Subject, AuthoredAt: c.AuthorTime, Imported: hasGitCommitRef(imported, hash)}
+			if !commit.Imported {
+				lines = append(lines, fmt.Sprintf("- %s (%s)", c.Subject, hash[:gitShortHashLength]))
+			}
+			dayResult.Commits = append(dayResult.Commits, commit)
+		}
+
+		switch {
+		case len(lines) == 0:
+			dayResult.Status = MarkdownImportUnchanged
+			result.Unchanged++
+		case !found:
+			dayResult.Status = MarkdownImportCreated
+			result.Created++
+		default:
+			dayResult.Status = MarkdownImportUpdated
+			result.Updated++
+		}
+		result.Days = append(result.Days, dayResult)
+
+		if cmd.Preview || len(lines) == 0 {
+			continue
+		}
+		_, err := u.createEntry.Execute(CreateEntryCommand{
+			UserID:  cmd.UserID,
+			Date:    day,
+			Type:    repositories.EntryTypeFact,
+			RawText: appendGitCommitLines(text, lines),
+		})
+		if err != nil {
+			return GitImportResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// readCommits читает коммиты автора из репозитория и группирует их по дням, в каждом дне — по времени
+func (u *ImportGitHistoryUsecase) readCommits(cmd ImportGitHistoryCommand, email string) (map[string][]gitlog.Commit, error) {
+	root, err := u.userRoot(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	path, err := repoPath(root, cmd.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+	// Каталоги, на которые ссылаются файлы репозитория, тоже обязаны быть внутри root
+	repo, err := gitlog.Open(path, root)
+	if errors.Is(err, gitlog.ErrNotRepository) || errors.Is(err, gitlog.ErrUnsupportedRepository) || errors.Is(err, gitlog.ErrOutsideRoot) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGitRepo, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer repo.Close()
+
+	ref := cmd.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	head, err := repo.Resolve(ref)
+	if errors.Is(err, gitlog.ErrRefNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrGitRefNotFound, ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+	commits, err := repo.Commits(head)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string][]gitlog.Commit)
+	for _, c := range commits {
+		day := c.AuthorTime.Format("2006-01-02")
+		if !strings.EqualFold(c.AuthorEmail, email) || (cmd.From != "" && day < cmd.From) || (cmd.To != "" && day > cmd.To) {
+			continue
+		}
+		byDay[day] = append(byDay[day], c)
+	}
+	for _, dayCommits := range byDay {
+		sort.Slice(dayCommits, func(i, j int) bool {
+			return dayCommits[i].AuthorTime.Before(dayCommits[j].AuthorTime)
+		})
+	}
+	return byDay, nil
+}
+
+// userRoot возвращает каталог репозиториев пользователя без симлинков: root или, при perUser, root/<userID>
+func (u *ImportGitHistoryUsecase) userRoot(userID string) (string, error) {
+	root := u.root
+	if u.perUser {
+		if userID == "" || userID == "." || userID == ".." || strings.ContainsAny(userID, `/\`) {
+			return "", ErrInvalidGitRepo
+		}
+		root = filepath.Join(root, userID)
+	}
+	resolved, err := filepath.EvalSymlinks(root)
+	if errors.Is(err, fs.ErrNotExist) && u.perUser {
+		return "", fmt.Errorf("%w: no repositories for the user", ErrInvalidGitRepo)
+	}
+	return resolved, err
+}
+
+// repoPath переводит repo_path в путь на диске и проверяет, что он не выходит за root, в том числе по симлинкам.
+// root должен быть без симлинков
+func repoPath(root, repoPath string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", ErrInvalidGitRepo
+	}
+	path := repoPath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s does not exist", ErrInvalidGitRepo, repoPath)
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", ErrInvalidGitRepo
+	}
+	return path, nil
+}
+
+// validGitImportPeriod проверяет необязательные границы периода
+func validGitImportPeriod(from, to string) bool {
+	for _, d := range []string{from, to} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return false
+		}
+	}
+	return from == "" || to == "" || from <= to
+}
+
+// gitCommitRefs возвращает хеши коммитов, упомянутые в тексте записи в скобках
+func gitCommitRefs(text string) []string {
+	var refs []string
+	for _, m := range gitCommitRefPattern.FindAllStringSubmatch(text, -1) {
+		refs = append(refs, m[1])
+	}
+	return refs
+}
+
+// hasGitCommitRef сообщает, упомянут ли коммит полным или сокращённым хешем
+func hasGitCommitRef(refs []string, hash string) bool {
+	for _, ref := range refs {
+		if strings.HasPrefix(hash, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendGitCommitLines дописывает строки коммитов к факту. Если факт уже заканчивается
+// импортированными коммитами, новые продолжают тот же список, иначе отделяются пустой строкой
+func appendGitCommitLines(text string, lines []string) string {
+	block := strings.Join(lines, "\n")
+	text = strings.TrimRight(text, " \t\r\n")
+	if text == "" {
+		return block
+	}
+	last := text[strings.LastIndex(text, "\n")+1:]
+	if gitCommitLinePattern.MatchString(strings.TrimSpace(last)) {
+		return text + "\n" + block
+	}
+	return text + "\n\n" + block
+}
@@ -0,0 +1,259 @@
+package usecases
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// gitFixture — git-репозиторий из loose-объектов, собранный без git: коммиты одной ветки main
+type gitFixture struct {
+	t      *testing.T
+	path   string
+	gitDir string
+	head   string
+}
+
+// newGitFixture создаёт пустой репозиторий в каталоге path
+func newGitFixture(t *testing.T, path string) *gitFixture {
+	t.Helper()
+	g := &gitFixture{t: t, path: path, gitDir: filepath.Join(path, ".git")}
+	for _, dir := range []string{"objects", "refs/heads"} {
+		if err := os.MkdirAll(filepath.Join(g.gitDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(g.gitDir, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// commit добавляет коммит поверх main и возвращает его хеш
+func (g *gitFixture) commit(email string, at time.Time, subject string) string {
+	g.t.Helper()
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "tree %s\n", strings.Repeat("4b", 20))
+	if g.head != "" {
+		fmt.Fprintf(&body, "parent %s\n", g.head)
+	}
+	fmt.Fprintf(&body, "author Dev <%s> %d +0300\n", email, at.Unix())
+	fmt.Fprintf(&body, "committer Dev <%s> %d +0300\n\n%s\n", email, at.Unix(), subject)
+
+	raw := append([]byte(fmt.Sprintf("commit %d\x00", body.Len())), body.Bytes()...)
+	sum := sha1.Sum(raw)
+	hash := hex.EncodeToString(sum[:])
+	var packed bytes.Buffer
+	w := zlib.NewWriter(&packed)
+	w.Write(raw)
+	w.Close()
+
+	dir := filepath.Join(g.gitDir, "objects", hash[:2])
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		g.t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hash[2:]), packed.Bytes(), 0o644); err != nil {
+		g.t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(g.gitDir, "refs", "heads", "main"), []byte(hash+"\n"), 0o644); err != nil {
+		g.t.Fatal(err)
+	}
+	g.head = hash
+	return hash
+}
+
+// gitImportAt возвращает время коммита: день января 2026 и час по Москве
+func gitImportAt(day, hour int) time.Time {
+	return time.Date(2026, 1, day, hour, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+}
+
+func newGitImportUsecase(root string, perUser bool) (*ImportGitHistoryUsecase, *repositories.InMemoryEntriesRepository) {
+	entries := repositories.NewInMemoryEntriesRepository()
+	create := NewCreateEntryUsecase(entries, repositories.NewInMemoryTagsRepository())
+	return NewImportGitHistoryUsecase(entries, create, root, perUser), entries
+}
+
+// gitImportFact возвращает текст факта пользователя за день
+func gitImportFact(t *testing.T, entries *repositories.InMemoryEntriesRepository, userID, date string) string {
+	t.Helper()
+	list, err := entries.ListByUserAndDate(userID, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range list {
+		if e.Type == repositories.EntryTypeFact {
+			return e.RawText
+		}
+	}
+	return ""
+}
+
+func TestImportGitHistoryDedupesByCommitHash(t *testing.T) {
+	root := t.TempDir()
+	g := newGitFixture(t, filepath.Join(root, "app"))
+	a := g.commit("dev@x.io", gitImportAt(5, 10), "Add login")
+	g.commit("other@x.io", gitImportAt(5, 11), "Not mine")
+	// 00:30 по Москве — ещё 5-е число в UTC, но 6-е в часовом поясе автора
+	b := g.commit("DEV@x.io", gitImportAt(6, 0).Add(30*time.Minute), "Fix logout")
+
+	u, entries := newGitImportUsecase(root, false)
+	cmd := ImportGitHistoryCommand{UserID: "u1", RepoPath: "app", AuthorEmail: "dev@x.io"}
+
+	preview := cmd
+	preview.Preview = true
+	result, err := u.Execute(preview)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 2 || gitImportFact(t, entries, "u1", "2026-01-05") != "" {
+		t.Fatalf("preview = %+v; it must count days and save nothing", result)
+	}
+
+	if _, err := u.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if got := gitImportFact(t, entries, "u1", "2026-01-05"); got != "- Add login ("+a[:7]+")" {
+		t.Fatalf("fact 2026-01-05 = %q", got)
+	}
+	if got := gitImportFact(t, entries, "u1", "2026-01-06"); got != "- Fix logout ("+b[:7]+")" {
+		t.Fatalf("fact 2026-01-06 = %q", got)
+	}
+
+	// Повторный импорт дописывает только новый коммит и не трогает импортированные дни
+	c := g.commit("dev@x.io", gitImportAt(6, 15), "Add tests")
+	result, err = u.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 0 || result.Updated != 1 || result.Unchanged != 1 {
+		t.Fatalf("re-import = %+v, want one updated and one unchanged day", result)
+	}
+	if got := gitImportFact(t, entries, "u1", "2026-01-05"); got != "- Add login ("+a[:7]+")" {
+		t.Fatalf("fact 2026-01-05 = %q", got)
+	}
+	want := "- Fix logout (" + b[:7] + ")\n- Add tests (" + c[:7] + ")"
+	if got := gitImportFact(t, entries, "u1", "2026-01-06"); got != want {
+		t.Fatalf("fact 2026-01-06 = %q, want %q", got, want)
+	}
+	for _, day := range result.Days {
+		for _, commit := range day.Commits {
+			if commit.Imported != (commit.Hash != c) {
+				t.Fatalf("commit %s imported = %v", commit.Subject, commit.Imported)
+			}
+		}
+	}
+}
+
+func TestImportGitHistoryKeepsManualFact(t *testing.T) {
+	root := t.TempDir()
+	g := newGitFixture(t, filepath.Join(root, "app"))
+	a := g.commit("dev@x.io", gitImportAt(5, 10), "Add login")
+	b := g.commit("dev@x.io", gitImportAt(5, 12), "Fix logout")
+
+	u, entries := newGitImportUsecase(root, false)
+	// Первый коммит уже упомянут в факте вручную, сокращённым хешем
+	manual := "Обсудили релиз\nдоделал (" + a[:10] + ")"
+	if _, err := u.createEntry.Execute(CreateEntryCommand{UserID: "u1", Date: "2026-01-05", Type: repositories.EntryTypeFact, RawText: manual}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := u.Execute(ImportGitHistoryCommand{UserID: "u1", RepoPath: "app", AuthorEmail: "dev@x.io"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Updated != 1 {
+		t.Fatalf("result = %+v, want the day updated", result)
+	}
+	want := manual + "\n\n- Fix logout (" + b[:7] + ")"
+	if got := gitImportFact(t, entries, "u1", "2026-01-05"); got != want {
+		t.Fatalf("fact = %q, want %q", got, want)
+	}
+}
+
+func TestImportGitHistoryPerUserRoot(t *testing.T) {
+	root := t.TempDir()
+	own := newGitFixture(t, filepath.Join(root, "u1", "app"))
+	own.commit("dev@x.io", gitImportAt(5, 10), "Own commit")
+	other := newGitFixture(t, filepath.Join(root, "u2", "secret"))
+	other.commit("dev@x.io", gitImportAt(5, 10), "Someone else's commit")
+	outside := newGitFixture(t, filepath.Join(t.TempDir(), "outside"))
+	outside.commit("dev@x.io", gitImportAt(5, 10), "Outside commit")
+
+	if err := os.Symlink(other.path, filepath.Join(root, "u1", "link-to-u2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside.path, filepath.Join(root, "u1", "link-outside")); err != nil {
+		t.Fatal(err)
+	}
+
+	u, _ := newGitImportUsecase(root, true)
+
+	t.Run("own repository", func(t *testing.T) {
+		for _, path := range []string{"app", filepath.Join(root, "u1", "app")} {
+			result, err := u.Execute(ImportGitHistoryCommand{UserID: "u1", RepoPath: path, AuthorEmail: "dev@x.io", Preview: true})
+			if err != nil {
+				t.Fatalf("repo_path %q: %v", path, err)
+			}
+			if len(result.Days) != 1 || result.Days[0].Commits[0].Subject != "Own commit" {
+				t.Fatalf("repo_path %q: %+v", path, result)
+			}
+		}
+	})
+
+	cases := []struct {
+		name     string
+		userID   string
+		repoPath string
+	}{
+		{name: "dot-dot to another user", userID: "u1", repoPath: "../u2/secret"},
+		{name: "absolute path to another user", userID: "u1", repoPath: filepath.Join(root, "u2", "secret")},
+		{name: "dot-dot outside the root", userID: "u1", repoPath: "../../" + filepath.Base(filepath.Dir(outside.path)) + "/outside"},
+		{name: "symlink to another user", userID: "u1", repoPath: "link-to-u2"},
+		{name: "symlink outside the root", userID: "u1", repoPath: "link-outside"},
+		{name: "user without a directory", userID: "u3", repoPath: "app"},
+		{name: "user id with dot-dot", userID: "..", repoPath: "u1/app"},
+		{name: "user id with a separator", userID: "u2/../u1", repoPath: "app"},
+		{name: "empty user id", userID: "", repoPath: "u1/app"},
+		{name: "empty repo path", userID: "u1", repoPath: " "},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := u.Execute(ImportGitHistoryCommand{UserID: tc.userID, RepoPath: tc.repoPath, AuthorEmail: "dev@x.io", Preview: true})
+			if !errors.Is(err, ErrInvalidGitRepo) {
+				t.Fatalf("Execute = %v, want ErrInvalidGitRepo", err)
+			}
+		})
+	}
+}
+
+func TestImportGitHistoryRepositoryLinksOutsideRoot(t *testing.T) {
+	root := t.TempDir()
+	outside := newGitFixture(t, filepath.Join(t.TempDir(), "outside"))
+	outside.commit("dev@x.io", gitImportAt(5, 10), "Outside commit")
+
+	// Рабочее дерево внутри root, а его .git ссылается на репозиторий снаружи
+	worktree := filepath.Join(root, "wt")
+	if err := os.MkdirAll(worktree, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+outside.gitDir+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	u, _ := newGitImportUsecase(root, false)
+	_, err := u.Execute(ImportGitHistoryCommand{UserID: "u1", RepoPath: "wt", AuthorEmail: "dev@x.io", Preview: true})
+	if !errors.Is(err, ErrInvalidGitRepo) {
+		t.Fatalf("Execute = %v, want ErrInvalidGitRepo", err)
+	}
+}
@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"errors"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/journal"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// maxMarkdownImportEntries ограничивает число записей в одном импорте: это около семи лет ежедневных планов и фактов
+const maxMarkdownImportEntries = 5000
+
+var (
+	// ErrNoMarkdownEntries возвращается, если в дневнике не нашлось ни одной записи под заголовком с датой
+	ErrNoMarkdownEntries = errors.New("no entries found: expected date headings like \"### December 15, 2025\" followed by **Plan:** or **Fact:** paragraphs")
+	// ErrTooManyMarkdownEntries возвращается, если в дневнике больше maxMarkdownImportEntries записей
+	ErrTooManyMarkdownEntries = errors.New("too many entries in one file, split it into several imports")
+)
+
+// Статусы записи импорта. При предпросмотре статус показывает, что произойдёт при импорте
+const (
+	MarkdownImportCreated   = "created"
+	MarkdownImportUpdated   = "updated"
+	MarkdownImportUnchanged = "unchanged"
+)
+
+// ImportMarkdownEntriesCommand представляет команду импорта дневника в Markdown.
+// При Preview записи только разбираются и сравниваются с существующими, ничего не сохраняется
+type ImportMarkdownEntriesCommand struct {
+	UserID   string
+	Markdown string
+	Preview  bool
+}
+
+// MarkdownImportEntry представляет запись дневника и её судьбу при импорте
+type MarkdownImportEntry struct {
+	Date    string                 `json:"date"`
+	Type    repositories.EntryType `json:"type"`
+	RawText string                 `json:"raw_text"`
+	Line    int                    `json:"line"`
+	Status  string                 `json:"status"`
+}
+
+// MarkdownImportResult представляет результат разбора и импорта дневника
+type MarkdownImportResult struct {
+	Preview   bool                  `json:"preview"`
+	Entries   []MarkdownImportEntry `json:"entries"`
+	Warnings  []journal.Warning     `json:"warnings"`
+	Created   int                   `json:"created"`
+	Updated   int                   `json:"updated"`
+	Unchanged int                   `json:"unchanged"`
+}
+
+// ImportMarkdownEntriesUsecase отвечает за импорт дневника в формате mock_entries.md.
+// Записи сохраняются так же, как через POST /entries: запись того же типа за ту же дату перезаписывается,
+// её теги не меняются. Записи, текст которых совпадает с уже сохранённым, не трогаются
+type ImportMarkdownEntriesUsecase struct {
+	repo        repositories.EntriesRepository
+	createEntry *CreateEntryUsecase
+}
+
+// NewImportMarkdownEntriesUsecase создает новый экземпляр ImportMarkdownEntriesUsecase
+func NewImportMarkdownEntriesUsecase(repo repositories.EntriesRepository, createEntry *CreateEntryUsecase) *ImportMarkdownEntriesUsecase {
+	return &ImportMarkdownEntriesUsecase{
+		repo:        repo,
+		createEntry: createEntry,
+	}
+}
+
+// Execute разбирает дневник и сохраняет найденные записи, если это не предпросмотр
+func (u *ImportMarkdownEntriesUsecase) Execute(cmd ImportMarkdownEntriesCommand) (MarkdownImportResult, error) {
+	parsed, warnings := journal.ParseMarkdown(cmd.Markdown)
+	if len(parsed) == 0 {
+		return MarkdownImportResult{}, ErrNoMarkdownEntries
+	}
+	if len(parsed) > maxMarkdownImportEntries {
+		return MarkdownImportResult{}, ErrTooManyMarkdownEntries
+	}
+
+	from, to := parsed[0].Date, parsed[0].Date
+	for _, e := range parsed {
+		from, to = min(from, e.Date), max(to, e.Date)
+	}
+	existing, err := u.repo.ListByUserAndPeriod(cmd.UserID, from, to)
+	if err != nil {
+		return MarkdownImportResult{}, err
+	}
+	texts := make(map[string]string, len(existing))
+	for _, e := range existing {
+		texts[entryDay(e)+"/"+string(e.Type)] = e.RawText
+	}
+
+	result := MarkdownImportResult{
+		Preview:  cmd.Preview,
+		Entries:  make([]MarkdownImportEntry, 0, len(parsed)),
+		Warnings: warnings,
+	}
+	if result.Warnings == nil {
+		result.Warnings = []journal.Warning{}
+	}
+	for _, e := range parsed {
+		entry := MarkdownImportEntry{Date: e.Date, Type: e.Type, RawText: e.RawText, Line: e.Line}
+
+		text, found := texts[e.Date+"/"+string(e.Type)]
+		switch {
+		case !found:
+			entry.Status = MarkdownImportCreated
+			result.Created++
+		case text == e.RawText:
+			entry.Status = MarkdownImportUnchanged
+			result.Unchanged++
+		default:
+			entry.Status = MarkdownImportUpdated
+			result.Updated++
+		}
+		result.Entries = append(result.Entries, entry)
+
+		if cmd.Preview || entry.Status == MarkdownImportUnchanged {
+			continue
+		}
+		_, err := u.createEntry.Execute(CreateEntryCommand{
+			UserID:  cmd.UserID,
+			Date:    e.Date,
+			Type:    e.Type,
+			RawText: e.RawText,
+		})
+		if err != nil {
+			return MarkdownImportResult{}, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// defaultRelevanceScore используется, если релевантность связи не передана
+const defaultRelevanceScore = 1.0
+
+var (
+	// ErrEntryNotFound возвращается, если привязываемая запись не найдена
+	ErrEntryNotFound = errors.New("entry not found")
+	// ErrInvalidRelevanceScore возвращается, если relevance_score вне диапазона [0, 1]
+	ErrInvalidRelevanceScore = errors.New("relevance score must be between 0 and 1")
+)
+
+// LinkGoalEntryCommand представляет команду для привязки записи к цели
+type LinkGoalEntryCommand struct {
+	UserID  string
+	GoalID  string
+	EntryID string
+	// RelevanceScore — nil означает значение по умолчанию
+	RelevanceScore *float64
+	Note           string
+}
+
+// LinkGoalEntryUsecase отвечает за привязку записей к целям
+type LinkGoalEntryUsecase struct {
+	goalsRepo   repositories.GoalsRepository
+	entriesRepo repositories.EntriesRepository
+	linksRepo   repositories.GoalEntryLinksRepository
+}
+
+// NewLinkGoalEntryUsecase создает новый экземпляр LinkGoalEntryUsecase
+func NewLinkGoalEntryUsecase(goalsRepo repositories.GoalsRepository, entriesRepo repositories.EntriesRepository, linksRepo repositories.GoalEntryLinksRepository) *LinkGoalEntryUsecase {
+	return &LinkGoalEntryUsecase{
+		goalsRepo:   goalsRepo,
+		entriesRepo: entriesRepo,
+		linksRepo:   linksRepo,
+	}
+}
+
+// Execute выполняет привязку записи к цели. Повторная привязка обновляет релевантность и заметку
+func (u *LinkGoalEntryUsecase) Execute(cmd LinkGoalEntryCommand) (repositories.GoalEntryLink, error) {
+	score := defaultRelevanceScore
+	if cmd.RelevanceScore != nil {
+		score = *cmd.RelevanceScore
+	}
+	if score < 0 || score > 1 {
+		return repositories.GoalEntryLink{}, ErrInvalidRelevanceScore
+	}
+
+	goal, err := u.goalsRepo.GetByID(cmd.UserID, cmd.GoalID)
+	if err != nil {
+		return repositories.GoalEntryLink{}, err
+	}
+
+	// Чужую запись привязать нельзя — для пользователя её не существует
+	entry, err := u.entriesRepo.GetByID(cmd.UserID, cmd.EntryID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return repositories.GoalEntryLink{}, fmt.Errorf("%w: %s", ErrEntryNotFound, cmd.EntryID)
+	}
+	if err != nil {
+		return repositories.GoalEntryLink{}, err
+	}
+
+	now := time.Now().UTC()
+	link := repositories.GoalEntryLink{
+		ID:             now.Format("20060102150405.000000000"),
+		UserID:         cmd.UserID,
+		GoalID:         goal.ID,
+		EntryID:        entry.ID,
+		RelevanceScore: score,
+		Note:           strings.TrimSpace(cmd.Note),
+		CreatedAt:      now,
+	}
+
+	return u.linksRepo.Upsert(link)
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ListAPITokensQuery представляет запрос для получения списка API-токенов
+type ListAPITokensQuery struct {
+	UserID string
+}
+
+// ListAPITokensUsecase отвечает за получение активных API-токенов пользователя
+type ListAPITokensUsecase struct {
+	repo repositories.APITokensRepository
+}
+
+// NewListAPITokensUsecase создает новый экземпляр ListAPITokensUsecase
+func NewListAPITokensUsecase(repo repositories.APITokensRepository) *ListAPITokensUsecase {
+	return &ListAPITokensUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет получение списка неотозванных токенов, новые первыми
+func (u *ListAPITokensUsecase) Execute(query ListAPITokensQuery) ([]repositories.APIToken, error) {
+	return u.repo.ListByUser(query.UserID)
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Лимиты выдачи журнала конфликтов синхронизации с ежедневными заметками
+const (
+	defaultDailyNoteConflictsLimit = 50
+	maxDailyNoteConflictsLimit     = 500
+)
+
+// ListDailyNoteConflictsQuery представляет запрос журнала конфликтов. Limit <= 0 означает значение по умолчанию
+type ListDailyNoteConflictsQuery struct {
+	UserID string
+	Limit  int
+}
+
+// ListDailyNoteConflictsUsecase отвечает за получение журнала конфликтов синхронизации с ежедневными заметками
+type ListDailyNoteConflictsUsecase struct {
+	repo repositories.DailyNotesSyncRepository
+}
+
+// NewListDailyNoteConflictsUsecase создает новый экземпляр ListDailyNoteConflictsUsecase
+func NewListDailyNoteConflictsUsecase(repo repositories.DailyNotesSyncRepository) *ListDailyNoteConflictsUsecase {
+	return &ListDailyNoteConflictsUsecase{
+		repo: repo,
+	}
+}
+
+// Execute возвращает последние конфликты пользователя, новые первыми
+func (u *ListDailyNoteConflictsUsecase) Execute(query ListDailyNoteConflictsQuery) ([]repositories.DailyNoteConflict, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultDailyNoteConflictsLimit
+	}
+	limit = min(limit, maxDailyNoteConflictsLimit)
+
+	return u.repo.ListConflicts(query.UserID, limit)
+}
@@ -4,35 +4,67 @@ import (
 	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
 )
 
-// ListEntriesQuery представляет запрос для получения списка записей
+// Границы периода, если from или to не заданы
+const (
+	minEntryDate = "0001-01-01"
+	maxEntryDate = "9999-12-31"
+)
+
+// ListEntriesQuery представляет запрос для получения списка записей.
+// Если Tags не пуст, возвращаются только записи хотя бы с одним из тегов
 type ListEntriesQuery struct {
 	From   string
 	To     string
 	UserID string
+	Tags   []string
 }
 
 // ListEntriesUsecase отвечает за получение списка записей
 type ListEntriesUsecase struct {
-	repo repositories.EntriesRepository
+	repo     repositories.EntriesRepository
+	tagsRepo repositories.TagsRepository
 }
 
 // NewListEntriesUsecase создает новый экземпляр ListEntriesUsecase
-func NewListEntriesUsecase(repo repositories.EntriesRepository) *ListEntriesUsecase {
+func NewListEntriesUsecase(repo repositories.EntriesRepository, tagsRepo repositories.TagsRepository) *ListEntriesUsecase {
 	return &ListEntriesUsecase{
-		repo: repo,
+		repo:     repo,
+		tagsRepo: tagsRepo,
 	}
 }
 
-// Execute выполняет получение списка записей
+// Execute выполняет получение списка записей пользователя
 func (u *ListEntriesUsecase) Execute(query ListEntriesQuery) ([]repositories.Entry, error) {
-	// Если заданы все три параметра и from == to — это запрос за один день
-	if query.UserID != "" && query.From != "" && query.To != "" && query.From == query.To {
-		return u.repo.ListByUserAndDate(query.UserID, query.From)
-	} else if query.UserID != "" && query.From != "" && query.To != "" {
-		// Запрос за период [from, to] для конкретного пользователя
-		return u.repo.ListByUserAndPeriod(query.UserID, query.From, query.To)
+	// Незаданная граница периода означает «без ограничения»
+	from, to := query.From, query.To
+	if from == "" {
+		from = minEntryDate
+	}
+	if to == "" {
+		to = maxEntryDate
+	}
+
+	tags, err := normalizeTags(query.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// from == to — это запрос за один день
+	var entries []repositories.Entry
+	if from == to {
+		entries, err = u.repo.ListByUserAndDate(query.UserID, from)
 	} else {
-		// Фоллбек: если параметры не заданы, возвращаем все записи
-		return u.repo.ListAll()
+		entries, err = u.repo.ListByUserAndPeriod(query.UserID, from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := withEntryTags(u.tagsRepo, query.UserID, entries); err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		entries = filterEntriesByTags(entries, tags)
 	}
+	return entries, nil
 }
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"errors"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// GoalEntry объединяет связь цели с записью и саму запись
+type GoalEntry struct {
+	Link  repositories.GoalEntryLink
+	Entry repositories.Entry
+}
+
+// ListGoalEntriesQuery представляет запрос для получения записей цели
+type ListGoalEntriesQuery struct {
+	UserID string
+	GoalID string
+}
+
+// ListGoalEntriesUsecase отвечает за получение записей, привязанных к цели
+type ListGoalEntriesUsecase struct {
+	goalsRepo   repositories.GoalsRepository
+	entriesRepo repositories.EntriesRepository
+	linksRepo   repositories.GoalEntryLinksRepository
+	tagsRepo    repositories.TagsRepository
+}
+
+// NewListGoalEntriesUsecase создает новый экземпляр ListGoalEntriesUsecase
+func NewListGoalEntriesUsecase(goalsRepo repositories.GoalsRepository, entriesRepo repositories.EntriesRepository, linksRepo repositories.GoalEntryLinksRepository, tagsRepo repositories.TagsRepository) *ListGoalEntriesUsecase {
+	return &ListGoalEntriesUsecase{
+		goalsRepo:   goalsRepo,
+		entriesRepo: entriesRepo,
+		linksRepo:   linksRepo,
+		tagsRepo:    tagsRepo,
+	}
+}
+
+// Execute выполняет получение записей цели, самые релевантные первыми
+func (u *ListGoalEntriesUsecase) Execute(query ListGoalEntriesQuery) ([]GoalEntry, error) {
+	if _, err := u.goalsRepo.GetByID(query.UserID, query.GoalID); err != nil {
+		return nil, err
+	}
+
+	links, err := u.linksRepo.ListByGoal(query.UserID, query.GoalID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]GoalEntry, 0, len(links))
+	entries := make([]repositories.Entry, 0, len(links))
+	for _, link := range links {
+		entry, err := u.entriesRepo.GetByID(query.UserID, link.EntryID)
+		// Запись могла исчезнуть в обход usecases — такую связь просто пропускаем
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, GoalEntry{Link: link})
+		entries = append(entries, entry)
+	}
+
+	if err := withEntryTags(u.tagsRepo, query.UserID, entries); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Entry = entries[i]
+	}
+
+	return result, nil
+}
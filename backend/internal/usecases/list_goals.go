@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ListGoalsQuery представляет запрос для получения списка целей
+type ListGoalsQuery struct {
+	UserID string
+	Status repositories.GoalStatus
+}
+
+// ListGoalsUsecase отвечает за получение списка целей
+type ListGoalsUsecase struct {
+	repo repositories.GoalsRepository
+}
+
+// NewListGoalsUsecase создает новый экземпляр ListGoalsUsecase
+func NewListGoalsUsecase(repo repositories.GoalsRepository) *ListGoalsUsecase {
+	return &ListGoalsUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет получение списка целей пользователя с опциональным фильтром по статусу
+func (u *ListGoalsUsecase) Execute(query ListGoalsQuery) ([]repositories.Goal, error) {
+	if query.Status != "" && !isKnownGoalStatus(query.Status) {
+		return nil, ErrInvalidGoalStatus
+	}
+	return u.repo.ListByUser(query.UserID, query.Status)
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ListPerfSummariesQuery представляет запрос для получения истории перф-саммари
+type ListPerfSummariesQuery struct {
+	UserID string
+}
+
+// ListPerfSummariesUsecase отвечает за получение истории перф-саммари
+type ListPerfSummariesUsecase struct {
+	repo repositories.PerfSummaryRepository
+}
+
+// NewListPerfSummariesUsecase создает новый экземпляр ListPerfSummariesUsecase
+func NewListPerfSummariesUsecase(repo repositories.PerfSummaryRepository) *ListPerfSummariesUsecase {
+	return &ListPerfSummariesUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет получение истории перф-саммари пользователя
+func (u *ListPerfSummariesUsecase) Execute(query ListPerfSummariesQuery) ([]repositories.PerfSummary, error) {
+	return u.repo.ListByUser(query.UserID)
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ListRelatedEntriesQuery представляет запрос записей, похожих на запись EntryID.
+// Limit <= 0 означает значение по умолчанию
+type ListRelatedEntriesQuery struct {
+	UserID  string
+	EntryID string
+	Limit   int
+}
+
+// ListRelatedEntriesUsecase отвечает за подбор записей, близких по смыслу к данной
+type ListRelatedEntriesUsecase struct {
+	entries    repositories.EntriesRepository
+	embeddings repositories.EntryEmbeddingsRepository
+	tagsRepo   repositories.TagsRepository
+	embedder   llm.Embedder
+}
+
+// NewListRelatedEntriesUsecase создает новый экземпляр ListRelatedEntriesUsecase.
+// embedder может быть nil — тогда Execute возвращает ErrSemanticSearchDisabled
+func NewListRelatedEntriesUsecase(entries repositories.EntriesRepository, embeddings repositories.EntryEmbeddingsRepository, tagsRepo repositories.TagsRepository, embedder llm.Embedder) *ListRelatedEntriesUsecase {
+	return &ListRelatedEntriesUsecase{
+		entries:    entries,
+		embeddings: embeddings,
+		tagsRepo:   tagsRepo,
+		embedder:   embedder,
+	}
+}
+
+// Execute возвращает записи пользователя, самые похожие на данную, по убыванию близости
+func (u *ListRelatedEntriesUsecase) Execute(ctx context.Context, query ListRelatedEntriesQuery) ([]SimilarEntry, error) {
+	if u.embedder == nil {
+		return nil, ErrSemanticSearchDisabled
+	}
+
+	// Чужая запись для репозитория не существует
+	entry, err := u.entries.GetByID(query.UserID, query.EntryID)
+	if err != nil {
+		return nil, err
+	}
+
+	vector, err := entryVector(ctx, u.embeddings, u.embedder, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	similar, err := u.embeddings.Nearest(query.UserID, u.embedder.Model(), vector, similarLimit(query.Limit), entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	return loadSimilarEntries(u.entries, u.tagsRepo, query.UserID, similar)
+}
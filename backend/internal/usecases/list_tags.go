@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ListTagsQuery представляет запрос для получения тегов пользователя.
+// Prefix — начало тега для автодополнения; пустой возвращает все теги
+type ListTagsQuery struct {
+	UserID string
+	Prefix string
+}
+
+// ListTagsUsecase отвечает за получение тегов с числом записей
+type ListTagsUsecase struct {
+	repo repositories.TagsRepository
+}
+
+// NewListTagsUsecase создает новый экземпляр ListTagsUsecase
+func NewListTagsUsecase(repo repositories.TagsRepository) *ListTagsUsecase {
+	return &ListTagsUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет получение тегов пользователя, самые частые первыми
+func (u *ListTagsUsecase) Execute(query ListTagsQuery) ([]repositories.TagCount, error) {
+	counts, err := u.repo.ListCounts(query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Префикс приводим к виду тегов, чтобы "#Tech Debt" находил "tech-debt"
+	prefix := strings.TrimPrefix(strings.TrimSpace(query.Prefix), "#")
+	prefix = strings.Join(strings.Fields(strings.ToLower(prefix)), "-")
+	if prefix == "" {
+		return counts, nil
+	}
+
+	result := []repositories.TagCount{}
+	for _, tc := range counts {
+		if strings.HasPrefix(tc.Name, prefix) {
+			result = append(result, tc)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// ErrInvalidCredentials возвращается при неверном email или пароле.
+// Причина намеренно не уточняется, чтобы по ответу нельзя было перебирать зарегистрированные адреса
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// dummyPasswordHash сравнивается с паролем для неизвестного email,
+// чтобы время ответа не выдавало, зарегистрирован ли адрес
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("perf-assist-dummy-password"), bcrypt.DefaultCost)
+
+// LoginUserCommand представляет команду для входа по email и паролю
+type LoginUserCommand struct {
+	Email    string
+	Password string
+}
+
+// LoginUserUsecase отвечает за вход пользователя и выдачу сессии
+type LoginUserUsecase struct {
+	usersRepo       repositories.UsersRepository
+	credentialsRepo repositories.CredentialsRepository
+	tokens          *auth.SessionTokens
+}
+
+// NewLoginUserUsecase создает новый экземпляр LoginUserUsecase
+func NewLoginUserUsecase(usersRepo repositories.UsersRepository, credentialsRepo repositories.CredentialsRepository, tokens *auth.SessionTokens) *LoginUserUsecase {
+	return &LoginUserUsecase{
+		usersRepo:       usersRepo,
+		credentialsRepo: credentialsRepo,
+		tokens:          tokens,
+	}
+}
+
+// Execute выполняет проверку пароля и выдаёт новый сессионный токен
+func (u *LoginUserUsecase) Execute(cmd LoginUserCommand) (AuthSession, error) {
+	email, err := normalizeEmail(cmd.Email)
+	if err != nil {
+		return AuthSession{}, ErrInvalidCredentials
+	}
+
+	credentials, err := u.credentialsRepo.GetByEmail(email)
+	if errors.Is(err, repositories.ErrNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(cmd.Password))
+		return AuthSession{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return AuthSession{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(credentials.PasswordHash), []byte(cmd.Password)); err != nil {
+		return AuthSession{}, ErrInvalidCredentials
+	}
+
+	user, err := loadUserProfile(u.usersRepo, credentials.UserID)
+	if err != nil {
+		return AuthSession{}, err
+	}
+
+	token, expiresAt := u.tokens.Issue(user.ID)
+	return AuthSession{Token: token, ExpiresAt: expiresAt, User: user}, nil
+}
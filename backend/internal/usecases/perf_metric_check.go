@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// numericClaimPattern находит числа вместе с валютой, приближением и множителем:
+// "40%", "~$120k", "120 тыс. руб", "1,5 часа", "2 000 запросов", "3x".
+// Число не должно начинаться сразу после буквы или цифры, чтобы не ловить "k8s", "S3", "v2".
+var numericClaimPattern = regexp.MustCompile(
+	`(?i)(?:^|[^\p{L}\d.,])` +
+		`((?:[~≈]\s*)?(?:[$€₽£]\s*)?` +
+		`(\d{1,3}(?:[  ]\d{3})+(?:[.,]\d+)?|\d+(?:[.,]\d+)?)` +
+		`(?:(k|к|m|м|b)(?:\P{L}|$)|\s?(тыс\p{L}*\.?|млн\.?|млрд\.?|thousands?|millions?|billions?|mln|bn)(?:\P{L}|$))?` +
+		`\s?(%|процент\p{L}*|percent|x|х|раз\p{L}*)?)`,
+)
+
+// numericNoisePattern — даты и время, которые не являются метриками и вырезаются до поиска чисел
+var numericNoisePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}|\d{1,2}\.\d{1,2}\.\d{2,4}|\d{1,2}:\d{2}`)
+
+// yearBeforePattern — контекст перед числом, в котором четыре цифры означают год: "в 2025", "in 2025",
+// "since 2020", "Q3 2025", "H1 2025", "марта 2025", "March 2025"
+var yearBeforePattern = regexp.MustCompile(
+	`(?i)(?:^|[^\p{L}\d])(?:в|во|in|since|q[1-4]|h[12]|` +
+		`(?:январ|феврал|март|апрел|ма[йяе]|июн|июл|август|сентябр|октябр|ноябр|декабр|` +
+		`jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)\p{L}*\.?),?\s+$`,
+)
+
+// yearAfterPattern — контекст после числа, в котором четыре цифры означают год: "2025 год", "2025 г."
+var yearAfterPattern = regexp.MustCompile(`(?i)^\s?(?:год\p{L}*|гг?\.|г(?:\P{L}|$))`)
+
+// numberWordPattern находит числа словами: "в два раза", "three services". "Один" и "one" не считаются,
+// они слишком часто означают не количество
+var numberWordPattern = regexp.MustCompile(`(?i)(?:^|[^\p{L}\d])(\p{L}+)`)
+
+// numberWords — значения чисел, записанных словами
+var numberWords = map[string]float64{
+	"два": 2, "две": 2, "двух": 2, "двум": 2, "двумя": 2, "вдвое": 2, "two": 2, "twice": 2,
+	"три": 3, "трёх": 3, "трех": 3, "трём": 3, "трем": 3, "тремя": 3, "втрое": 3, "three": 3,
+	"четыре": 4, "четырёх": 4, "четырех": 4, "четырьмя": 4, "вчетверо": 4, "four": 4,
+	"пять": 5, "пяти": 5, "пятью": 5, "five": 5,
+	"шесть": 6, "шести": 6, "шестью": 6, "six": 6,
+	"семь": 7, "семи": 7, "семью": 7, "seven": 7,
+	"восемь": 8, "восьми": 8, "восемью": 8, "eight": 8,
+	"девять": 9, "девяти": 9, "девятью": 9, "nine": 9,
+	"десять": 10, "десяти": 10, "десятью": 10, "ten": 10,
+}
+
+// numericClaim — число, найденное в тексте, и его значение после нормализации
+type numericClaim struct {
+	Raw   string
+	Value float64
+	Year  bool
+}
+
+// extractNumericClaims извлекает числовые утверждения из текста
+func extractNumericClaims(text string) []numericClaim {
+	var claims []numericClaim
+	text = numericNoisePattern.ReplaceAllString(text, " ")
+	for _, idx := range numericClaimPattern.FindAllStringSubmatchIndex(text, -1) {
+		m := make([]string, len(idx)/2)
+		for i := range m {
+			if idx[2*i] >= 0 {
+				m[i] = text[idx[2*i]:idx[2*i+1]]
+			}
+		}
+		value, ok := parseClaimNumber(m[2])
+		if !ok {
+			continue
+		}
+
+		multiplier := strings.ToLower(m[3] + m[4])
+		switch {
+		case multiplier == "k" || multiplier == "к" || strings.HasPrefix(multiplier, "тыс") || strings.HasPrefix(multiplier, "thousand"):
+			value *= 1e3
+		case multiplier == "m" || multiplier == "м" || strings.HasPrefix(multiplier, "млн") || strings.HasPrefix(multiplier, "million") || multiplier == "mln":
+			value *= 1e6
+		case multiplier == "b" || multiplier == "bn" || strings.HasPrefix(multiplier, "млрд") || strings.HasPrefix(multiplier, "billion"):
+			value *= 1e9
+		}
+
+		raw := strings.TrimSpace(m[1])
+		claims = append(claims, numericClaim{
+			Raw:   raw,
+			Value: value,
+			// Год — это дата, а не метрика, но только в контексте даты: "обработали 2000 заявок" — метрика
+			Year: raw == m[2] && isYearContext(text[:idx[4]], m[2], text[idx[5]:]),
+		})
+	}
+	for _, idx := range numberWordPattern.FindAllStringSubmatchIndex(text, -1) {
+		word := text[idx[2]:idx[3]]
+		if value, ok := numberWords[strings.ToLower(word)]; ok {
+			claims = append(claims, numericClaim{Raw: word, Value: value})
+		}
+	}
+	return claims
+}
+
+// isYearContext сообщает, что число — год: ровно четыре цифры без разделителей в пределах 1900–2100
+// рядом с предлогом, кварталом, месяцем или словом "год"
+func isYearContext(before, number, after string) bool {
+	if len(number) != 4 || number < "1900" || number > "2100" {
+		return false
+	}
+	return yearBeforePattern.MatchString(before) || yearAfterPattern.MatchString(after)
+}
+
+// parseClaimNumber разбирает число с разделителями разрядов и десятичной запятой или точкой.
+// Запятая, за которой ровно три цифры ("1,500"), считается разделителем разрядов.
+func parseClaimNumber(s string) (float64, bool) {
+	s = strings.NewReplacer(" ", "", " ", "").Replace(s)
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		if len(s)-i-1 == 3 && !strings.Contains(s, ".") {
+			s = strings.Replace(s, ",", "", 1)
+		} else {
+			s = strings.Replace(s, ",", ".", 1)
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	return value, err == nil
+}
+
+// unsupportedMetrics возвращает числа из текста пункта, которых нет ни в одном из исходных текстов
+func unsupportedMetrics(text string, sources []string) []string {
+	var known []float64
+	for _, source := range sources {
+		for _, c := range extractNumericClaims(source) {
+			known = append(known, c.Value)
+		}
+	}
+
+	unsupported := []string{}
+	for _, claim := range extractNumericClaims(text) {
+		if claim.Year || containsValue(known, claim.Value) {
+			continue
+		}
+		unsupported = append(unsupported, claim.Raw)
+	}
+	return unsupported
+}
+
+// containsValue сравнивает значения с учётом погрешности float
+func containsValue(values []float64, v float64) bool {
+	for _, known := range values {
+		if math.Abs(known-v) <= 1e-9*math.Max(1, math.Abs(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPerfGoalMetrics помечает в пунктах цели числа, которые не встречаются в исходных записях.
+// Пункт сверяется с записями, на которые он ссылается; пункт без источников — со всеми записями периода.
+func checkPerfGoalMetrics(goal *repositories.PerfGoal, entries []repositories.Entry) {
+	byID := make(map[string]string, len(entries))
+	all := make([]string, 0, len(entries))
+	for _, e := range entries {
+		byID[e.ID] = e.RawText
+		all = append(all, e.RawText)
+	}
+
+	check := func(bullets []string, evidence []repositories.PerfEvidence) {
+		for i := range evidence {
+			if i >= len(bullets) {
+				break
+			}
+			sources := all
+			if len(evidence[i].Sources) > 0 {
+				sources = make([]string, 0, len(evidence[i].Sources))
+				for _, s := range evidence[i].Sources {
+					sources = append(sources, byID[s.EntryID])
+				}
+			}
+			evidence[i].UnsupportedMetrics = unsupportedMetrics(bullets[i], sources)
+		}
+	}
+
+	check(goal.Outputs, goal.OutputEvidence)
+	check(goal.Outcomes, goal.OutcomeEvidence)
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnsupportedMetrics(t *testing.T) {
+	cases := []struct {
+		name    string
+		text    string
+		sources []string
+		want    []string
+	}{
+		// Проценты
+		{name: "ru percent supported", text: "Сократили время сборки на 40%", sources: []string{"сборка стала быстрее на 40 %"}, want: []string{}},
+		{name: "ru percent word", text: "Рост конверсии на 15 процентов", sources: []string{"конверсия +15%"}, want: []string{}},
+		{name: "en percent invented", text: "Reduced latency by 35%", sources: []string{"reduced latency by 30%"}, want: []string{"35%"}},
+		{name: "decimal comma vs dot", text: "Uptime 99.9%", sources: []string{"аптайм 99,9%"}, want: []string{}},
+
+		// Длительности
+		{name: "ru duration supported", text: "Время ответа снизилось до 1,5 часа", sources: []string{"теперь отчёт строится 1.5 часа"}, want: []string{}},
+		{name: "en duration invented", text: "Cut deploy time to 12 minutes", sources: []string{"deploy takes 15 minutes now"}, want: []string{"12"}},
+
+		// Деньги
+		{name: "money k vs тыс", text: "Сэкономили ~$120k в месяц", sources: []string{"экономия 120 тыс. долларов"}, want: []string{}},
+		{name: "money invented", text: "Saved ~$120k per month", sources: []string{"moved billing to spot instances"}, want: []string{"~$120k"}},
+		{name: "money millions", text: "Выручка 2 млн руб", sources: []string{"принесли $2m"}, want: []string{}},
+
+		// Количества
+		{name: "ru count thousands separator", text: "Обработали 2 000 запросов", sources: []string{"разобрали 2000 запросов"}, want: []string{}},
+		{name: "en count comma separator", text: "Handled 1,500 tickets", sources: []string{"closed 1500 tickets"}, want: []string{}},
+		{name: "multiplier x", text: "Ускорили импорт в 3x", sources: []string{"импорт стал быстрее в 3 раза"}, want: []string{}},
+		{name: "identifiers are not numbers", text: "Migrated k8s and S3 to v2", sources: nil, want: []string{}},
+		{name: "dates and times are not numbers", text: "Релиз 2025-03-14 в 10:30", sources: nil, want: []string{}},
+
+		// Числа словами
+		{name: "ru word multiplier supported", text: "Ускорили поиск в два раза", sources: []string{"поиск стал в 2 раза быстрее"}, want: []string{}},
+		{name: "ru word multiplier invented", text: "Ускорили поиск в два раза", sources: []string{"ускорили поиск"}, want: []string{"два"}},
+		{name: "en word count supported", text: "Onboarded three new teams", sources: []string{"подключили 3 команды"}, want: []string{}},
+		{name: "en word count invented", text: "Onboarded three new teams", sources: []string{"onboarded the platform team"}, want: []string{"three"}},
+		{name: "one is not a claim", text: "One of the key services", sources: nil, want: []string{}},
+
+		// Годы и количества в диапазоне лет
+		{name: "ru year after preposition", text: "В 2025 запустили биллинг", sources: nil, want: []string{}},
+		{name: "ru year before год", text: "Итоги 2024 года", sources: nil, want: []string{}},
+		{name: "ru year before г.", text: "План на 2025 г. выполнен", sources: nil, want: []string{}},
+		{name: "ru year after month", text: "Запуск в марте 2025", sources: nil, want: []string{}},
+		{name: "en year in", text: "In 2025 we shipped billing", sources: nil, want: []string{}},
+		{name: "en year after quarter", text: "Delivered in Q3 2025", sources: nil, want: []string{}},
+		{name: "en year after month", text: "Launched March 2025", sources: nil, want: []string{}},
+		{name: "ru count in year range", text: "Обработали 2000 заявок", sources: []string{"обработали заявки"}, want: []string{"2000"}},
+		{name: "en count in year range", text: "Handled 1950 RPS", sources: []string{"handled peak load"}, want: []string{"1950"}},
+		{name: "en thousands separator is never a year", text: "Handled 2 000 RPS", sources: []string{"handled peak load"}, want: []string{"2 000"}},
+		{name: "ru thousands separator after preposition", text: "Выросли в 2 000 заказов", sources: nil, want: []string{"2 000"}},
+		{name: "year out of range", text: "В 2500 регионах", sources: nil, want: []string{"2500"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := unsupportedMetrics(tc.text, tc.sources)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unsupportedMetrics(%q) = %q, want %q", tc.text, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// PerfSummaryEventType описывает этап генерации перф-саммари
+type PerfSummaryEventType string
+
+const (
+	PerfSummaryEventEntriesLoaded   PerfSummaryEventType = "entries_loaded"
+	PerfSummaryEventChunkSummarized PerfSummaryEventType = "chunk_summarized"
+	PerfSummaryEventToken           PerfSummaryEventType = "token"
+	PerfSummaryEventGoalDrafted     PerfSummaryEventType = "goal_drafted"
+)
+
+// PerfSummaryEvent представляет событие прогресса генерации перф-саммари.
+// Заполняются только поля, относящиеся к типу события.
+type PerfSummaryEvent struct {
+	Type         PerfSummaryEventType
+	EntriesCount int
+	Chunk        int
+	TotalChunks  int
+	Token        string
+	Goal         *repositories.PerfGoal
+}
+
+// PerfSummaryProgressFunc получает события прогресса генерации
+type PerfSummaryProgressFunc func(event PerfSummaryEvent)
+
+// goalStreamScanner выделяет завершённые объекты целей из потока JSON
+// вида {"goals":[{...},{...}]}, не дожидаясь конца ответа модели.
+type goalStreamScanner struct {
+	buf      []byte
+	depth    int
+	inString bool
+	escaped  bool
+	start    int
+}
+
+// Feed добавляет фрагмент ответа и возвращает JSON целей, объекты которых завершились в нём
+func (s *goalStreamScanner) Feed(token string) []json.RawMessage {
+	var goals []json.RawMessage
+	for i := 0; i < len(token); i++ {
+		ch := token[i]
+		s.buf = append(s.buf, ch)
+
+		if s.inString {
+			switch {
+			case s.escaped:
+				s.escaped = false
+			case ch == '\\':
+				s.escaped = true
+			case ch == '"':
+				s.inString = false
+			}
+			continue
+		}
+
+		switch ch {
+		case '"':
+			s.inString = true
+		case '{':
+			s.depth++
+			// Глубина 2 — объект цели внутри массива goals корневого объекта
+			if s.depth == 2 {
+				s.start = len(s.buf) - 1
+			}
+		case '}':
+			if s.depth == 2 {
+				goal := make(json.RawMessage, len(s.buf)-s.start)
+				copy(goal, s.buf[s.start:])
+				goals = append(goals, goal)
+			}
+			s.depth--
+		}
+	}
+	return goals
+}
@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// evidenceLine — строка входа модели вместе с записями, из которых она получена.
+// Для исходной записи это она сама, для пункта недельного саммари — записи, на которые он сослался.
+type evidenceLine struct {
+	Date    string
+	Type    repositories.EntryType
+	Text    string
+	Sources []repositories.PerfEvidenceSource
+}
+
+// evidenceIndex сопоставляет метки строк входа модели ([R1], [R2], ...) с записями-источниками
+type evidenceIndex struct {
+	byLabel map[string][]repositories.PerfEvidenceSource
+	byDate  map[string][]repositories.PerfEvidenceSource
+}
+
+// evidenceDatePattern — модели иногда ссылаются на дату вместо метки
+var evidenceDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// newEvidenceIndex создает пустой индекс источников
+func newEvidenceIndex() *evidenceIndex {
+	return &evidenceIndex{
+		byLabel: make(map[string][]repositories.PerfEvidenceSource),
+		byDate:  make(map[string][]repositories.PerfEvidenceSource),
+	}
+}
+
+// add регистрирует строку и возвращает её метку
+func (x *evidenceIndex) add(line evidenceLine) string {
+	label := fmt.Sprintf("R%d", len(x.byLabel)+1)
+	x.byLabel[label] = line.Sources
+	x.byDate[line.Date] = append(x.byDate[line.Date], line.Sources...)
+	return label
+}
+
+// resolve превращает ссылки из ответа модели в записи-источники.
+// Ссылки на метки или даты, которых не было во входе, отбрасываются.
+func (x *evidenceIndex) resolve(refs []string) []repositories.PerfEvidenceSource {
+	seen := make(map[string]bool)
+	sources := []repositories.PerfEvidenceSource{}
+	for _, ref := range refs {
+		ref = strings.Trim(strings.TrimSpace(ref), "[]")
+		found, ok := x.byLabel[strings.ToUpper(ref)]
+		if !ok && evidenceDatePattern.MatchString(ref) {
+			found = x.byDate[ref]
+		}
+		for _, s := range found {
+			if !seen[s.EntryID] {
+				seen[s.EntryID] = true
+				sources = append(sources, s)
+			}
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].Date < sources[j].Date
+	})
+	return sources
+}
+
+// evidence формирует источники пункта перф-саммари, помечая пункты без подтверждения
+func (x *evidenceIndex) evidence(refs []string) repositories.PerfEvidence {
+	sources := x.resolve(refs)
+	return repositories.PerfEvidence{
+		Sources:   sources,
+		Unsourced: len(sources) == 0,
+	}
+}
+
+// entryEvidenceLines превращает записи в строки входа модели; каждая запись подтверждает сама себя
+func entryEvidenceLines(entries []repositories.Entry) []evidenceLine {
+	lines := make([]evidenceLine, 0, len(entries))
+	for _, e := range entries {
+		day := entryDay(e)
+		lines = append(lines, evidenceLine{
+			Date:    day,
+			Type:    e.Type,
+			Text:    e.RawText,
+			Sources: []repositories.PerfEvidenceSource{{EntryID: e.ID, Date: day}},
+		})
+	}
+	return lines
+}
+
+// writeEvidenceLines пишет строки "- YYYY-MM-DD (type) [Rn]: текст" и регистрирует их метки в индексе
+func writeEvidenceLines(b *strings.Builder, lines []evidenceLine, index *evidenceIndex) {
+	for _, line := range lines {
+		text := strings.Join(strings.Fields(line.Text), " ")
+		fmt.Fprintf(b, "- %s (%s) [%s]: %s\n", line.Date, line.Type, index.add(line), text)
+	}
+}
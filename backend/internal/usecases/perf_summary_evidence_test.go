@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/prompts"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// evidenceIDs возвращает источники пункта строкой "entry_id@date ..."; "unsourced" — пункт без источников
+func evidenceIDs(e repositories.PerfEvidence) string {
+	if e.Unsourced {
+		return "unsourced"
+	}
+	parts := make([]string, 0, len(e.Sources))
+	for _, s := range e.Sources {
+		parts = append(parts, s.EntryID+"@"+s.Date)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestEvidenceIndex(t *testing.T) {
+	entries := []repositories.Entry{
+		{ID: "e1", Date: "2026-01-05", Type: repositories.EntryTypePlan, RawText: "Plan login"},
+		{ID: "e2", Date: "2026-01-05T00:00:00Z", Type: repositories.EntryTypeFact, RawText: "Shipped login"},
+		{ID: "e3", Date: "2026-01-06", Type: repositories.EntryTypeFact, RawText: "Fixed logout"},
+	}
+	var b strings.Builder
+	index := newEvidenceIndex()
+	writeEvidenceLines(&b, entryEvidenceLines(entries), index)
+	wantInput := "- 2026-01-05 (plan) [R1]: Plan login\n- 2026-01-05 (fact) [R2]: Shipped login\n- 2026-01-06 (fact) [R3]: Fixed logout\n"
+	if b.String() != wantInput {
+		t.Fatalf("input = %q, want %q", b.String(), wantInput)
+	}
+
+	cases := []struct {
+		name string
+		refs []string
+		want string
+	}{
+		{name: "labels ordered by date", refs: []string{"R3", "R1"}, want: "e1@2026-01-05 e3@2026-01-06"},
+		{name: "brackets, spaces and case", refs: []string{"[r2]", " R1 "}, want: "e2@2026-01-05 e1@2026-01-05"},
+		{name: "date instead of a label", refs: []string{"2026-01-05"}, want: "e1@2026-01-05 e2@2026-01-05"},
+		{name: "duplicates", refs: []string{"R2", "2026-01-05", "R2"}, want: "e2@2026-01-05 e1@2026-01-05"},
+		{name: "unknown labels and dates", refs: []string{"R4", "R0", "2026-01-07", "entry 1"}, want: "unsourced"},
+		{name: "partly unknown", refs: []string{"R9", "R3"}, want: "e3@2026-01-06"},
+		{name: "no refs", refs: nil, want: "unsourced"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := index.evidence(tc.refs)
+			if evidenceIDs(got) != tc.want {
+				t.Fatalf("evidence(%q) = %s, want %s", tc.refs, evidenceIDs(got), tc.want)
+			}
+			if got.Sources == nil {
+				t.Fatal("sources must be an empty list, not null")
+			}
+		})
+	}
+}
+
+func TestGeneratePerfSummaryEvidence(t *testing.T) {
+	f := newPerfSummaryFixture(t, perfSummaryTestBudget, answer(`{"goals":[{
+		"title": "Auth",
+		"outputs": [
+			{"text": "Shipped login", "sources": ["R2"]},
+			{"text": "Rewrote auth", "sources": ["R9"]},
+			"Improved onboarding"
+		],
+		"outcomes": [{"text": "Fewer logout bugs", "sources": ["2026-01-06", "R3"]}]
+	}]}`))
+	f.entry("e1", "2026-01-05", repositories.EntryTypePlan, "Plan login")
+	f.entry("e2", "2026-01-05", repositories.EntryTypeFact, "Shipped login")
+	f.entry("e3", "2026-01-06", repositories.EntryTypeFact, "Fixed logout")
+
+	summary, err := f.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	goal := summary.Goals[0]
+	var outputs, outcomes []string
+	for _, e := range goal.OutputEvidence {
+		outputs = append(outputs, evidenceIDs(e))
+	}
+	for _, e := range goal.OutcomeEvidence {
+		outcomes = append(outcomes, evidenceIDs(e))
+	}
+	if want := []string{"e2@2026-01-05", "unsourced", "unsourced"}; !reflect.DeepEqual(outputs, want) {
+		t.Fatalf("output evidence = %q, want %q", outputs, want)
+	}
+	if want := []string{"e3@2026-01-06"}; !reflect.DeepEqual(outcomes, want) {
+		t.Fatalf("outcome evidence = %q, want %q", outcomes, want)
+	}
+}
+
+func TestGeneratePerfSummaryEvidenceThroughWeeks(t *testing.T) {
+	// Две недели длинных записей: цели собираются из недельных саммари, а источники ведут к исходным записям
+	entries := []repositories.Entry{
+		{ID: "e1", Date: "2026-01-05", Type: repositories.EntryTypeFact, RawText: longText("migrated the billing service", 900)},
+		{ID: "e2", Date: "2026-01-07", Type: repositories.EntryTypeFact, RawText: longText("removed the old billing queue", 900)},
+		{ID: "e3", Date: "2026-01-13", Type: repositories.EntryTypeFact, RawText: longText("moved invoices to the new service", 900)},
+	}
+	cmd := GeneratePerfSummaryCommand{Role: "engineer", PeriodStart: "2026-01-01", PeriodEnd: "2026-01-31"}
+	full, _ := renderPerfSummaryInput(cmd, entryEvidenceLines(entries))
+	budget := budgetFor(llm.EstimateMessagesTokens(llm.SystemUserMessages(prompts.PerfSummaryPrompt, full)) - 1)
+
+	f := newPerfSummaryFixture(t, budget,
+		answer(`{"summary":[{"text":"Billing migrated","sources":["R1","R2"]},{"text":"Queue cleanup","sources":["R7"]}],"candidate_goals":[]}`),
+		answer(`{"summary":[{"text":"Invoices moved","sources":["2026-01-13"]}],"candidate_goals":[]}`),
+		answer(`{"goals":[{"title":"Billing","outputs":[
+			{"text":"Migrated billing","sources":["R1"]},
+			{"text":"Cleaned up the queue","sources":["R2"]},
+			{"text":"Moved invoices","sources":["R3","R1"]}
+		],"outcomes":[]}]}`),
+	)
+	for _, e := range entries {
+		f.entry(e.ID, e.Date, e.Type, e.RawText)
+	}
+
+	summary, err := f.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.provider.Requests()); n != 3 {
+		t.Fatalf("got %d requests, want 2 weeks and a reduce", n)
+	}
+	var outputs []string
+	for _, e := range summary.Goals[0].OutputEvidence {
+		outputs = append(outputs, evidenceIDs(e))
+	}
+	// Пункт недели без верных ссылок не подтверждает и цель, которая на него сослалась
+	want := []string{"e1@2026-01-05 e2@2026-01-07", "unsourced", "e1@2026-01-05 e2@2026-01-07 e3@2026-01-13"}
+	if !reflect.DeepEqual(outputs, want) {
+		t.Fatalf("output evidence = %q, want %q", outputs, want)
+	}
+}
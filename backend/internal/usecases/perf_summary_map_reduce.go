@@ -0,0 +1,256 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/prompts"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// perfChunk — часть записей периода, которая суммаризуется одним запросом к модели.
+// Обычно это одна неделя; слишком длинная неделя делится на несколько чанков.
+type perfChunk struct {
+	Start string
+	End   string
+	Lines []evidenceLine
+}
+
+// weeklySummary — результат map-шага: краткое саммари недели (или нескольких недель после свёртки).
+// Каждый пункт хранит записи, на которые сослалась модель, чтобы источники дошли до итоговых целей.
+type weeklySummary struct {
+	Start          string
+	End            string
+	Bullets        []evidenceLine
+	CandidateGoals []string
+}
+
+// llmLocalSummaryAnswer описывает JSON-ответ модели из local_summary_prompt.md
+type llmLocalSummaryAnswer struct {
+	Summary        []rawPerfBullet `json:"summary"`
+	CandidateGoals []string        `json:"candidate_goals"`
+}
+
+// splitIntoWeeklyChunks группирует записи по неделям (с понедельника), обрезая крайние недели по границам периода.
+// Неделя, которая сама не помещается в бюджет, делится на несколько последовательных чанков.
+func (u *GeneratePerfSummaryUsecase) splitIntoWeeklyChunks(cmd GeneratePerfSummaryCommand, entries []repositories.Entry) []perfChunk {
+	var chunks []perfChunk
+	var current perfChunk
+
+	flush := func() {
+		if len(current.Lines) > 0 {
+			chunks = append(chunks, current)
+		}
+		current = perfChunk{}
+	}
+
+	for _, line := range entryEvidenceLines(sortEntriesByDay(entries)) {
+		weekStart, weekEnd := weekBounds(line.Date)
+		weekStart, weekEnd = max(weekStart, cmd.PeriodStart), min(weekEnd, cmd.PeriodEnd)
+		if len(current.Lines) > 0 && current.Start != weekStart {
+			flush()
+		}
+		if len(current.Lines) > 0 {
+			candidate := append(current.Lines[:len(current.Lines):len(current.Lines)], line)
+			if messages, _ := localSummaryMessages(weekStart, weekEnd, candidate); !u.budget.Fits(messages) {
+				flush()
+			}
+		}
+		current.Start, current.End = weekStart, weekEnd
+		current.Lines = append(current.Lines, line)
+	}
+	flush()
+
+	return chunks
+}
+
+// summarizeChunk выполняет map-шаг: краткое саммари записей одного чанка
+func (u *GeneratePerfSummaryUsecase) summarizeChunk(ctx context.Context, chunk perfChunk) (weeklySummary, error) {
+	answer, err := u.localSummary(ctx, chunk.Start, chunk.End, chunk.Lines)
+	if err != nil {
+		return weeklySummary{}, err
+	}
+
+	return weeklySummary{
+		Start:          chunk.Start,
+		End:            chunk.End,
+		Bullets:        answer.Bullets,
+		CandidateGoals: answer.CandidateGoals,
+	}, nil
+}
+
+// compressWeeklySummaries сворачивает соседние недельные саммари, пока reduce-запрос не поместится в бюджет.
+// Если свернуть больше нечего (даже пара соседних саммари не помещается в один запрос), отдаём как есть.
+func (u *GeneratePerfSummaryUsecase) compressWeeklySummaries(ctx context.Context, cmd GeneratePerfSummaryCommand, weeks []weeklySummary) ([]weeklySummary, error) {
+	for {
+		input, _ := renderPerfSummaryReduceInput(cmd, weeks)
+		if u.budget.Fits(llm.SystemUserMessages(prompts.PerfSummaryPrompt, input)) {
+			break
+		}
+
+		groups := u.packWeeklySummaries(weeks)
+		if len(groups) >= len(weeks) {
+			break
+		}
+
+		merged := make([]weeklySummary, 0, len(groups))
+		for _, group := range groups {
+			if len(group) == 1 {
+				merged = append(merged, group[0])
+				continue
+			}
+
+			start, end := group[0].Start, group[len(group)-1].End
+			answer, err := u.localSummary(ctx, start, end, weeklySummaryLines(group))
+			if err != nil {
+				return nil, fmt.Errorf("failed to merge weeks %s — %s: %w", start, end, err)
+			}
+			merged = append(merged, weeklySummary{
+				Start:          start,
+				End:            end,
+				Bullets:        answer.Bullets,
+				CandidateGoals: mergeCandidateGoals(append(group, weeklySummary{CandidateGoals: answer.CandidateGoals})),
+			})
+		}
+		weeks = merged
+	}
+
+	return weeks, nil
+}
+
+// packWeeklySummaries жадно объединяет соседние саммари в группы, помещающиеся в один запрос локального саммари
+func (u *GeneratePerfSummaryUsecase) packWeeklySummaries(weeks []weeklySummary) [][]weeklySummary {
+	var groups [][]weeklySummary
+	for _, w := range weeks {
+		if n := len(groups); n > 0 {
+			last := groups[n-1]
+			candidate := append(last[:len(last):len(last)], w)
+			if messages, _ := localSummaryMessages(candidate[0].Start, w.End, weeklySummaryLines(candidate)); u.budget.Fits(messages) {
+				groups[n-1] = candidate
+				continue
+			}
+		}
+		groups = append(groups, []weeklySummary{w})
+	}
+	return groups
+}
+
+// localSummaryResult — краткое саммари с разрешёнными источниками пунктов
+type localSummaryResult struct {
+	Bullets        []evidenceLine
+	CandidateGoals []string
+}
+
+// localSummary запрашивает у модели краткое саммари строк за период по промпту local_summary_prompt.md
+func (u *GeneratePerfSummaryUsecase) localSummary(ctx context.Context, start, end string, lines []evidenceLine) (localSummaryResult, error) {
+	return requestLocalSummary(ctx, u.provider, u.budget, start, end, lines)
+}
+
+// requestLocalSummary выполняет запрос локального саммари; общий для map-шага перф-саммари и саммари дня/недели
+func requestLocalSummary(ctx context.Context, provider llm.Provider, budget llm.Budget, start, end string, lines []evidenceLine) (localSummaryResult, error) {
+	messages, index := localSummaryMessages(start, end, lines)
+	resp, err := provider.Complete(ctx, llm.CompletionRequest{
+		Messages:    messages,
+		Temperature: perfSummaryTemperature,
+		MaxTokens:   budget.OutputTokens,
+		JSONMode:    true,
+	})
+	if err != nil {
+		return localSummaryResult{}, fmt.Errorf("llm completion failed: %w", err)
+	}
+
+	var answer llmLocalSummaryAnswer
+	if err := json.Unmarshal([]byte(llm.ExtractJSON(resp.Content)), &answer); err != nil {
+		return localSummaryResult{}, fmt.Errorf("failed to parse llm answer: %w", err)
+	}
+
+	result := localSummaryResult{
+		Bullets:        make([]evidenceLine, 0, len(answer.Summary)),
+		CandidateGoals: answer.CandidateGoals,
+	}
+	for _, b := range answer.Summary {
+		if strings.TrimSpace(b.Text) == "" {
+			continue
+		}
+		result.Bullets = append(result.Bullets, evidenceLine{
+			Date:    start,
+			Type:    repositories.EntryTypeFact,
+			Text:    b.Text,
+			Sources: index.resolve(b.Sources),
+		})
+	}
+	if result.CandidateGoals == nil {
+		result.CandidateGoals = []string{}
+	}
+	return result, nil
+}
+
+// localSummaryMessages формирует запрос локального саммари за период и индекс меток его строк
+func localSummaryMessages(start, end string, lines []evidenceLine) ([]llm.Message, *evidenceIndex) {
+	index := newEvidenceIndex()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Period: %s — %s\n\n", start, end)
+	b.WriteString("Entries:\n")
+	writeEvidenceLines(&b, lines, index)
+	return llm.SystemUserMessages(prompts.LocalSummaryPrompt, b.String()), index
+}
+
+// renderPerfSummaryReduceInput формирует reduce-запрос: пункты недельных саммари подаются в формате записей,
+// датированных первым днём недели, плюс кандидаты в цели, предложенные на map-шаге
+func renderPerfSummaryReduceInput(cmd GeneratePerfSummaryCommand, weeks []weeklySummary) (string, *evidenceIndex) {
+	input, index := renderPerfSummaryInput(cmd, weeklySummaryLines(weeks))
+
+	var b strings.Builder
+	b.WriteString(input)
+	b.WriteString("\nThe entries above are summaries of consecutive weeks, each dated by the first day of its week.\n")
+
+	if candidates := mergeCandidateGoals(weeks); len(candidates) > 0 {
+		b.WriteString("\nCandidate goals suggested for individual weeks:\n")
+		for _, c := range candidates {
+			fmt.Fprintf(&b, "* %s\n", c)
+		}
+	}
+	return b.String(), index
+}
+
+// weeklySummaryLines собирает пункты нескольких саммари в одну последовательность строк
+func weeklySummaryLines(weeks []weeklySummary) []evidenceLine {
+	var lines []evidenceLine
+	for _, w := range weeks {
+		lines = append(lines, w.Bullets...)
+	}
+	return lines
+}
+
+// mergeCandidateGoals объединяет кандидатов в цели без повторов, сохраняя порядок появления
+func mergeCandidateGoals(weeks []weeklySummary) []string {
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, w := range weeks {
+		for _, c := range w.CandidateGoals {
+			key := strings.ToLower(strings.TrimSpace(c))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, strings.TrimSpace(c))
+		}
+	}
+	return candidates
+}
+
+// weekBounds возвращает понедельник и воскресенье недели, в которую попадает дата YYYY-MM-DD
+func weekBounds(day string) (string, string) {
+	t, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return day, day
+	}
+	offset := (int(t.Weekday()) + 6) % 7
+	monday := t.AddDate(0, 0, -offset)
+	return monday.Format("2006-01-02"), monday.AddDate(0, 0, 6).Format("2006-01-02")
+}
@@ -0,0 +1,279 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/prompts"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// budgetFor возвращает бюджет, в котором на сообщения запроса остаётся ровно inputTokens
+func budgetFor(inputTokens int) llm.Budget {
+	return llm.Budget{ContextTokens: inputTokens + 100, OutputTokens: 100}
+}
+
+// longText повторяет фразу до n рун, чтобы запись заметно занимала бюджет
+func longText(phrase string, n int) string {
+	return strings.Repeat(phrase+" ", n/(len(phrase)+1)+1)[:n]
+}
+
+func TestSplitIntoWeeklyChunks(t *testing.T) {
+	// 2026-01-05 — понедельник
+	line := func(date string) evidenceLine {
+		return evidenceLine{Date: date, Type: repositories.EntryTypeFact, Text: "Fact of " + date + " " + longText("reviewed pull requests", 120)}
+	}
+	twoLines, _ := localSummaryMessages("2026-01-05", "2026-01-11", []evidenceLine{line("2026-01-05"), line("2026-01-06")})
+
+	cases := []struct {
+		name   string
+		start  string
+		end    string
+		budget llm.Budget
+		dates  []string
+		want   []string
+	}{
+		{
+			name:   "one chunk per week",
+			start:  "2026-01-01",
+			end:    "2026-01-31",
+			budget: perfSummaryTestBudget,
+			dates:  []string{"2026-01-05", "2026-01-11", "2026-01-12", "2026-01-26"},
+			want:   []string{"2026-01-05..2026-01-11: 2026-01-05 2026-01-11", "2026-01-12..2026-01-18: 2026-01-12", "2026-01-26..2026-01-31: 2026-01-26"},
+		},
+		{
+			name:   "weeks are clipped by the period",
+			start:  "2026-01-07",
+			end:    "2026-01-13",
+			budget: perfSummaryTestBudget,
+			dates:  []string{"2026-01-07", "2026-01-13"},
+			want:   []string{"2026-01-07..2026-01-11: 2026-01-07", "2026-01-12..2026-01-13: 2026-01-13"},
+		},
+		{
+			name:   "long week is split",
+			start:  "2026-01-01",
+			end:    "2026-01-31",
+			budget: budgetFor(llm.EstimateMessagesTokens(twoLines)),
+			dates:  []string{"2026-01-05", "2026-01-06", "2026-01-07", "2026-01-08", "2026-01-09", "2026-01-12"},
+			want: []string{
+				"2026-01-05..2026-01-11: 2026-01-05 2026-01-06",
+				"2026-01-05..2026-01-11: 2026-01-07 2026-01-08",
+				"2026-01-05..2026-01-11: 2026-01-09",
+				"2026-01-12..2026-01-18: 2026-01-12",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &GeneratePerfSummaryUsecase{budget: tc.budget}
+			var entries []repositories.Entry
+			// Записи подаются вразнобой: чанки всё равно идут по датам
+			for i := len(tc.dates) - 1; i >= 0; i-- {
+				l := line(tc.dates[i])
+				entries = append(entries, repositories.Entry{ID: "e-" + l.Date, Date: l.Date, Type: l.Type, RawText: l.Text})
+			}
+
+			chunks := u.splitIntoWeeklyChunks(GeneratePerfSummaryCommand{PeriodStart: tc.start, PeriodEnd: tc.end}, entries)
+			var got []string
+			for _, c := range chunks {
+				dates := make([]string, 0, len(c.Lines))
+				for _, l := range c.Lines {
+					dates = append(dates, l.Date)
+				}
+				got = append(got, c.Start+".."+c.End+": "+strings.Join(dates, " "))
+				if messages, _ := localSummaryMessages(c.Start, c.End, c.Lines); len(c.Lines) > 1 && !tc.budget.Fits(messages) {
+					t.Fatalf("chunk %s does not fit the budget", got[len(got)-1])
+				}
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("chunks:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tc.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestGeneratePerfSummaryMapReduce(t *testing.T) {
+	// Три недели по две длинные записи: целиком период не помещается, а каждая неделя — помещается
+	dates := []string{"2026-01-05", "2026-01-07", "2026-01-12", "2026-01-14", "2026-01-19", "2026-01-21"}
+	var entries []repositories.Entry
+	for i, date := range dates {
+		entries = append(entries, repositories.Entry{ID: fmt.Sprintf("e%d", i+1), Date: date, Type: repositories.EntryTypeFact, RawText: date + " " + longText("migrated the billing service", 600)})
+	}
+	cmd := GeneratePerfSummaryCommand{Role: "engineer", PeriodStart: "2026-01-01", PeriodEnd: "2026-01-31"}
+	full, _ := renderPerfSummaryInput(cmd, entryEvidenceLines(entries))
+	budget := budgetFor(llm.EstimateMessagesTokens(llm.SystemUserMessages(prompts.PerfSummaryPrompt, full)) - 1)
+
+	f := newPerfSummaryFixture(t, budget,
+		answer(`{"summary":[{"text":"Billing week one","sources":["R1","R2"]}],"candidate_goals":["Billing"]}`),
+		answer(`{"summary":[{"text":"Billing week two","sources":["R2"]}],"candidate_goals":["billing ","Payments"]}`),
+		answer(`{"summary":["Billing week three"],"candidate_goals":[]}`),
+		answer(`{"goals":[{"title":"Billing","outputs":[{"text":"Migrated billing","sources":["R1","R2"]}],"outcomes":[]}]}`),
+	)
+	for _, e := range entries {
+		f.entry(e.ID, e.Date, e.Type, e.RawText)
+	}
+
+	var chunkEvents []string
+	summary, err := f.usecase.ExecuteWithProgress(context.Background(), GeneratePerfSummaryCommand{UserID: perfSummaryTestUser, PeriodStart: cmd.PeriodStart, PeriodEnd: cmd.PeriodEnd}, func(event PerfSummaryEvent) {
+		if event.Type == PerfSummaryEventChunkSummarized {
+			chunkEvents = append(chunkEvents, fmt.Sprintf("%d/%d", event.Chunk, event.TotalChunks))
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := f.provider.Requests()
+	if len(requests) != 4 {
+		t.Fatalf("got %d requests, want 3 weeks and a reduce", len(requests))
+	}
+	weeks := []struct{ start, end, first, second string }{
+		{"2026-01-05", "2026-01-11", "2026-01-05", "2026-01-07"},
+		{"2026-01-12", "2026-01-18", "2026-01-12", "2026-01-14"},
+		{"2026-01-19", "2026-01-25", "2026-01-19", "2026-01-21"},
+	}
+	for i, w := range weeks {
+		req := requests[i]
+		if req.Messages[0].Content != prompts.LocalSummaryPrompt {
+			t.Fatalf("request %d must use the local summary prompt", i)
+		}
+		input := req.Messages[1].Content
+		if !strings.HasPrefix(input, "Period: "+w.start+" — "+w.end+"\n") ||
+			!strings.Contains(input, "- "+w.first+" (fact) [R1]: ") || !strings.Contains(input, "- "+w.second+" (fact) [R2]: ") ||
+			strings.Count(input, "(fact)") != 2 {
+			t.Fatalf("request %d input = %q", i, input)
+		}
+		if !budget.Fits(req.Messages) {
+			t.Fatalf("request %d does not fit the budget", i)
+		}
+	}
+
+	reduce := requests[3]
+	if reduce.Messages[0].Content != prompts.PerfSummaryPrompt || !budget.Fits(reduce.Messages) {
+		t.Fatal("reduce request must use the perf summary prompt and fit the budget")
+	}
+	wantReduce := "Role: engineer\nPeriod: 2026-01-01 — 2026-01-31\n\nEntries:\n" +
+		"- 2026-01-05 (fact) [R1]: Billing week one\n" +
+		"- 2026-01-12 (fact) [R2]: Billing week two\n" +
+		"- 2026-01-19 (fact) [R3]: Billing week three\n" +
+		"\nThe entries above are summaries of consecutive weeks, each dated by the first day of its week.\n" +
+		"\nCandidate goals suggested for individual weeks:\n* Billing\n* Payments\n"
+	if got := reduce.Messages[1].Content; got != wantReduce {
+		t.Fatalf("reduce input =\n%s\nwant:\n%s", got, wantReduce)
+	}
+
+	if strings.Join(chunkEvents, " ") != "1/4 2/4 3/4 4/4" {
+		t.Fatalf("chunk events = %v", chunkEvents)
+	}
+	if len(summary.Goals) != 1 || summary.Goals[0].Title != "Billing" {
+		t.Fatalf("goals = %+v", summary.Goals)
+	}
+}
+
+func TestCompressWeeklySummaries(t *testing.T) {
+	week := func(start, end, entryID string, candidates ...string) weeklySummary {
+		return weeklySummary{
+			Start: start,
+			End:   end,
+			Bullets: []evidenceLine{{
+				Date:    start,
+				Type:    repositories.EntryTypeFact,
+				Text:    "Week of " + start + ": " + longText("moved reports to the new storage", 3000),
+				Sources: []repositories.PerfEvidenceSource{{EntryID: entryID, Date: start}},
+			}},
+			CandidateGoals: candidates,
+		}
+	}
+	weeks := []weeklySummary{
+		week("2026-01-05", "2026-01-11", "e1", "Reports"),
+		week("2026-01-12", "2026-01-18", "e2", "reports", "Storage"),
+		week("2026-01-19", "2026-01-25", "e3", "Storage"),
+		week("2026-01-26", "2026-01-31", "e4"),
+	}
+	cmd := GeneratePerfSummaryCommand{Role: "engineer", PeriodStart: "2026-01-01", PeriodEnd: "2026-01-31"}
+	localTokens := func(group []weeklySummary) int {
+		messages, _ := localSummaryMessages(group[0].Start, group[len(group)-1].End, weeklySummaryLines(group))
+		return llm.EstimateMessagesTokens(messages)
+	}
+	reduceInput, _ := renderPerfSummaryReduceInput(cmd, weeks)
+	reduceTokens := llm.EstimateMessagesTokens(llm.SystemUserMessages(prompts.PerfSummaryPrompt, reduceInput))
+
+	type wantWeek struct {
+		start, end string
+		sources    []string
+		candidates []string
+	}
+	cases := []struct {
+		name        string
+		inputTokens int
+		steps       []llm.ScriptedStep
+		want        []wantWeek
+	}{
+		{
+			name:        "reduce request already fits",
+			inputTokens: reduceTokens,
+			want: []wantWeek{
+				{"2026-01-05", "2026-01-11", []string{"e1"}, []string{"Reports"}},
+				{"2026-01-12", "2026-01-18", []string{"e2"}, []string{"reports", "Storage"}},
+				{"2026-01-19", "2026-01-25", []string{"e3"}, []string{"Storage"}},
+				{"2026-01-26", "2026-01-31", []string{"e4"}, nil},
+			},
+		},
+		{
+			// В один запрос помещаются две недели, но не три: соседние недели сворачиваются попарно
+			name:        "merges neighbouring weeks",
+			inputTokens: localTokens(weeks[:2]),
+			steps: []llm.ScriptedStep{
+				answer(`{"summary":[{"text":"Reports moved","sources":["R1","R2"]}],"candidate_goals":["Reports"]}`),
+				answer(`{"summary":[{"text":"Storage done","sources":["R2","R9"]}],"candidate_goals":["Migration"]}`),
+			},
+			want: []wantWeek{
+				{"2026-01-05", "2026-01-18", []string{"e1", "e2"}, []string{"Reports", "Storage"}},
+				{"2026-01-19", "2026-01-31", []string{"e4"}, []string{"Storage", "Migration"}},
+			},
+		},
+		{
+			name:        "gives up when no pair fits",
+			inputTokens: localTokens(weeks[:1]),
+			want: []wantWeek{
+				{"2026-01-05", "2026-01-11", []string{"e1"}, []string{"Reports"}},
+				{"2026-01-12", "2026-01-18", []string{"e2"}, []string{"reports", "Storage"}},
+				{"2026-01-19", "2026-01-25", []string{"e3"}, []string{"Storage"}},
+				{"2026-01-26", "2026-01-31", []string{"e4"}, nil},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newPerfSummaryFixture(t, budgetFor(tc.inputTokens), tc.steps...)
+
+			got, err := f.usecase.compressWeeklySummaries(context.Background(), cmd, weeks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(f.provider.Requests()); n != len(tc.steps) {
+				t.Fatalf("got %d requests, want %d", n, len(tc.steps))
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d weeks, want %d", len(got), len(tc.want))
+			}
+			for i, w := range tc.want {
+				var sources []string
+				for _, b := range got[i].Bullets {
+					for _, s := range b.Sources {
+						sources = append(sources, s.EntryID)
+					}
+				}
+				if got[i].Start != w.start || got[i].End != w.end || !reflect.DeepEqual(sources, w.sources) || !reflect.DeepEqual(got[i].CandidateGoals, w.candidates) {
+					t.Fatalf("week %d = %s — %s, sources %v, candidates %v; want %+v", i, got[i].Start, got[i].End, sources, got[i].CandidateGoals, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package usecases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+)
+
+// Ограничения на цель в ответе модели: всё, что длиннее, не поместится на экран Summary
+const (
+	maxPerfGoals         = 10
+	maxPerfGoalTitle     = 200
+	maxPerfGoalContext   = 3000
+	maxPerfGoalBullets   = 30
+	maxPerfGoalBulletLen = 600
+)
+
+// perfSummaryRepairAttempts — сколько раз просим модель исправить ответ, не прошедший валидацию
+const perfSummaryRepairAttempts = 2
+
+// PerfSummaryIssue описывает одно нарушение схемы в ответе модели
+type PerfSummaryIssue struct {
+	Path    string
+	Message string
+}
+
+// PerfSummaryValidationError возвращается, если ответ модели не прошёл валидацию даже после попыток исправления
+type PerfSummaryValidationError struct {
+	Attempts int
+	Issues   []PerfSummaryIssue
+}
+
+// Error возвращает краткое описание всех нарушений
+func (e *PerfSummaryValidationError) Error() string {
+	parts := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		parts = append(parts, issue.String())
+	}
+	return fmt.Sprintf("llm answer failed validation after %d attempts: %s", e.Attempts, strings.Join(parts, "; "))
+}
+
+// String возвращает нарушение в виде "path: message"
+func (i PerfSummaryIssue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// rawPerfGoal — цель из ответа модели до валидации; указатели отличают отсутствующее поле от пустого
+type rawPerfGoal struct {
+	Title    *string          `json:"title"`
+	Context  *string          `json:"context"`
+	Outputs  *[]rawPerfBullet `json:"outputs"`
+	Outcomes *[]rawPerfBullet `json:"outcomes"`
+}
+
+// rawPerfBullet — пункт outputs/outcomes: объект {"text", "sources"} или просто строка без источников
+type rawPerfBullet struct {
+	Text    string
+	Sources []string
+}
+
+// UnmarshalJSON принимает пункт как в виде объекта, так и в виде строки
+func (b *rawPerfBullet) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = rawPerfBullet{Text: text}
+		return nil
+	}
+
+	var obj struct {
+		Text    *string  `json:"text"`
+		Sources []string `json:"sources"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil || obj.Text == nil {
+		return &json.UnmarshalTypeError{Value: string(data), Type: reflect.TypeOf(b).Elem()}
+	}
+	*b = rawPerfBullet{Text: *obj.Text, Sources: obj.Sources}
+	return nil
+}
+
+// parsePerfSummaryAnswer разбирает ответ модели вида {"goals":[...]} и проверяет каждую цель
+func parsePerfSummaryAnswer(content string) ([]llmPerfGoal, []PerfSummaryIssue) {
+	var answer struct {
+		Goals *[]json.RawMessage `json:"goals"`
+	}
+	if err := json.Unmarshal([]byte(llm.ExtractJSON(content)), &answer); err != nil {
+		return nil, []PerfSummaryIssue{{Message: "response is not a valid JSON object: " + err.Error()}}
+	}
+	if answer.Goals == nil {
+		return nil, []PerfSummaryIssue{{Path: "goals", Message: "is required and must be an array"}}
+	}
+	if len(*answer.Goals) > maxPerfGoals {
+		return nil, []PerfSummaryIssue{{Path: "goals", Message: fmt.Sprintf("must contain at most %d goals", maxPerfGoals)}}
+	}
+
+	var goals []llmPerfGoal
+	var issues []PerfSummaryIssue
+	for i, raw := range *answer.Goals {
+		goal, goalIssues := parsePerfGoal(fmt.Sprintf("goals[%d]", i), raw)
+		goals = append(goals, goal)
+		issues = append(issues, goalIssues...)
+	}
+	return goals, issues
+}
+
+// parsePerfGoal разбирает и проверяет один объект цели
+func parsePerfGoal(path string, raw json.RawMessage) (llmPerfGoal, []PerfSummaryIssue) {
+	var issues []PerfSummaryIssue
+	addIssue := func(field, message string) {
+		issues = append(issues, PerfSummaryIssue{Path: path + "." + field, Message: message})
+	}
+
+	// При несовпадении типа json продолжает разбор остальных полей,
+	// поэтому сообщаем о неверном поле и проверяем остальные как обычно
+	var g rawPerfGoal
+	badField := ""
+	if err := json.Unmarshal(raw, &g); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) || typeErr.Field == "" {
+			return llmPerfGoal{}, []PerfSummaryIssue{{Path: path, Message: "must be a JSON object"}}
+		}
+		badField = typeErr.Field
+		if badField == "outputs" || badField == "outcomes" {
+			addIssue(badField, `must be an array of {"text", "sources"} objects`)
+		} else {
+			addIssue(badField, "must be a string")
+		}
+	}
+
+	var goal llmPerfGoal
+	if badField != "title" {
+		switch {
+		case g.Title == nil || strings.TrimSpace(*g.Title) == "":
+			addIssue("title", "must not be empty")
+		case utf8.RuneCountInString(*g.Title) > maxPerfGoalTitle:
+			addIssue("title", fmt.Sprintf("must be at most %d characters", maxPerfGoalTitle))
+		default:
+			goal.Title = strings.TrimSpace(*g.Title)
+		}
+	}
+
+	if g.Context != nil {
+		if utf8.RuneCountInString(*g.Context) > maxPerfGoalContext {
+			addIssue("context", fmt.Sprintf("must be at most %d characters", maxPerfGoalContext))
+		}
+		goal.Context = strings.TrimSpace(*g.Context)
+	}
+
+	if badField != "outputs" {
+		goal.Outputs = validatePerfGoalBullets(g.Outputs, func(message string) { addIssue("outputs", message) })
+	}
+	if badField != "outcomes" {
+		goal.Outcomes = validatePerfGoalBullets(g.Outcomes, func(message string) { addIssue("outcomes", message) })
+	}
+
+	return goal, issues
+}
+
+// validatePerfGoalBullets проверяет список пунктов outputs/outcomes
+func validatePerfGoalBullets(bullets *[]rawPerfBullet, addIssue func(message string)) []llmPerfBullet {
+	if bullets == nil {
+		addIssue(`is required and must be an array of {"text", "sources"} objects`)
+		return nil
+	}
+	if len(*bullets) > maxPerfGoalBullets {
+		addIssue(fmt.Sprintf("must contain at most %d items", maxPerfGoalBullets))
+	}
+
+	result := make([]llmPerfBullet, 0, len(*bullets))
+	for i, b := range *bullets {
+		text := strings.TrimSpace(b.Text)
+		switch {
+		case text == "":
+			addIssue(fmt.Sprintf("item %d text must not be empty", i))
+		case utf8.RuneCountInString(text) > maxPerfGoalBulletLen:
+			addIssue(fmt.Sprintf("item %d text must be at most %d characters", i, maxPerfGoalBulletLen))
+		}
+		result = append(result, llmPerfBullet{Text: text, Sources: b.Sources})
+	}
+	return result
+}
+
+// renderRepairPrompt формирует сообщение, которое возвращает модели найденные нарушения
+func renderRepairPrompt(issues []PerfSummaryIssue) string {
+	var b strings.Builder
+	b.WriteString("Your previous answer does not match the required JSON format:\n")
+	for _, issue := range issues {
+		fmt.Fprintf(&b, "- %s\n", issue)
+	}
+	b.WriteString("\nReturn the corrected answer as a single JSON object {\"goals\": [...]} with the same content and sources, fixing only these problems.")
+	return b.String()
+}
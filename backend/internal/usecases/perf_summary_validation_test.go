@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+func TestParsePerfSummaryAnswer(t *testing.T) {
+	cases := []struct {
+		name       string
+		content    string
+		wantTitles []string
+		// wantIssues — начала сообщений о нарушениях в порядке их появления
+		wantIssues []string
+	}{
+		{
+			name:       "valid answer",
+			content:    `{"goals":[{"title":"Auth","context":"ctx","outputs":[{"text":"Shipped login","sources":["R1"]}],"outcomes":[]}]}`,
+			wantTitles: []string{"Auth"},
+		},
+		{
+			name:       "json in a code fence with string bullets",
+			content:    "Here you go:\n```json\n{\"goals\":[{\"title\":\"Auth\",\"outputs\":[\"Shipped login\"],\"outcomes\":[\"Fewer tickets\"]}]}\n```",
+			wantTitles: []string{"Auth"},
+		},
+		{
+			name:       "no goals",
+			content:    `{"goals":[]}`,
+			wantTitles: nil,
+		},
+		{
+			name:       "not json",
+			content:    "I could not find any goals.",
+			wantIssues: []string{"response is not a valid JSON object"},
+		},
+		{
+			name:       "missing goals",
+			content:    `{"result":[]}`,
+			wantIssues: []string{"goals: is required and must be an array"},
+		},
+		{
+			name:       "too many goals",
+			content:    `{"goals":[` + strings.Repeat(`{"title":"A","outputs":[],"outcomes":[]},`, 10) + `{"title":"A","outputs":[],"outcomes":[]}]}`,
+			wantIssues: []string{"goals: must contain at most 10 goals"},
+		},
+		{
+			name:       "goal is not an object",
+			content:    `{"goals":["Auth"]}`,
+			wantIssues: []string{"goals[0]: must be a JSON object"},
+		},
+		{
+			name:    "empty title and missing outcomes",
+			content: `{"goals":[{"title":" ","outputs":[]}]}`,
+			wantIssues: []string{
+				"goals[0].title: must not be empty",
+				`goals[0].outcomes: is required and must be an array of {"text", "sources"} objects`,
+			},
+		},
+		{
+			name:       "context is not a string",
+			content:    `{"goals":[{"title":"Auth","context":5,"outputs":[],"outcomes":[]}]}`,
+			wantIssues: []string{"goals[0].context: must be a string"},
+		},
+		{
+			name:       "outputs is not an array",
+			content:    `{"goals":[{"title":"Auth","outputs":"Shipped login","outcomes":[]}]}`,
+			wantIssues: []string{`goals[0].outputs: must be an array of {"text", "sources"} objects`},
+		},
+		{
+			name:    "limits",
+			content: `{"goals":[{"title":"` + strings.Repeat("т", 201) + `","context":"` + strings.Repeat("к", 3001) + `","outputs":["", "` + strings.Repeat("о", 601) + `"],"outcomes":[]}]}`,
+			wantIssues: []string{
+				"goals[0].title: must be at most 200 characters",
+				"goals[0].context: must be at most 3000 characters",
+				"goals[0].outputs: item 0 text must not be empty",
+				"goals[0].outputs: item 1 text must be at most 600 characters",
+			},
+		},
+		{
+			name:       "issues of every goal",
+			content:    `{"goals":[{"title":"","outputs":[],"outcomes":[]},{"title":"Ok","outputs":[],"outcomes":[]},{"outputs":[],"outcomes":[]}]}`,
+			wantIssues: []string{"goals[0].title: must not be empty", "goals[2].title: must not be empty"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			goals, issues := parsePerfSummaryAnswer(tc.content)
+			if len(issues) != len(tc.wantIssues) {
+				t.Fatalf("issues = %v, want %v", issues, tc.wantIssues)
+			}
+			for i, issue := range issues {
+				if !strings.HasPrefix(issue.String(), tc.wantIssues[i]) {
+					t.Fatalf("issue %d = %q, want %q", i, issue, tc.wantIssues[i])
+				}
+			}
+			if len(tc.wantIssues) > 0 {
+				return
+			}
+			var titles []string
+			for _, g := range goals {
+				titles = append(titles, g.Title)
+			}
+			if !reflect.DeepEqual(titles, tc.wantTitles) {
+				t.Fatalf("titles = %q, want %q", titles, tc.wantTitles)
+			}
+		})
+	}
+}
+
+func TestGeneratePerfSummaryRepair(t *testing.T) {
+	const (
+		invalid = `{"goals":[{"title":"","outputs":[],"outcomes":[]}]}`
+		valid   = `{"goals":[{"title":"Auth","outputs":["Shipped login"],"outcomes":[]}]}`
+	)
+	errUnavailable := errors.New("model is unavailable")
+
+	cases := []struct {
+		name         string
+		steps        []llm.ScriptedStep
+		wantRequests int
+		// wantAttempts — число попыток в PerfSummaryValidationError; 0 — ошибки валидации нет
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "valid at once", steps: []llm.ScriptedStep{answer(valid)}, wantRequests: 1},
+		{name: "repaired on the second attempt", steps: []llm.ScriptedStep{answer(invalid), answer(valid)}, wantRequests: 2},
+		{name: "repaired on the last attempt", steps: []llm.ScriptedStep{answer("not json"), answer(invalid), answer(valid)}, wantRequests: 3},
+		{name: "repair fails", steps: []llm.ScriptedStep{answer(invalid), answer("not json"), answer(invalid), answer(valid)}, wantRequests: 3, wantAttempts: 3},
+		{name: "provider fails during repair", steps: []llm.ScriptedStep{answer(invalid), {Err: errUnavailable}}, wantRequests: 2, wantErr: errUnavailable},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newPerfSummaryFixture(t, perfSummaryTestBudget, tc.steps...)
+			f.entry("e1", "2026-01-05", repositories.EntryTypeFact, "Shipped login")
+
+			summary, err := f.generate()
+			requests := f.provider.Requests()
+			if len(requests) != tc.wantRequests {
+				t.Fatalf("got %d requests, want %d", len(requests), tc.wantRequests)
+			}
+
+			// Каждая попытка исправления продолжает диалог: прошлый ответ и список нарушений
+			for i := 1; i < len(requests); i++ {
+				messages := requests[i].Messages
+				if len(messages) != 2+2*i {
+					t.Fatalf("request %d has %d messages", i, len(messages))
+				}
+				previous, repair := messages[len(messages)-2], messages[len(messages)-1]
+				if previous.Role != llm.RoleAssistant || previous.Content != tc.steps[i-1].Content {
+					t.Fatalf("request %d must repeat the previous answer, got %+v", i, previous)
+				}
+				if repair.Role != llm.RoleUser || !strings.HasPrefix(repair.Content, "Your previous answer does not match the required JSON format:\n- ") {
+					t.Fatalf("request %d repair prompt = %q", i, repair.Content)
+				}
+				if !requests[i].JSONMode {
+					t.Fatalf("request %d is not in JSON mode", i)
+				}
+			}
+
+			var validationErr *PerfSummaryValidationError
+			switch {
+			case tc.wantAttempts > 0:
+				if !errors.As(err, &validationErr) || validationErr.Attempts != tc.wantAttempts {
+					t.Fatalf("Execute = %v, want a validation error after %d attempts", err, tc.wantAttempts)
+				}
+				if len(validationErr.Issues) != 1 || validationErr.Issues[0].Path != "goals[0].title" {
+					t.Fatalf("issues = %v, want the issues of the last answer", validationErr.Issues)
+				}
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) || errors.As(err, &validationErr) {
+					t.Fatalf("Execute = %v, want %v", err, tc.wantErr)
+				}
+			default:
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(summary.Goals) != 1 || summary.Goals[0].Title != "Auth" {
+					t.Fatalf("goals = %+v", summary.Goals)
+				}
+				return
+			}
+			if saved, _ := f.summaries.ListByUser(perfSummaryTestUser); len(saved) != 0 {
+				t.Fatalf("failed generation saved %d summaries", len(saved))
+			}
+		})
+	}
+}
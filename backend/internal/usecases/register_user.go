@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/auth"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Ограничения учётных данных. bcrypt учитывает только первые 72 байта пароля,
+// поэтому более длинные пароли отклоняются, а не обрезаются молча
+const (
+	maxEmailLength    = 254
+	minPasswordLength = 8
+	maxPasswordBytes  = 72
+)
+
+var (
+	ErrInvalidEmail     = errors.New("email is invalid")
+	ErrPasswordTooShort = errors.New("password must be at least 8 characters")
+	ErrPasswordTooLong  = errors.New("password must be at most 72 bytes")
+	ErrEmailTaken       = errors.New("email is already registered")
+)
+
+// AuthSession — выданный пользователю сессионный токен
+type AuthSession struct {
+	Token     string
+	ExpiresAt time.Time
+	User      repositories.User
+}
+
+// RegisterUserCommand представляет команду для регистрации пользователя
+type RegisterUserCommand struct {
+	Email    string
+	Password string
+	Name     string
+}
+
+// RegisterUserUsecase отвечает за регистрацию пользователя по email и паролю
+type RegisterUserUsecase struct {
+	usersRepo       repositories.UsersRepository
+	credentialsRepo repositories.CredentialsRepository
+	tokens          *auth.SessionTokens
+}
+
+// NewRegisterUserUsecase создает новый экземпляр RegisterUserUsecase
+func NewRegisterUserUsecase(usersRepo repositories.UsersRepository, credentialsRepo repositories.CredentialsRepository, tokens *auth.SessionTokens) *RegisterUserUsecase {
+	return &RegisterUserUsecase{
+		usersRepo:       usersRepo,
+		credentialsRepo: credentialsRepo,
+		tokens:          tokens,
+	}
+}
+
+// Execute выполняет регистрацию: создаёт профиль, сохраняет bcrypt-хеш пароля и сразу выдаёт сессию
+func (u *RegisterUserUsecase) Execute(cmd RegisterUserCommand) (AuthSession, error) {
+	email, err := normalizeEmail(cmd.Email)
+	if err != nil {
+		return AuthSession{}, err
+	}
+	if utf8.RuneCountInString(cmd.Password) < minPasswordLength {
+		return AuthSession{}, ErrPasswordTooShort
+	}
+	if len(cmd.Password) > maxPasswordBytes {
+		return AuthSession{}, ErrPasswordTooLong
+	}
+	name := strings.TrimSpace(cmd.Name)
+	if utf8.RuneCountInString(name) > maxUserNameLength {
+		return AuthSession{}, ErrUserNameTooLong
+	}
+
+	if _, err := u.credentialsRepo.GetByEmail(email); err == nil {
+		return AuthSession{}, ErrEmailTaken
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return AuthSession{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(cmd.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return AuthSession{}, err
+	}
+
+	user := newDefaultUser(time.Now().UTC().Format("20060102150405.000000000"))
+	user.Name = name
+	if err := u.usersRepo.Create(user); err != nil {
+		return AuthSession{}, err
+	}
+
+	credentials := repositories.Credentials{
+		UserID:       user.ID,
+		Email:        email,
+		PasswordHash: string(hash),
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.CreatedAt,
+	}
+	if err := u.credentialsRepo.Create(credentials); err != nil {
+		// Без учётных данных в профиль нельзя войти, поэтому он удаляется
+		if delErr := u.usersRepo.DeleteByID(user.ID); delErr != nil {
+			return AuthSession{}, errors.Join(err, delErr)
+		}
+		// Параллельная регистрация с тем же email успела раньше
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			return AuthSession{}, ErrEmailTaken
+		}
+		return AuthSession{}, err
+	}
+
+	token, expiresAt := u.tokens.Issue(user.ID)
+	return AuthSession{Token: token, ExpiresAt: expiresAt, User: user}, nil
+}
+
+// normalizeEmail проверяет формат email и приводит его к нижнему регистру
+func normalizeEmail(raw string) (string, error) {
+	email := strings.ToLower(strings.TrimSpace(raw))
+	if email == "" || len(email) > maxEmailLength {
+		return "", ErrInvalidEmail
+	}
+	// Разрешаем только «голый» адрес, без имени и угловых скобок
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
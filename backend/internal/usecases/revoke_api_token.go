@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// RevokeAPITokenCommand представляет команду для отзыва API-токена
+type RevokeAPITokenCommand struct {
+	UserID string
+	ID     string
+}
+
+// RevokeAPITokenUsecase отвечает за отзыв API-токенов
+type RevokeAPITokenUsecase struct {
+	repo repositories.APITokensRepository
+}
+
+// NewRevokeAPITokenUsecase создает новый экземпляр RevokeAPITokenUsecase
+func NewRevokeAPITokenUsecase(repo repositories.APITokensRepository) *RevokeAPITokenUsecase {
+	return &RevokeAPITokenUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет отзыв токена. Отозванный токен сразу перестаёт приниматься
+func (u *RevokeAPITokenUsecase) Execute(cmd RevokeAPITokenCommand) error {
+	return u.repo.Revoke(cmd.UserID, cmd.ID, time.Now().UTC())
+}
@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Прогресс задачи: загрузка записей — первые 10%, обработка чанков — до 95%, сохранение — 100%
+const (
+	summaryJobProgressEntriesLoaded = 10
+	summaryJobProgressChunksDone    = 95
+	summaryJobProgressDone          = 100
+)
+
+// Аренда задачи воркером: пока генерация идёт, аренда продлевается каждые summaryJobLeaseRenewInterval,
+// поэтому истекает, только если воркер упал или потерял связь с БД. Задачу с истёкшей арендой забирает другой воркер
+const (
+	summaryJobLeaseTimeout       = 2 * time.Minute
+	summaryJobLeaseRenewInterval = 30 * time.Second
+)
+
+// summaryJobMaxAttempts — сколько раз задачу можно забрать из очереди. Задачу, на которой воркер
+// раз за разом падает, не выполнив её, ClaimNext переводит в failed, а не отдаёт следующему воркеру
+const summaryJobMaxAttempts = 3
+
+// errSummaryJobLeaseLost возвращается, если аренда истекла и задачу забрал другой воркер: её результат больше не сохраняется
+var errSummaryJobLeaseLost = errors.New("summary job lease lost")
+
+// RunSummaryJobUsecase отвечает за выполнение задач генерации перф-саммари из очереди
+type RunSummaryJobUsecase struct {
+	repo     repositories.SummaryJobsRepository
+	generate *GeneratePerfSummaryUsecase
+}
+
+// NewRunSummaryJobUsecase создает новый экземпляр RunSummaryJobUsecase
+func NewRunSummaryJobUsecase(repo repositories.SummaryJobsRepository, generate *GeneratePerfSummaryUsecase) *RunSummaryJobUsecase {
+	return &RunSummaryJobUsecase{
+		repo:     repo,
+		generate: generate,
+	}
+}
+
+// Execute забирает следующую задачу из очереди (или задачу, аренда которой истекла) и выполняет её.
+// Возвращает false, если очередь пуста.
+// Если ctx отменён во время генерации (остановка сервера), задача возвращается в очередь.
+func (u *RunSummaryJobUsecase) Execute(ctx context.Context) (bool, error) {
+	job, err := u.repo.ClaimNext(summaryJobLeaseTimeout, summaryJobMaxAttempts)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	cmd := GeneratePerfSummaryCommand{
+		UserID:      job.UserID,
+		PeriodStart: job.PeriodStart,
+		PeriodEnd:   job.PeriodEnd,
+		Role:        job.Role,
+		Tags:        job.Tags,
+	}
+
+	// Потеря аренды отменяет генерацию: задачу уже выполняет другой воркер
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	leaseDone := make(chan struct{})
+	go func() {
+		defer close(leaseDone)
+		u.keepLease(jobCtx, cancel, job)
+	}()
+
+	summary, err := u.generate.ExecuteWithProgress(jobCtx, cmd, func(event PerfSummaryEvent) {
+		progress := job.Progress
+		switch event.Type {
+		case PerfSummaryEventEntriesLoaded:
+			progress = summaryJobProgressEntriesLoaded
+		case PerfSummaryEventChunkSummarized:
+			if event.TotalChunks > 0 {
+				span := summaryJobProgressChunksDone - summaryJobProgressEntriesLoaded
+				progress = summaryJobProgressEntriesLoaded + span*event.Chunk/event.TotalChunks
+			}
+		}
+		// Токены прогресс не двигают — не пишем в БД на каждый токен
+		if progress != job.Progress {
+			job.Progress = progress
+			u.update(&job)
+		}
+	})
+	leaseErr := context.Cause(jobCtx)
+	cancel(nil)
+	<-leaseDone
+	if errors.Is(leaseErr, errSummaryJobLeaseLost) {
+		return true, fmt.Errorf("summary job %s: %w", job.ID, leaseErr)
+	}
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// Остановка сервера — не вина задачи: попытка ей не засчитывается
+		job.Status = repositories.SummaryJobStatusQueued
+		job.Progress = 0
+		job.Attempts--
+	case err != nil:
+		job.Status = repositories.SummaryJobStatusFailed
+		job.Error = err.Error()
+	default:
+		job.Status = repositories.SummaryJobStatusSucceeded
+		job.Progress = summaryJobProgressDone
+		job.ResultSummaryID = summary.ID
+		job.Error = ""
+	}
+
+	return true, u.update(&job)
+}
+
+// keepLease продлевает аренду задачи, пока не отменён ctx. Если задачу забрал другой воркер,
+// отменяет генерацию с причиной errSummaryJobLeaseLost
+func (u *RunSummaryJobUsecase) keepLease(ctx context.Context, cancel context.CancelCauseFunc, job repositories.SummaryJob) {
+	ticker := time.NewTicker(summaryJobLeaseRenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := u.repo.ExtendLease(job, summaryJobLeaseTimeout)
+		if errors.Is(err, repositories.ErrNotFound) {
+			cancel(errSummaryJobLeaseLost)
+			return
+		}
+		if err != nil {
+			log.Printf("summary jobs: failed to extend lease of job %s: %v", job.ID, err)
+		}
+	}
+}
+
+// update сохраняет текущее состояние задачи. Если задачу уже забрал другой воркер, возвращает errSummaryJobLeaseLost
+func (u *RunSummaryJobUsecase) update(job *repositories.SummaryJob) error {
+	job.UpdatedAt = time.Now().UTC()
+	err := u.repo.Update(*job)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return fmt.Errorf("summary job %s: %w", job.ID, errSummaryJobLeaseLost)
+	}
+	return err
+}
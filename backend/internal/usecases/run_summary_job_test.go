@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// newSummaryJobFixture ставит в очередь задачу за январь 2026 года
+func newSummaryJobFixture(t *testing.T, steps ...llm.ScriptedStep) (*perfSummaryFixture, *repositories.InMemorySummaryJobsRepository, *RunSummaryJobUsecase) {
+	f := newPerfSummaryFixture(t, perfSummaryTestBudget, steps...)
+	f.entry("e1", "2026-01-05", repositories.EntryTypeFact, "Shipped login")
+	jobs := repositories.NewInMemorySummaryJobsRepository()
+	now := time.Now().UTC()
+	job := repositories.SummaryJob{ID: "j1", UserID: perfSummaryTestUser, PeriodStart: "2026-01-01", PeriodEnd: "2026-01-31", Status: repositories.SummaryJobStatusQueued, CreatedAt: now, UpdatedAt: now}
+	if err := jobs.Create(job); err != nil {
+		t.Fatal(err)
+	}
+	return f, jobs, NewRunSummaryJobUsecase(jobs, f.usecase)
+}
+
+func TestRunSummaryJobGivesUpAfterMaxAttempts(t *testing.T) {
+	f, jobs, u := newSummaryJobFixture(t)
+
+	// Воркеры забирают задачу и падают, не продлив аренду
+	for i := 0; i < summaryJobMaxAttempts; i++ {
+		if _, err := jobs.ClaimNext(0, summaryJobMaxAttempts); err != nil {
+			t.Fatalf("claim %d: %v", i+1, err)
+		}
+	}
+
+	ran, err := u.Execute(context.Background())
+	if err != nil || ran {
+		t.Fatalf("Execute = %v, %v; want nothing to run", ran, err)
+	}
+	job, err := jobs.GetByID(perfSummaryTestUser, "j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != repositories.SummaryJobStatusFailed || job.Error == "" || job.Attempts != summaryJobMaxAttempts {
+		t.Fatalf("job = %+v, want failed after %d attempts", job, summaryJobMaxAttempts)
+	}
+	if len(f.provider.Requests()) != 0 {
+		t.Fatal("exhausted job reached the model")
+	}
+}
+
+func TestRunSummaryJobShutdownKeepsAttempts(t *testing.T) {
+	_, jobs, u := newSummaryJobFixture(t, answer(`{"goals":[]}`))
+	stopped, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Остановки сервера возвращают задачу в очередь, не расходуя попытки
+	for i := 0; i < summaryJobMaxAttempts+1; i++ {
+		if ran, err := u.Execute(stopped); err != nil || !ran {
+			t.Fatalf("Execute while stopping = %v, %v", ran, err)
+		}
+		job, _ := jobs.GetByID(perfSummaryTestUser, "j1")
+		if job.Status != repositories.SummaryJobStatusQueued || job.Attempts != 0 {
+			t.Fatalf("job after a shutdown = %s after %d attempts, want queued after 0", job.Status, job.Attempts)
+		}
+	}
+
+	if ran, err := u.Execute(context.Background()); err != nil || !ran {
+		t.Fatalf("Execute = %v, %v", ran, err)
+	}
+	job, _ := jobs.GetByID(perfSummaryTestUser, "j1")
+	if job.Status != repositories.SummaryJobStatusSucceeded || job.Attempts != 1 || job.ResultSummaryID == "" {
+		t.Fatalf("job = %+v, want succeeded on the first attempt", job)
+	}
+}
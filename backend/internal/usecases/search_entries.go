@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Ограничения выдачи поиска по записям
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// ErrEmptySearchQuery возвращается, если поисковый запрос пуст
+var ErrEmptySearchQuery = errors.New("search query is required")
+
+// SearchEntriesQuery представляет запрос полнотекстового поиска по записям.
+// Limit <= 0 означает значение по умолчанию
+type SearchEntriesQuery struct {
+	UserID string
+	Q      string
+	Limit  int
+}
+
+// SearchEntriesUsecase отвечает за полнотекстовый поиск по записям пользователя
+type SearchEntriesUsecase struct {
+	repo     repositories.EntriesRepository
+	tagsRepo repositories.TagsRepository
+}
+
+// NewSearchEntriesUsecase создает новый экземпляр SearchEntriesUsecase
+func NewSearchEntriesUsecase(repo repositories.EntriesRepository, tagsRepo repositories.TagsRepository) *SearchEntriesUsecase {
+	return &SearchEntriesUsecase{
+		repo:     repo,
+		tagsRepo: tagsRepo,
+	}
+}
+
+// Execute выполняет поиск и возвращает записи с рангом и сниппетом, самые релевантные первыми
+func (u *SearchEntriesUsecase) Execute(query SearchEntriesQuery) ([]repositories.EntrySearchResult, error) {
+	q := strings.TrimSpace(query.Q)
+	if q == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	results, err := u.repo.Search(query.UserID, q, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]repositories.Entry, len(results))
+	for i, r := range results {
+		entries[i] = r.Entry
+	}
+	if err := withEntryTags(u.tagsRepo, query.UserID, entries); err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Entry = entries[i]
+	}
+	return results, nil
+}
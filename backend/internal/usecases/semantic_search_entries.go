@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/llm"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// SemanticSearchEntriesQuery представляет запрос семантического поиска по записям.
+// Limit <= 0 означает значение по умолчанию
+type SemanticSearchEntriesQuery struct {
+	UserID string
+	Q      string
+	Limit  int
+}
+
+// SemanticSearchEntriesUsecase отвечает за поиск записей по смыслу запроса, а не по словам
+type SemanticSearchEntriesUsecase struct {
+	entries    repositories.EntriesRepository
+	embeddings repositories.EntryEmbeddingsRepository
+	tagsRepo   repositories.TagsRepository
+	embedder   llm.Embedder
+}
+
+// NewSemanticSearchEntriesUsecase создает новый экземпляр SemanticSearchEntriesUsecase.
+// embedder может быть nil — тогда Execute возвращает ErrSemanticSearchDisabled
+func NewSemanticSearchEntriesUsecase(entries repositories.EntriesRepository, embeddings repositories.EntryEmbeddingsRepository, tagsRepo repositories.TagsRepository, embedder llm.Embedder) *SemanticSearchEntriesUsecase {
+	return &SemanticSearchEntriesUsecase{
+		entries:    entries,
+		embeddings: embeddings,
+		tagsRepo:   tagsRepo,
+		embedder:   embedder,
+	}
+}
+
+// Execute возвращает записи пользователя, ближайшие по смыслу к запросу.
+// Записи, которые фоновая индексация ещё не обработала, в выдачу не попадают
+func (u *SemanticSearchEntriesUsecase) Execute(ctx context.Context, query SemanticSearchEntriesQuery) ([]SimilarEntry, error) {
+	q := strings.TrimSpace(query.Q)
+	if q == "" {
+		return nil, ErrEmptySearchQuery
+	}
+	if u.embedder == nil {
+		return nil, ErrSemanticSearchDisabled
+	}
+
+	vectors, err := u.embedder.Embed(ctx, []string{q})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for 1 query", len(vectors))
+	}
+
+	similar, err := u.embeddings.Nearest(query.UserID, u.embedder.Model(), vectors[0], similarLimit(query.Limit), "")
+	if err != nil {
+		return nil, err
+	}
+	return loadSimilarEntries(u.entries, u.tagsRepo, query.UserID, similar)
+}
@@ -0,0 +1,394 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/dailynotes"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// SyncDailyNotesUsecase синхронизирует записи пользователя с каталогом ежедневных заметок в обе стороны.
+// Для каждой даты и типа записи сравниваются текст записи и раздел заметки с тем, что было согласовано в прошлый раз:
+//   - изменилась только заметка — текст раздела сохраняется в запись, пустой раздел удаляет запись;
+//   - изменилась только запись — её текст пишется в раздел, при необходимости заметка создаётся;
+//   - изменились обе стороны по-разному — побеждает изменённая позже (время файла против updated_at записи),
+//     проигравший текст попадает в журнал конфликтов.
+//
+// Удалённая заметка или удалённый из неё раздел записи не удаляют: заметку могли перенести в архив.
+// Раздел вернётся в заметку, когда запись снова изменится.
+//
+// Первый проход и затем раз в dailyNotesFullSyncInterval читают все заметки и все записи.
+// Остальные проходы читают только файлы, у которых с прошлого прохода изменились время или размер,
+// и записи, изменённые с начала прошлого прохода. Удаление записи такой проход не видит:
+// раздел заметки очистится на ближайшем полном проходе.
+// Проходы выполняются по одному (BatchWorker), поэтому состояние между ними не защищено мьютексом
+type SyncDailyNotesUsecase struct {
+	vault       *dailynotes.Vault
+	userID      string
+	entriesRepo repositories.EntriesRepository
+	syncRepo    repositories.DailyNotesSyncRepository
+	createEntry *CreateEntryUsecase
+	deleteEntry *DeleteEntryUsecase
+
+	// files — файлы, прочитанные прошлыми проходами; nil до первого прохода
+	files        map[string]dailynotes.NoteFile
+	lastPass     time.Time
+	lastFullPass time.Time
+}
+
+// dailyNotesFullSyncInterval — как часто проход читает все заметки и записи, а не только изменённые
+const dailyNotesFullSyncInterval = 10 * time.Minute
+
+// NewSyncDailyNotesUsecase создает новый экземпляр SyncDailyNotesUsecase для заметок пользователя userID
+func NewSyncDailyNotesUsecase(vault *dailynotes.Vault, userID string, entriesRepo repositories.EntriesRepository, syncRepo repositories.DailyNotesSyncRepository, createEntry *CreateEntryUsecase, deleteEntry *DeleteEntryUsecase) *SyncDailyNotesUsecase {
+	return &SyncDailyNotesUsecase{
+		vault:       vault,
+		userID:      userID,
+		entriesRepo: entriesRepo,
+		syncRepo:    syncRepo,
+		createEntry: createEntry,
+		deleteEntry: deleteEntry,
+	}
+}
+
+// dailyNoteKey — дата и тип записи, которые синхронизируются с одним разделом заметки
+type dailyNoteKey struct {
+	date      string
+	entryType repositories.EntryType
+}
+
+// dailyNotesPass — то, что проход прочитал: заметки по датам, записи и состояния по ключам
+type dailyNotesPass struct {
+	notes   map[string]dailynotes.Note
+	entries map[dailyNoteKey]repositories.Entry
+	states  map[dailyNoteKey]repositories.DailyNoteSyncState
+	keys    map[dailyNoteKey]bool
+}
+
+// addNote добавляет заметку и ключи её разделов
+func (p *dailyNotesPass) addNote(note dailynotes.Note) {
+	p.notes[note.Date] = note
+	for entryType := range note.Sections {
+		p.keys[dailyNoteKey{note.Date, entryType}] = true
+	}
+}
+
+// addEntry добавляет запись и её ключ
+func (p *dailyNotesPass) addEntry(e repositories.Entry) {
+	key := dailyNoteKey{entryDay(e), e.Type}
+	p.entries[key] = e
+	p.keys[key] = true
+}
+
+// Execute выполняет один проход синхронизации. Всегда возвращает false:
+// следующий проход BatchWorker запускает через обычный интервал опроса
+func (u *SyncDailyNotesUsecase) Execute(ctx context.Context) (bool, error) {
+	started := time.Now().UTC()
+	full := u.files == nil || started.Sub(u.lastFullPass) >= dailyNotesFullSyncInterval
+
+	files, err := u.vault.List()
+	if err != nil {
+		return false, err
+	}
+	filesByDate := make(map[string]dailynotes.NoteFile, len(files))
+	for _, f := range files {
+		filesByDate[f.Date] = f
+	}
+
+	var pass *dailyNotesPass
+	if full {
+		pass, err = u.readAll(files)
+	} else {
+		pass, err = u.readChanged(files, filesByDate)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Обходим в порядке дат, план перед фактом: так новые заметки получают разделы в привычном порядке
+	ordered := make([]dailyNoteKey, 0, len(pass.keys))
+	for key := range pass.keys {
+		ordered = append(ordered, key)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].date != ordered[j].date {
+			return ordered[i].date < ordered[j].date
+		}
+		return ordered[i].entryType == repositories.EntryTypePlan && ordered[j].entryType != repositories.EntryTypePlan
+	})
+
+	for _, key := range ordered {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		entry, entryFound := pass.entries[key]
+		state, stateFound := pass.states[key]
+		err := u.syncKey(key, pass.notes, entry, entryFound, state, stateFound)
+		if errors.Is(err, dailynotes.ErrNoteChanged) {
+			// Заметку отредактировали после того, как проход её прочитал: перечитываем и согласуем заново,
+			// чтобы правка из редактора дошла до записи или попала в журнал конфликтов, а не была затёрта
+			note, readErr := u.vault.Read(key.date)
+			if readErr != nil && !errors.Is(readErr, fs.ErrNotExist) {
+				return false, readErr
+			}
+			pass.notes[key.date] = note
+			err = u.syncKey(key, pass.notes, entry, entryFound, state, stateFound)
+		}
+		if errors.Is(err, dailynotes.ErrNoteChanged) {
+			log.Printf("daily notes sync: %s.md keeps changing, %s %s is left for the next pass", key.date, key.date, key.entryType)
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	u.files = filesByDate
+	u.lastPass = started
+	if full {
+		u.lastFullPass = started
+	}
+	return false, nil
+}
+
+// readAll читает все заметки, все записи пользователя и все состояния синхронизации
+func (u *SyncDailyNotesUsecase) readAll(files []dailynotes.NoteFile) (*dailyNotesPass, error) {
+	pass := newDailyNotesPass()
+	for _, f := range files {
+		if err := u.readNote(pass, f.Date); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := u.entriesRepo.ListByUserAndPeriod(u.userID, archiveMinDate, archiveMaxDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		pass.addEntry(e)
+	}
+
+	states, err := u.syncRepo.ListStates(u.userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range states {
+		key := dailyNoteKey{s.Date, s.Type}
+		pass.states[key] = s
+		pass.keys[key] = true
+	}
+	return pass, nil
+}
+
+// readChanged читает заметки, изменившиеся с прошлого прохода, и записи, изменённые с его начала.
+// К ним дочитываются вторые стороны: записи за даты изменённых заметок и заметки за даты изменённых записей
+func (u *SyncDailyNotesUsecase) readChanged(files []dailynotes.NoteFile, filesByDate map[string]dailynotes.NoteFile) (*dailyNotesPass, error) {
+	pass := newDailyNotesPass()
+	for _, f := range files {
+		if u.files[f.Date] == f {
+			continue
+		}
+		if err := u.readNote(pass, f.Date); err != nil {
+			return nil, err
+		}
+	}
+
+	// Записи за даты изменённых заметок: без них изменение заметки нельзя сравнить с записью
+	for date := range pass.notes {
+		entries, err := u.entriesRepo.ListByUserAndDate(u.userID, date)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			pass.addEntry(e)
+		}
+	}
+
+	updated, err := u.entriesRepo.ListByUserUpdatedSince(u.userID, u.lastPass)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range updated {
+		pass.addEntry(e)
+		date := entryDay(e)
+		if _, loaded := pass.notes[date]; loaded {
+			continue
+		}
+		if _, exists := filesByDate[date]; exists {
+			if err := u.readNote(pass, date); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for key := range pass.keys {
+		state, err := u.syncRepo.GetState(u.userID, key.date, key.entryType)
+		if errors.Is(err, repositories.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pass.states[key] = state
+	}
+	return pass, nil
+}
+
+// readNote читает заметку за дату в проход; заметку, удалённую после чтения каталога, пропускает
+func (u *SyncDailyNotesUsecase) readNote(pass *dailyNotesPass, date string) error {
+	note, err := u.vault.Read(date)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	pass.addNote(note)
+	return nil
+}
+
+// newDailyNotesPass создает пустой dailyNotesPass
+func newDailyNotesPass() *dailyNotesPass {
+	return &dailyNotesPass{
+		notes:   make(map[string]dailynotes.Note),
+		entries: make(map[dailyNoteKey]repositories.Entry),
+		states:  make(map[dailyNoteKey]repositories.DailyNoteSyncState),
+		keys:    make(map[dailyNoteKey]bool),
+	}
+}
+
+// syncKey согласует одну запись с разделом заметки. notes — заметки прохода по датам; после записи в заметку
+// в notes попадает её новый вид. Если заметка изменилась после чтения, возвращает dailynotes.ErrNoteChanged
+func (u *SyncDailyNotesUsecase) syncKey(key dailyNoteKey, notes map[string]dailynotes.Note, entry repositories.Entry, entryFound bool, state repositories.DailyNoteSyncState, stateFound bool) error {
+	note := notes[key.date]
+	if !stateFound {
+		// Ещё не согласованные стороны считаем пустыми: непустая сторона выглядит изменённой
+		state.FileHash, state.EntryHash = textHash(""), textHash("")
+	}
+
+	fileText, fileKnown := note.Sections[key.entryType]
+	entryText := ""
+	if entryFound {
+		entryText = entry.RawText
+	}
+	fileChanged := fileKnown && textHash(fileText) != state.FileHash
+	entryChanged := textHash(entryText) != state.EntryHash
+
+	switch {
+	case !fileChanged && !entryChanged:
+		return nil
+	case fileChanged && entryChanged && fileText != strings.TrimSpace(entryText):
+		return u.resolveConflict(key, notes, fileText, entry, entryFound)
+	case fileChanged && entryChanged:
+		// Обе стороны пришли к одному тексту
+		return u.saveState(key, fileText, entryText)
+	case fileChanged:
+		return u.pushToEntry(key, fileText, entry, entryFound)
+	default:
+		return u.pushToNote(key, notes, entryText)
+	}
+}
+
+// resolveConflict выбирает сторону, изменённую позже, и записывает проигравший текст в журнал.
+// Время удаления записи неизвестно, поэтому удалённая запись проигрывает изменённой заметке
+func (u *SyncDailyNotesUsecase) resolveConflict(key dailyNoteKey, notes map[string]dailynotes.Note, fileText string, entry repositories.Entry, entryFound bool) error {
+	note := notes[key.date]
+	now := time.Now().UTC()
+	conflict := repositories.DailyNoteConflict{
+		ID:             now.Format("20060102150405.000000000"),
+		UserID:         u.userID,
+		Date:           key.date,
+		Type:           key.entryType,
+		Winner:         repositories.DailyNoteSideFile,
+		FileText:       fileText,
+		FileModifiedAt: note.ModTime,
+		CreatedAt:      now,
+	}
+	if entryFound {
+		updatedAt := entry.UpdatedAt
+		conflict.EntryText = entry.RawText
+		conflict.EntryUpdatedAt = &updatedAt
+		if updatedAt.After(note.ModTime) {
+			conflict.Winner = repositories.DailyNoteSideEntry
+		}
+	}
+	if err := u.syncRepo.AddConflict(conflict); err != nil {
+		return err
+	}
+	log.Printf("daily notes sync: %s %s changed in both %s.md and the service, keeping the %s version", key.date, key.entryType, key.date, conflict.Winner)
+
+	if conflict.Winner == repositories.DailyNoteSideEntry {
+		return u.pushToNote(key, notes, entry.RawText)
+	}
+	return u.pushToEntry(key, fileText, entry, entryFound)
+}
+
+// pushToEntry сохраняет текст раздела в запись; пустой раздел удаляет запись вместе с её связями и тегами
+func (u *SyncDailyNotesUsecase) pushToEntry(key dailyNoteKey, fileText string, entry repositories.Entry, entryFound bool) error {
+	if fileText == "" {
+		if entryFound {
+			err := u.deleteEntry.Execute(DeleteEntryCommand{UserID: u.userID, IDOrDate: entry.ID})
+			if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+				return err
+			}
+		}
+		return u.syncRepo.DeleteState(u.userID, key.date, key.entryType)
+	}
+
+	saved, err := u.createEntry.Execute(CreateEntryCommand{
+		UserID:  u.userID,
+		Date:    key.date,
+		Type:    key.entryType,
+		RawText: fileText,
+	})
+	if err != nil {
+		return err
+	}
+	return u.saveState(key, fileText, saved.RawText)
+}
+
+// pushToNote пишет текст записи в раздел заметки, если заметка не изменилась с тех пор, как проход её прочитал.
+// Удалённая запись очищает раздел, но не создаёт заметку или раздел, если их нет
+func (u *SyncDailyNotesUsecase) pushToNote(key dailyNoteKey, notes map[string]dailynotes.Note, entryText string) error {
+	if _, fileKnown := notes[key.date].Sections[key.entryType]; strings.TrimSpace(entryText) == "" && !fileKnown {
+		return u.syncRepo.DeleteState(u.userID, key.date, key.entryType)
+	}
+	note, err := u.vault.WriteSection(key.date, key.entryType, entryText, notes[key.date])
+	if err != nil {
+		return err
+	}
+
+	// Запоминаем раздел в том виде, в каком он записан: правку, сделанную сразу после записи,
+	// следующий проход увидит как изменение заметки
+	notes[key.date] = note
+	return u.saveState(key, note.Sections[key.entryType], entryText)
+}
+
+// saveState запоминает согласованный вид обеих сторон; если обе пусты, состояние больше не нужно
+func (u *SyncDailyNotesUsecase) saveState(key dailyNoteKey, fileText, entryText string) error {
+	if fileText == "" && entryText == "" {
+		return u.syncRepo.DeleteState(u.userID, key.date, key.entryType)
+	}
+	return u.syncRepo.SaveState(repositories.DailyNoteSyncState{
+		UserID:    u.userID,
+		Date:      key.date,
+		Type:      key.entryType,
+		FileHash:  textHash(fileText),
+		EntryHash: textHash(entryText),
+		SyncedAt:  time.Now().UTC(),
+	})
+}
+
+// textHash возвращает SHA-256 текста в hex
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,232 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/dailynotes"
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// dailyNotesFixture — каталог заметок во временной папке и синхронизация с in-memory хранилищами
+type dailyNotesFixture struct {
+	t        *testing.T
+	dir      string
+	entries  *repositories.InMemoryEntriesRepository
+	syncRepo *repositories.InMemoryDailyNotesSyncRepository
+	create   *CreateEntryUsecase
+	update   *UpdateEntryUsecase
+	delete   *DeleteEntryUsecase
+	sync     *SyncDailyNotesUsecase
+}
+
+const dailyNotesTestUser = "u1"
+
+func newDailyNotesFixture(t *testing.T) *dailyNotesFixture {
+	f := &dailyNotesFixture{
+		t:        t,
+		dir:      t.TempDir(),
+		entries:  repositories.NewInMemoryEntriesRepository(),
+		syncRepo: repositories.NewInMemoryDailyNotesSyncRepository(),
+	}
+	tags := repositories.NewInMemoryTagsRepository()
+	f.create = NewCreateEntryUsecase(f.entries, tags)
+	f.update = NewUpdateEntryUsecase(f.entries, tags)
+	f.delete = NewDeleteEntryUsecase(f.entries, repositories.NewInMemoryGoalEntryLinksRepository(), tags)
+	f.sync = NewSyncDailyNotesUsecase(dailynotes.NewVault(f.dir, "Plan", "Fact"), dailyNotesTestUser, f.entries, f.syncRepo, f.create, f.delete)
+	return f
+}
+
+// pass выполняет проход синхронизации; full — сделать его полным, как раз в dailyNotesFullSyncInterval
+func (f *dailyNotesFixture) pass(full bool) {
+	f.t.Helper()
+	if full {
+		f.sync.lastFullPass = time.Time{}
+	}
+	if _, err := f.sync.Execute(context.Background()); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// writeNote записывает заметку с заданным временем изменения
+func (f *dailyNotesFixture) writeNote(date, content string, modTime time.Time) {
+	f.t.Helper()
+	path := filepath.Join(f.dir, date+".md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		f.t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// note возвращает содержимое заметки; пустую строку, если её нет
+func (f *dailyNotesFixture) note(date string) string {
+	f.t.Helper()
+	content, err := os.ReadFile(filepath.Join(f.dir, date+".md"))
+	if errors.Is(err, os.ErrNotExist) {
+		return ""
+	}
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return string(content)
+}
+
+// entry возвращает запись за дату и тип
+func (f *dailyNotesFixture) entry(date string, entryType repositories.EntryType) (repositories.Entry, bool) {
+	f.t.Helper()
+	entries, err := f.entries.ListByUserAndDate(dailyNotesTestUser, date)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Type == entryType {
+			return e, true
+		}
+	}
+	return repositories.Entry{}, false
+}
+
+// synced создаёт заметку с планом и проходом синхронизации превращает её в запись
+func (f *dailyNotesFixture) synced(date, plan string) repositories.Entry {
+	f.t.Helper()
+	f.writeNote(date, "# Day\n\n## Plan\n"+plan+"\n", time.Now().Add(-time.Hour))
+	f.pass(false)
+	e, found := f.entry(date, repositories.EntryTypePlan)
+	if !found || e.RawText != plan {
+		f.t.Fatalf("entry after the first pass = %+v, found %v", e, found)
+	}
+	return e
+}
+
+func TestSyncDailyNotesFileChanged(t *testing.T) {
+	f := newDailyNotesFixture(t)
+	f.synced("2026-01-05", "- do A")
+
+	f.writeNote("2026-01-05", "# Day\n\n## Plan\n- do B\n", time.Now().Add(time.Minute))
+	f.pass(false)
+
+	if e, _ := f.entry("2026-01-05", repositories.EntryTypePlan); e.RawText != "- do B" {
+		t.Fatalf("entry = %q, want the note edit", e.RawText)
+	}
+	if conflicts, _ := f.syncRepo.ListConflicts(dailyNotesTestUser, 10); len(conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %+v", conflicts)
+	}
+}
+
+func TestSyncDailyNotesEntryChanged(t *testing.T) {
+	f := newDailyNotesFixture(t)
+	e := f.synced("2026-01-05", "- do A")
+
+	if _, err := f.update.Execute(UpdateEntryCommand{UserID: dailyNotesTestUser, ID: e.ID, RawText: "- do B"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.create.Execute(CreateEntryCommand{UserID: dailyNotesTestUser, Date: "2026-01-06", Type: repositories.EntryTypeFact, RawText: "- did C"}); err != nil {
+		t.Fatal(err)
+	}
+	f.pass(false)
+
+	if got := f.note("2026-01-05"); got != "# Day\n\n## Plan\n- do B\n" {
+		t.Fatalf("note = %q", got)
+	}
+	if got := f.note("2026-01-06"); got != "## Fact\n- did C\n" {
+		t.Fatalf("new note = %q", got)
+	}
+}
+
+func TestSyncDailyNotesBothChanged(t *testing.T) {
+	cases := []struct {
+		name       string
+		noteAge    time.Duration
+		wantWinner string
+		wantText   string
+	}{
+		{name: "note edited later", noteAge: -time.Hour, wantWinner: repositories.DailyNoteSideFile, wantText: "- from the note"},
+		{name: "entry edited later", noteAge: time.Hour, wantWinner: repositories.DailyNoteSideEntry, wantText: "- from the service"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newDailyNotesFixture(t)
+			e := f.synced("2026-01-05", "- do A")
+
+			if _, err := f.update.Execute(UpdateEntryCommand{UserID: dailyNotesTestUser, ID: e.ID, RawText: "- from the service"}); err != nil {
+				t.Fatal(err)
+			}
+			f.writeNote("2026-01-05", "# Day\n\n## Plan\n- from the note\n", time.Now().Add(-tc.noteAge))
+			f.pass(false)
+
+			got, _ := f.entry("2026-01-05", repositories.EntryTypePlan)
+			if got.RawText != tc.wantText {
+				t.Fatalf("entry = %q, want %q", got.RawText, tc.wantText)
+			}
+			if note := f.note("2026-01-05"); note != "# Day\n\n## Plan\n"+tc.wantText+"\n" {
+				t.Fatalf("note = %q", note)
+			}
+			conflicts, err := f.syncRepo.ListConflicts(dailyNotesTestUser, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(conflicts) != 1 || conflicts[0].Winner != tc.wantWinner {
+				t.Fatalf("conflicts = %+v, want one won by %s", conflicts, tc.wantWinner)
+			}
+			if conflicts[0].FileText != "- from the note" || conflicts[0].EntryText != "- from the service" {
+				t.Fatalf("conflict lost a side: %+v", conflicts[0])
+			}
+
+			// Согласованное состояние не порождает новых конфликтов
+			f.pass(true)
+			if conflicts, _ := f.syncRepo.ListConflicts(dailyNotesTestUser, 10); len(conflicts) != 1 {
+				t.Fatalf("got %d conflicts after a repeated pass", len(conflicts))
+			}
+		})
+	}
+}
+
+func TestSyncDailyNotesEntryDeleted(t *testing.T) {
+	f := newDailyNotesFixture(t)
+	e := f.synced("2026-01-05", "- do A")
+
+	if err := f.delete.Execute(DeleteEntryCommand{UserID: dailyNotesTestUser, IDOrDate: e.ID}); err != nil {
+		t.Fatal(err)
+	}
+	// Удаление записи видит только полный проход
+	f.pass(false)
+	if got := f.note("2026-01-05"); got != "# Day\n\n## Plan\n- do A\n" {
+		t.Fatalf("incremental pass changed the note: %q", got)
+	}
+	f.pass(true)
+
+	if got := f.note("2026-01-05"); got != "# Day\n\n## Plan\n" {
+		t.Fatalf("note = %q, want the plan section cleared", got)
+	}
+	if _, found := f.entry("2026-01-05", repositories.EntryTypePlan); found {
+		t.Fatal("cleared section recreated the entry")
+	}
+	if _, err := f.syncRepo.GetState(dailyNotesTestUser, "2026-01-05", repositories.EntryTypePlan); !errors.Is(err, repositories.ErrNotFound) {
+		t.Fatalf("state should be removed, got %v", err)
+	}
+}
+
+func TestSyncDailyNotesNoteDeleted(t *testing.T) {
+	f := newDailyNotesFixture(t)
+	f.synced("2026-01-05", "- do A")
+
+	if err := os.Remove(filepath.Join(f.dir, "2026-01-05.md")); err != nil {
+		t.Fatal(err)
+	}
+	f.pass(false)
+	f.pass(true)
+
+	if e, found := f.entry("2026-01-05", repositories.EntryTypePlan); !found || e.RawText != "- do A" {
+		t.Fatalf("entry = %+v, found %v; deleting the note must keep the entry", e, found)
+	}
+	if got := f.note("2026-01-05"); got != "" {
+		t.Fatalf("unchanged entry recreated the note: %q", got)
+	}
+}
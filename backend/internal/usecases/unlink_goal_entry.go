@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// UnlinkGoalEntryCommand представляет команду для отвязки записи от цели
+type UnlinkGoalEntryCommand struct {
+	UserID  string
+	GoalID  string
+	EntryID string
+}
+
+// UnlinkGoalEntryUsecase отвечает за отвязку записей от целей
+type UnlinkGoalEntryUsecase struct {
+	goalsRepo repositories.GoalsRepository
+	linksRepo repositories.GoalEntryLinksRepository
+}
+
+// NewUnlinkGoalEntryUsecase создает новый экземпляр UnlinkGoalEntryUsecase
+func NewUnlinkGoalEntryUsecase(goalsRepo repositories.GoalsRepository, linksRepo repositories.GoalEntryLinksRepository) *UnlinkGoalEntryUsecase {
+	return &UnlinkGoalEntryUsecase{
+		goalsRepo: goalsRepo,
+		linksRepo: linksRepo,
+	}
+}
+
+// Execute выполняет отвязку записи от цели
+func (u *UnlinkGoalEntryUsecase) Execute(cmd UnlinkGoalEntryCommand) error {
+	if _, err := u.goalsRepo.GetByID(cmd.UserID, cmd.GoalID); err != nil {
+		return err
+	}
+	return u.linksRepo.Delete(cmd.UserID, cmd.GoalID, cmd.EntryID)
+}
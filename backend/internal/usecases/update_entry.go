@@ -1,41 +1,60 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
 )
 
-// UpdateEntryCommand представляет команду для обновления записи
+// UpdateEntryCommand представляет команду для обновления записи.
+// Tags == nil оставляет теги записи без изменений
 type UpdateEntryCommand struct {
+	UserID  string
 	ID      string
 	RawText string
+	Tags    []string
 }
 
 // UpdateEntryUsecase отвечает за обновление записи
 type UpdateEntryUsecase struct {
-	repo repositories.EntriesRepository
+	repo     repositories.EntriesRepository
+	tagsRepo repositories.TagsRepository
 }
 
 // NewUpdateEntryUsecase создает новый экземпляр UpdateEntryUsecase
-func NewUpdateEntryUsecase(repo repositories.EntriesRepository) *UpdateEntryUsecase {
+func NewUpdateEntryUsecase(repo repositories.EntriesRepository, tagsRepo repositories.TagsRepository) *UpdateEntryUsecase {
 	return &UpdateEntryUsecase{
-		repo: repo,
+		repo:     repo,
+		tagsRepo: tagsRepo,
 	}
 }
 
 // Execute выполняет обновление записи
 func (u *UpdateEntryUsecase) Execute(cmd UpdateEntryCommand) (repositories.Entry, error) {
-	// Получаем существующую запись
-	// В данном случае мы просто обновляем запись напрямую, так как у нас нет отдельного метода получения по ID
-	entry := repositories.Entry{
-		ID:      cmd.ID,
-		RawText: cmd.RawText,
+	tags, err := prepareEntryTags(cmd.Tags)
+	if err != nil {
+		return repositories.Entry{}, err
 	}
 
-	err := u.repo.Update(entry)
+	// Получаем существующую запись; чужая запись для репозитория не существует
+	entry, err := u.repo.GetByID(cmd.UserID, cmd.ID)
 	if err != nil {
 		return repositories.Entry{}, err
 	}
+	// Новый текст обогатится заново в фоне
+	if entry.RawText != cmd.RawText {
+		entry.LLMEnriched = nil
+	}
+	entry.RawText = cmd.RawText
+	entry.UpdatedAt = time.Now().UTC()
+
+	if err := u.repo.Update(cmd.UserID, entry); err != nil {
+		return repositories.Entry{}, err
+	}
+
+	if err := saveEntryTags(u.tagsRepo, &entry, tags); err != nil {
+		return repositories.Entry{}, err
+	}
 
-	// Возвращаем обновленную запись (в реальной реализации нужно было бы получить её из репозитория)
 	return entry, nil
 }
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"strings"
+	"time"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// UpdateGoalCommand представляет команду для обновления цели
+type UpdateGoalCommand struct {
+	UserID      string
+	ID          string
+	Title       string
+	Description string
+}
+
+// UpdateGoalUsecase отвечает за обновление названия и описания цели
+type UpdateGoalUsecase struct {
+	repo repositories.GoalsRepository
+}
+
+// NewUpdateGoalUsecase создает новый экземпляр UpdateGoalUsecase
+func NewUpdateGoalUsecase(repo repositories.GoalsRepository) *UpdateGoalUsecase {
+	return &UpdateGoalUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет обновление цели. Статус меняется только через ChangeGoalStatusUsecase
+func (u *UpdateGoalUsecase) Execute(cmd UpdateGoalCommand) (repositories.Goal, error) {
+	title, err := validateGoalTitle(cmd.Title)
+	if err != nil {
+		return repositories.Goal{}, err
+	}
+
+	goal, err := u.repo.GetByID(cmd.UserID, cmd.ID)
+	if err != nil {
+		return repositories.Goal{}, err
+	}
+
+	goal.Title = title
+	goal.Description = strings.TrimSpace(cmd.Description)
+	goal.UpdatedAt = time.Now().UTC()
+
+	if err := u.repo.Update(cmd.UserID, goal); err != nil {
+		return repositories.Goal{}, err
+	}
+
+	return goal, nil
+}
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/inkuroshev/perf-assist-backend/internal/repositories"
+)
+
+// Ограничения профиля пользователя
+const (
+	maxUserNameLength        = 255
+	minPerfCycleLengthMonths = 1
+	maxPerfCycleLengthMonths = 24
+)
+
+var (
+	ErrUserNameTooLong        = errors.New("name must be at most 255 characters")
+	ErrInvalidUserRole        = errors.New("role must be one of: engineer, lead, manager, mixed")
+	ErrInvalidPerfCycleLength = errors.New("perf_cycle_length_months must be between 1 and 24")
+	ErrInvalidUserLocale      = errors.New("locale must be one of: ru, en")
+)
+
+// userLocales — поддерживаемые языки интерфейса и генерации
+var userLocales = map[string]bool{"ru": true, "en": true}
+
+// UpdateUserProfileCommand представляет команду для обновления профиля.
+// Поля со значением nil не меняются.
+type UpdateUserProfileCommand struct {
+	UserID                string
+	Name                  *string
+	Role                  *string
+	PerfCycleLengthMonths *int
+	Locale                *string
+}
+
+// UpdateUserProfileUsecase отвечает за обновление профиля текущего пользователя
+type UpdateUserProfileUsecase struct {
+	repo repositories.UsersRepository
+}
+
+// NewUpdateUserProfileUsecase создает новый экземпляр UpdateUserProfileUsecase
+func NewUpdateUserProfileUsecase(repo repositories.UsersRepository) *UpdateUserProfileUsecase {
+	return &UpdateUserProfileUsecase{
+		repo: repo,
+	}
+}
+
+// Execute выполняет обновление профиля. Если профиля ещё нет, он создаётся
+func (u *UpdateUserProfileUsecase) Execute(cmd UpdateUserProfileCommand) (repositories.User, error) {
+	user, err := u.repo.GetByID(cmd.UserID)
+	exists := err == nil
+	if errors.Is(err, repositories.ErrNotFound) {
+		user = newDefaultUser(cmd.UserID)
+	} else if err != nil {
+		return repositories.User{}, err
+	}
+
+	if cmd.Name != nil {
+		name := strings.TrimSpace(*cmd.Name)
+		if utf8.RuneCountInString(name) > maxUserNameLength {
+			return repositories.User{}, ErrUserNameTooLong
+		}
+		user.Name = name
+	}
+	if cmd.Role != nil {
+		role := repositories.UserRole(*cmd.Role)
+		if !isKnownUserRole(role) {
+			return repositories.User{}, ErrInvalidUserRole
+		}
+		user.Role = role
+	}
+	if cmd.PerfCycleLengthMonths != nil {
+		months := *cmd.PerfCycleLengthMonths
+		if months < minPerfCycleLengthMonths || months > maxPerfCycleLengthMonths {
+			return repositories.User{}, ErrInvalidPerfCycleLength
+		}
+		user.PerfCycleLengthMonths = months
+	}
+	if cmd.Locale != nil {
+		if !userLocales[*cmd.Locale] {
+			return repositories.User{}, ErrInvalidUserLocale
+		}
+		user.Locale = *cmd.Locale
+	}
+	user.UpdatedAt = time.Now().UTC()
+
+	if exists {
+		err = u.repo.Update(user)
+	} else {
+		err = u.repo.Create(user)
+	}
+	if err != nil {
+		return repositories.User{}, err
+	}
+
+	return user, nil
+}
+
+// isKnownUserRole проверяет, что роль входит в список поддерживаемых
+func isKnownUserRole(role repositories.UserRole) bool {
+	switch role {
+	case repositories.UserRoleEngineer, repositories.UserRoleLead, repositories.UserRoleManager, repositories.UserRoleMixed:
+		return true
+	}
+	return false
+}
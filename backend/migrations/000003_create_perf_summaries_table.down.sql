@@ -0,0 +1 @@
+DROP TABLE IF EXISTS perf_summaries;
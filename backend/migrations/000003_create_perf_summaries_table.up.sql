@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS perf_summaries (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    raw_goals JSONB NOT NULL DEFAULT '[]'::jsonb,
+    summary_text TEXT NOT NULL DEFAULT '',
+    bullets JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_perf_summaries_user_id_created_at ON perf_summaries(user_id, created_at DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS goal_entry_links (
+    id VARCHAR(255) PRIMARY KEY,
+    goal_id VARCHAR(255) NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    entry_id VARCHAR(255) NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    relevance_score DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (relevance_score >= 0 AND relevance_score <= 1),
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_goal_entry_links_goal_id_entry_id ON goal_entry_links(goal_id, entry_id);
+CREATE INDEX IF NOT EXISTS idx_goal_entry_links_entry_id ON goal_entry_links(entry_id);
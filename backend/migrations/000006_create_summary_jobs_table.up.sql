@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS summary_jobs (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    role VARCHAR(32) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    progress INTEGER NOT NULL DEFAULT 0 CHECK (progress >= 0 AND progress <= 100),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    result_summary_id VARCHAR(255) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Частичный индекс для быстрой выборки очереди воркерами
+CREATE INDEX IF NOT EXISTS idx_summary_jobs_queued ON summary_jobs(created_at) WHERE status = 'queued';
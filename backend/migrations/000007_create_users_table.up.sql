@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    role VARCHAR(16) NOT NULL DEFAULT 'engineer' CHECK (role IN ('engineer', 'lead', 'manager', 'mixed')),
+    perf_cycle_length_months INTEGER NOT NULL DEFAULT 6 CHECK (perf_cycle_length_months BETWEEN 1 AND 24),
+    locale VARCHAR(8) NOT NULL DEFAULT 'ru' CHECK (locale IN ('ru', 'en')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
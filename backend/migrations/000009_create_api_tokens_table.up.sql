@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    token_prefix VARCHAR(16) NOT NULL,
+    scopes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_tokens_token_hash ON api_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_id ON api_tokens (user_id, created_at DESC) WHERE revoked_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_goal_entry_links_user_id_goal_id;
+
+ALTER TABLE goal_entry_links DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,11 @@
+-- Владелец связи дублируется из цели, чтобы репозиторий ограничивал все запросы пользователем
+ALTER TABLE goal_entry_links ADD COLUMN IF NOT EXISTS user_id VARCHAR(255);
+
+UPDATE goal_entry_links l
+SET user_id = g.user_id
+FROM goals g
+WHERE g.id = l.goal_id AND l.user_id IS NULL;
+
+ALTER TABLE goal_entry_links ALTER COLUMN user_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_goal_entry_links_user_id_goal_id ON goal_entry_links(user_id, goal_id);
@@ -0,0 +1,5 @@
+ALTER TABLE perf_summaries DROP COLUMN IF EXISTS tags;
+ALTER TABLE summary_jobs DROP COLUMN IF EXISTS tags;
+
+DROP TABLE IF EXISTS entry_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_id_name ON tags(user_id, name);
+
+CREATE TABLE IF NOT EXISTS entry_tags (
+    entry_id VARCHAR(255) NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    tag_id VARCHAR(255) NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (entry_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_tags_user_id_tag_id ON entry_tags(user_id, tag_id);
+
+-- Набор тегов, которым ограничена генерация саммари (пустой — все записи)
+ALTER TABLE summary_jobs ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE perf_summaries ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'::jsonb;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_entries_pending_enrichment;
+
+ALTER TABLE entries DROP COLUMN IF EXISTS llm_enriched;
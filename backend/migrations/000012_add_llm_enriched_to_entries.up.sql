@@ -0,0 +1,4 @@
+-- Результат обогащения записи моделью; NULL — запись ещё не обработана
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS llm_enriched JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_entries_pending_enrichment ON entries(created_at) WHERE llm_enriched IS NULL;
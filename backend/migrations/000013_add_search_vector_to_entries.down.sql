@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_entries_search_vector;
+
+ALTER TABLE entries DROP COLUMN IF EXISTS search_vector;
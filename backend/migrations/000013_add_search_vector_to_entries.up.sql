@@ -0,0 +1,7 @@
+-- Полнотекстовый поиск по записям. Конфигурация russian стеммит русские слова,
+-- а слова латиницей передаёт english_stem, поэтому смешанные записи ("настроил Redis caching")
+-- индексируются с учётом обоих языков
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('russian', raw_text)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_entries_search_vector ON entries USING GIN (search_vector);
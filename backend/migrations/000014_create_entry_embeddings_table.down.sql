@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS entry_embeddings;
+
+-- Расширение vector не удаляем: оно могло быть установлено до миграции
@@ -0,0 +1,21 @@
+-- pgvector необязателен: без него близость векторов считается в приложении
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS vector;
+EXCEPTION WHEN OTHERS THEN
+    RAISE NOTICE 'pgvector is not available, semantic search falls back to in-process cosine similarity';
+END
+$$;
+
+-- Эмбеддинги записей. Размерность зависит от модели, поэтому вектор хранится как REAL[]
+CREATE TABLE IF NOT EXISTS entry_embeddings (
+    entry_id VARCHAR(255) NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    model VARCHAR(255) NOT NULL,
+    text_hash VARCHAR(32) NOT NULL,
+    embedding REAL[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entry_id, model)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_embeddings_user_id_model ON entry_embeddings(user_id, model);
@@ -0,0 +1,12 @@
+-- Кэш саммари дня или недели: одна строка на период пользователя.
+-- entries_hash — хеш записей периода; при их изменении саммари генерируется заново
+CREATE TABLE IF NOT EXISTS local_summaries (
+    user_id VARCHAR(255) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    entries_hash VARCHAR(64) NOT NULL,
+    bullets JSONB NOT NULL DEFAULT '[]'::jsonb,
+    candidate_goals JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, period_start, period_end)
+);
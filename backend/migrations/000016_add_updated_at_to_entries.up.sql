@@ -0,0 +1,5 @@
+-- Время последнего изменения текста записи. Для существующих записей им становится время создания
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+UPDATE entries SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE entries ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE entries ALTER COLUMN updated_at SET NOT NULL;
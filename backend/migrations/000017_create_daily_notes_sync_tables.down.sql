@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS daily_note_conflicts;
+DROP TABLE IF EXISTS daily_note_sync_states;
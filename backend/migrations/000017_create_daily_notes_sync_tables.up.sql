@@ -0,0 +1,27 @@
+-- Состояние синхронизации записей с ежедневными заметками: хеши текста записи и раздела заметки
+-- на момент последнего согласования, по одной строке на дату и тип записи пользователя
+CREATE TABLE IF NOT EXISTS daily_note_sync_states (
+    user_id VARCHAR(255) NOT NULL,
+    date DATE NOT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('plan', 'fact')),
+    file_hash VARCHAR(64) NOT NULL,
+    entry_hash VARCHAR(64) NOT NULL,
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, date, type)
+);
+
+-- Журнал конфликтов синхронизации: текст обеих сторон и то, какая из них победила
+CREATE TABLE IF NOT EXISTS daily_note_conflicts (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    date DATE NOT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('plan', 'fact')),
+    winner VARCHAR(10) NOT NULL CHECK (winner IN ('file', 'entry')),
+    file_text TEXT NOT NULL,
+    entry_text TEXT NOT NULL,
+    file_modified_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    entry_updated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_note_conflicts_user_id_created_at ON daily_note_conflicts(user_id, created_at);
@@ -0,0 +1,11 @@
+-- Ошибки провайдера эмбеддингов по записям: запись, на которой провайдер ошибается раз за разом,
+-- уходит в конец очереди индексации, а после нескольких попыток пропускается до изменения текста
+CREATE TABLE IF NOT EXISTS entry_embedding_failures (
+    entry_id VARCHAR(255) NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    model VARCHAR(255) NOT NULL,
+    text_hash VARCHAR(32) NOT NULL,
+    attempts INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entry_id, model)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_summary_jobs_running_lease;
+ALTER TABLE summary_jobs DROP COLUMN IF EXISTS lease_expires_at;
+ALTER TABLE summary_jobs DROP COLUMN IF EXISTS claimed_at;
@@ -0,0 +1,7 @@
+-- Аренда задачи воркером: claimed_at — когда задачу забрали (по нему воркер узнаёт, что задача всё ещё его),
+-- lease_expires_at — до какого момента воркер её держит. Задачу running с истёкшей арендой забирает любой воркер.
+-- У задач, захваченных до появления аренды, обе колонки пустые, и они считаются истёкшими
+ALTER TABLE summary_jobs ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE summary_jobs ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_summary_jobs_running_lease ON summary_jobs(lease_expires_at) WHERE status = 'running';